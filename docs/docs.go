@@ -15,32 +15,21 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/tags": {
+        "/namespaces": {
             "get": {
-                "description": "Get a list of tags filtered by the name parameter",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get every tag namespace with its settings sorted by name",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Tags"
-                ],
-                "summary": "Retrieve a list of tags",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Name of the tag to filter by",
-                        "name": "name",
-                        "in": "query"
-                    }
+                    "Namespaces"
                 ],
+                "summary": "List namespaces",
                 "responses": {
                     "200": {
-                        "description": "Successful retrieval of tags",
+                        "description": "Namespaces",
                         "schema": {
-                            "$ref": "#/definitions/tag.GetTagsResponse"
+                            "$ref": "#/definitions/tag.GetNamespacesResponse"
                         }
                     },
                     "500": {
@@ -55,7 +44,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Add a tag with the provided information",
+                "description": "Create a tag namespace with its settings",
                 "consumes": [
                     "application/json"
                 ],
@@ -63,25 +52,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tags"
+                    "Namespaces"
                 ],
-                "summary": "Add a new tag",
+                "summary": "Create namespace",
                 "parameters": [
                     {
-                        "description": "Tag Object",
-                        "name": "tag",
+                        "description": "Namespace",
+                        "name": "namespace",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/tag.SaveTagRequest"
+                            "$ref": "#/definitions/tag.SaveNamespaceRequest"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Successfully created tag",
+                        "description": "Successfully created namespace",
                         "schema": {
-                            "$ref": "#/definitions/models.Tag"
+                            "$ref": "#/definitions/tag.Namespace"
                         }
                     },
                     "400": {
@@ -93,6 +82,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -105,30 +103,39 @@ const docTemplate = `{
                 }
             }
         },
-        "/tags/{id}": {
+        "/namespaces/{name}": {
             "get": {
-                "description": "Get tag by id from the database",
+                "description": "Get a tag namespace by name",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Tags"
+                    "Namespaces"
                 ],
-                "summary": "Retrieve a tag",
+                "summary": "Get namespace",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Tag ID",
-                        "name": "id",
+                        "description": "Namespace name",
+                        "name": "name",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved a tag",
+                        "description": "Namespace",
                         "schema": {
-                            "$ref": "#/definitions/tag.Tag"
+                            "$ref": "#/definitions/tag.Namespace"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
@@ -143,7 +150,7 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "Update a tag with the provided information",
+                "description": "Update the settings of a tag namespace, they apply to the tags created or renamed afterwards",
                 "consumes": [
                     "application/json"
                 ],
@@ -151,32 +158,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tags"
+                    "Namespaces"
                 ],
-                "summary": "Update tag",
+                "summary": "Update namespace",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Tag ID",
-                        "name": "id",
+                        "description": "Namespace name",
+                        "name": "name",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Tag Object",
-                        "name": "tag",
+                        "description": "Settings, the name is taken from the path",
+                        "name": "namespace",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/tag.SaveTagRequest"
+                            "$ref": "#/definitions/tag.SaveNamespaceRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully updated a tag",
+                        "description": "Successfully updated namespace",
                         "schema": {
-                            "$ref": "#/definitions/tag.Tag"
+                            "$ref": "#/definitions/tag.Namespace"
                         }
                     },
                     "400": {
@@ -188,6 +195,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -200,22 +216,19 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Delete a tag with the provided information",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Remove a tag namespace without tags, along with its deleted tags. The default namespace cannot be removed",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Tags"
+                    "Namespaces"
                 ],
-                "summary": "Delete tag",
+                "summary": "Remove namespace",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Tag ID",
-                        "name": "id",
+                        "description": "Namespace name",
+                        "name": "name",
                         "in": "path",
                         "required": true
                     }
@@ -239,6 +252,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -250,49 +272,1496 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "models.Tag": {
-            "type": "object",
-            "properties": {
-                "id": {
-                    "type": "string"
-                },
-                "name": {
-                    "description": "Fields",
-                    "type": "string"
-                }
-            }
         },
-        "tag.GetTagsResponse": {
-            "type": "object",
-            "properties": {
-                "tags": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/tag.Tag"
+        "/tags": {
+            "get": {
+                "description": "Get a list of tags filtered by the name and parent_id parameters",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Retrieve a list of tags",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Name of the tag to filter by",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only the tags right below this tag",
+                        "name": "parent_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace of the tags, the default one when empty",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA timezone of the *_local fields, e.g. Europe/Paris",
+                        "name": "X-Timezone",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful retrieval of tags",
+                        "schema": {
+                            "$ref": "#/definitions/tag.GetTagsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
-            }
-        },
-        "tag.SaveTagRequest": {
-            "type": "object",
-            "properties": {
-                "name": {
-                    "type": "string"
+            },
+            "post": {
+                "description": "Add a tag with the provided information",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Add a new tag",
+                "parameters": [
+                    {
+                        "description": "Tag Object",
+                        "name": "tag",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/tag.SaveTagRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA timezone of the *_local fields, e.g. Europe/Paris",
+                        "name": "X-Timezone",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Successfully created tag",
+                        "schema": {
+                            "$ref": "#/definitions/models.Tag"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Parent Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
                 }
             }
         },
-        "tag.Tag": {
-            "type": "object",
-            "properties": {
-                "id": {
-                    "type": "string"
-                },
-                "name": {
-                    "description": "Fields",
-                    "type": "string"
+        "/tags/by-slug/{slug}": {
+            "get": {
+                "description": "Get tag by the slug derived from its name, the slug of an alias resolves to its tag with redirected_from set",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Retrieve a tag by slug",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace of the tag, the default one when empty",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA timezone of the *_local fields, e.g. Europe/Paris",
+                        "name": "X-Timezone",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved a tag",
+                        "schema": {
+                            "$ref": "#/definitions/tag.Tag"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/{id}": {
+            "get": {
+                "description": "Get tag by id from the database, the id of an alias resolves to its tag with redirected_from set",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Retrieve a tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace of the tag, the default one when empty",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA timezone of the *_local fields, e.g. Europe/Paris",
+                        "name": "X-Timezone",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved a tag",
+                        "schema": {
+                            "$ref": "#/definitions/tag.Tag"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update a tag with the provided information",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Update tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tag Object",
+                        "name": "tag",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/tag.SaveTagRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA timezone of the *_local fields, e.g. Europe/Paris",
+                        "name": "X-Timezone",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully updated a tag",
+                        "schema": {
+                            "$ref": "#/definitions/tag.Tag"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a tag with the provided information",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Delete tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace of the tag, the default one when empty",
+                        "name": "namespace",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/{id}/aliases": {
+            "get": {
+                "description": "Get the aliases of a tag sorted by name",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "List tag aliases",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace of the tag, the default one when empty",
+                        "name": "namespace",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Aliases",
+                        "schema": {
+                            "$ref": "#/definitions/tag.TagAliases"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Add an alternative name resolved to the tag",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Add a tag alias",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Alias, its tag_id is taken from the path",
+                        "name": "alias",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/tag.AddTagAliasRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Successfully added alias",
+                        "schema": {
+                            "$ref": "#/definitions/tag.TagAlias"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/{id}/aliases/{alias_id}": {
+            "delete": {
+                "description": "Remove an alias of a tag by id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Remove a tag alias",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Alias ID",
+                        "name": "alias_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace of the tag, the default one when empty",
+                        "name": "namespace",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/{id}/ancestors": {
+            "get": {
+                "description": "Get the ancestors of a tag from its root down to its parent",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "List tag ancestors",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace of the tag, the default one when empty",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA timezone of the *_local fields, e.g. Europe/Paris",
+                        "name": "X-Timezone",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ancestors",
+                        "schema": {
+                            "$ref": "#/definitions/tag.GetTagsResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/{id}/children": {
+            "get": {
+                "description": "Get the tags right below a tag sorted by name",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "List tag children",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace of the tag, the default one when empty",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA timezone of the *_local fields, e.g. Europe/Paris",
+                        "name": "X-Timezone",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Children",
+                        "schema": {
+                            "$ref": "#/definitions/tag.GetTagsResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/{id}/parent": {
+            "put": {
+                "description": "Move a tag and its subtree under another parent, or to the roots with an empty parent_id. Rejects cycles and subtrees deeper than the maximum depth",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Move tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New parent, the id is taken from the path",
+                        "name": "move",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/tag.MoveTagRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA timezone of the *_local fields, e.g. Europe/Paris",
+                        "name": "X-Timezone",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Moved tag",
+                        "schema": {
+                            "$ref": "#/definitions/tag.Tag"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/{id}/subtree": {
+            "get": {
+                "description": "Get every descendant of a tag with its depth, level by level",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "List a tag subtree",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace of the tag, the default one when empty",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA timezone of the *_local fields, e.g. Europe/Paris",
+                        "name": "X-Timezone",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Descendants",
+                        "schema": {
+                            "$ref": "#/definitions/tag.TagSubtree"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/{id}/usage": {
+            "post": {
+                "description": "Add to the usage count of a tag, which ranks the autocompletions. Called by the services tagging items, with a negative delta when they untag them",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Record tag usage",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Delta, 1 when 0, the id is taken from the path",
+                        "name": "usage",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/tag.RecordTagUsageRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA timezone of the *_local fields, e.g. Europe/Paris",
+                        "name": "X-Timezone",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tag with its new usage count",
+                        "schema": {
+                            "$ref": "#/definitions/tag.Tag"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/tags:autocomplete": {
+            "get": {
+                "description": "Suggest the tags whose name starts with the prefix, ignoring case, the most used first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Autocomplete tags",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start of the tag names",
+                        "name": "prefix",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of suggestions",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace of the tags, the default one when empty",
+                        "name": "namespace",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Suggestions",
+                        "schema": {
+                            "$ref": "#/definitions/tag.AutocompleteTagsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/tags:merge": {
+            "post": {
+                "description": "Soft delete the source tags, turn their names into aliases of the target and move their aliases, children and usage counts to it, in one transaction. A dry run reports the changes without applying them",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Merge tags",
+                "parameters": [
+                    {
+                        "description": "Sources and target",
+                        "name": "merge",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/tag.MergeTagsRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA timezone of the *_local fields, e.g. Europe/Paris",
+                        "name": "X-Timezone",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Changes",
+                        "schema": {
+                            "$ref": "#/definitions/tag.MergeTagsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/tags:search": {
+            "get": {
+                "description": "Search tags by name, ranking exact, prefix and fuzzy matches. Fuzzy matches tolerate typos down to the similarity threshold",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Search tags",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Text to search in tag names",
+                        "name": "query",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum similarity, between 0 and 1, of a fuzzy match",
+                        "name": "threshold",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of matches",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace of the tags, the default one when empty",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA timezone of the *_local fields, e.g. Europe/Paris",
+                        "name": "X-Timezone",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ranked matches",
+                        "schema": {
+                            "$ref": "#/definitions/tag.SearchTagsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "models.Tag": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Fields",
+                    "type": "string"
+                },
+                "namespace": {
+                    "description": "Namespace is the vocabulary of the tag, the name and slug are unique\nwithin it.",
+                    "type": "string"
+                },
+                "parent_id": {
+                    "description": "ParentID places the tag in the hierarchy, nil for the roots",
+                    "type": "string"
+                },
+                "slug": {
+                    "description": "Slug identifies the tag in URLs, see tagname.Slug. It is unique so\nnames differing only by case or punctuation conflict.",
+                    "type": "string"
+                },
+                "usage_count": {
+                    "description": "UsageCount ranks the autocompletions, the most used tags first",
+                    "type": "integer"
+                }
+            }
+        },
+        "tag.AddTagAliasRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "description": "Namespace of the tag, the default one when empty",
+                    "type": "string"
+                },
+                "tag_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "tag.AutocompleteTagsResponse": {
+            "type": "object",
+            "properties": {
+                "suggestions": {
+                    "description": "The most used tags first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tag.TagSuggestion"
+                    }
+                }
+            }
+        },
+        "tag.GetNamespacesResponse": {
+            "type": "object",
+            "properties": {
+                "namespaces": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tag.Namespace"
+                    }
+                }
+            }
+        },
+        "tag.GetTagsResponse": {
+            "type": "object",
+            "properties": {
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tag.Tag"
+                    }
+                }
+            }
+        },
+        "tag.Highlight": {
+            "type": "object",
+            "properties": {
+                "end": {
+                    "description": "Offset following the last matched character",
+                    "type": "integer"
+                },
+                "start": {
+                    "type": "integer"
+                }
+            }
+        },
+        "tag.MergeTagsRequest": {
+            "type": "object",
+            "properties": {
+                "dry_run": {
+                    "description": "Reports the changes without applying them",
+                    "type": "boolean"
+                },
+                "namespace": {
+                    "description": "Namespace of the sources and the target, the default one when empty",
+                    "type": "string"
+                },
+                "source_ids": {
+                    "description": "Tags merged into the target, soft deleted",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "target_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "tag.MergeTagsResponse": {
+            "type": "object",
+            "properties": {
+                "created_aliases": {
+                    "description": "The aliases created from the names of the sources, sharing their ids",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tag.TagAlias"
+                    }
+                },
+                "dry_run": {
+                    "description": "Set when nothing was applied",
+                    "type": "boolean"
+                },
+                "moved_aliases": {
+                    "description": "The aliases of the sources moved to the target",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tag.TagAlias"
+                    }
+                },
+                "moved_children": {
+                    "description": "The children of the sources moved under the target",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tag.Tag"
+                    }
+                },
+                "sources": {
+                    "description": "The merged tags before the merge",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tag.Tag"
+                    }
+                },
+                "target": {
+                    "description": "The target after the merge, its usage count includes the ones of the sources",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/tag.Tag"
+                        }
+                    ]
+                }
+            }
+        },
+        "tag.MoveTagRequest": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "description": "Namespace of the tag and its parent, the default one when empty",
+                    "type": "string"
+                },
+                "parent_id": {
+                    "description": "New parent of the tag, empty to make it a root",
+                    "type": "string"
+                }
+            }
+        },
+        "tag.Namespace": {
+            "type": "object",
+            "properties": {
+                "allowed_characters": {
+                    "description": "Characters allowed in the names of the tags and aliases: any, ascii or alphanumeric",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "Timestamps in UTC, RFC 3339",
+                    "type": "string"
+                },
+                "max_tags": {
+                    "description": "Maximum number of tags, unlimited when 0",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "tag_count": {
+                    "description": "Number of tags of the namespace",
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "tag.RecordTagUsageRequest": {
+            "type": "object",
+            "properties": {
+                "delta": {
+                    "description": "Added to the usage count, 1 when 0. Negative when the tag is removed\nfrom items, the count never goes below 0",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "description": "Namespace of the tag, the default one when empty",
+                    "type": "string"
+                }
+            }
+        },
+        "tag.SaveNamespaceRequest": {
+            "type": "object",
+            "properties": {
+                "allowed_characters": {
+                    "description": "Characters allowed in the names of the tags and aliases created or renamed afterwards, any when empty",
+                    "type": "string"
+                },
+                "max_tags": {
+                    "description": "Maximum number of tags, unlimited when 0. Lowering it below the current count only prevents new tags",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "Lower case letters, digits and single hyphens. Cannot be changed",
+                    "type": "string"
+                }
+            }
+        },
+        "tag.SaveTagRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "description": "Namespace of the tag, the default one when empty. UpdateTag looks the tag up in it, tags cannot change namespace",
+                    "type": "string"
+                },
+                "parent_id": {
+                    "description": "Parent of the created tag, empty for a root. Ignored by UpdateTag, see MoveTag",
+                    "type": "string"
+                }
+            }
+        },
+        "tag.SearchTagsResponse": {
+            "type": "object",
+            "properties": {
+                "matches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tag.TagMatch"
+                    }
+                }
+            }
+        },
+        "tag.Tag": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "Timestamps in UTC, RFC 3339",
+                    "type": "string"
+                },
+                "created_at_local": {
+                    "description": "Timestamps in the timezone requested with the X-Timezone header, or the server timezone",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Fields",
+                    "type": "string"
+                },
+                "namespace": {
+                    "description": "Vocabulary of the tag, the name and slug are unique within it",
+                    "type": "string"
+                },
+                "parent_id": {
+                    "description": "Parent in the hierarchy, empty for the roots",
+                    "type": "string"
+                },
+                "redirected_from": {
+                    "description": "The alias resolved to reach this tag, when it was looked up by the ID or slug of an alias",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/tag.TagAlias"
+                        }
+                    ]
+                },
+                "slug": {
+                    "description": "Identifies the tag in URLs, derived from the name",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_at_local": {
+                    "type": "string"
+                },
+                "usage_count": {
+                    "description": "Number of uses of the tag, ranking the autocompletions",
+                    "type": "integer"
+                }
+            }
+        },
+        "tag.TagAlias": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "Timestamp in UTC, RFC 3339",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "tag_id": {
+                    "description": "ID of the canonical tag",
+                    "type": "string"
+                }
+            }
+        },
+        "tag.TagAliases": {
+            "type": "object",
+            "properties": {
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tag.TagAlias"
+                    }
+                }
+            }
+        },
+        "tag.TagMatch": {
+            "type": "object",
+            "properties": {
+                "alias": {
+                    "description": "Alias matching the query, the highlights are spans of it. Empty when the name of the tag matched",
+                    "type": "string"
+                },
+                "highlights": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tag.Highlight"
+                    }
+                },
+                "score": {
+                    "description": "Trigram similarity of the query to the name, between 0 and 1",
+                    "type": "number"
+                },
+                "tag": {
+                    "$ref": "#/definitions/tag.Tag"
+                },
+                "tier": {
+                    "description": "exact, prefix or fuzzy, from the best to the worst",
+                    "type": "string"
+                }
+            }
+        },
+        "tag.TagNode": {
+            "type": "object",
+            "properties": {
+                "depth": {
+                    "description": "Levels below the root of the subtree, 1 for its children",
+                    "type": "integer"
+                },
+                "tag": {
+                    "$ref": "#/definitions/tag.Tag"
+                }
+            }
+        },
+        "tag.TagSubtree": {
+            "type": "object",
+            "properties": {
+                "nodes": {
+                    "description": "Descendants level by level, then by name",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tag.TagNode"
+                    }
+                }
+            }
+        },
+        "tag.TagSuggestion": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "usage_count": {
+                    "type": "integer"
                 }
             }
         }
@@ -0,0 +1,38 @@
+package database
+
+import (
+	"gorm.io/gorm"
+)
+
+// registerCallbacks registers before and after hooks of a plugin around each
+// GORM operation. after receives the operation name.
+func registerCallbacks(db *gorm.DB, plugin string, before func(*gorm.DB), after func(operation string) func(*gorm.DB)) error {
+	cb := db.Callback()
+	for _, err := range []error{
+		cb.Create().Before("gorm:create").Register(plugin+":before_create", before),
+		cb.Create().After("gorm:create").Register(plugin+":after_create", after("create")),
+		cb.Query().Before("gorm:query").Register(plugin+":before_query", before),
+		cb.Query().After("gorm:query").Register(plugin+":after_query", after("query")),
+		cb.Update().Before("gorm:update").Register(plugin+":before_update", before),
+		cb.Update().After("gorm:update").Register(plugin+":after_update", after("update")),
+		cb.Delete().Before("gorm:delete").Register(plugin+":before_delete", before),
+		cb.Delete().After("gorm:delete").Register(plugin+":after_delete", after("delete")),
+		cb.Row().Before("gorm:row").Register(plugin+":before_row", before),
+		cb.Row().After("gorm:row").Register(plugin+":after_row", after("row")),
+		cb.Raw().Before("gorm:raw").Register(plugin+":before_raw", before),
+		cb.Raw().After("gorm:raw").Register(plugin+":after_raw", after("raw")),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tableName returns the table of the statement, bounded for raw queries.
+func tableName(db *gorm.DB) string {
+	if db.Statement.Table == "" {
+		return "unknown"
+	}
+	return db.Statement.Table
+}
@@ -1,53 +1,212 @@
 package database
 
 import (
-	"log"
-	"os"
-	"strconv"
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
 
-	"gorm.io/driver/postgres"
-	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
-	"gorm.io/plugin/dbresolver"
-)
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/config"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/metrics"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/timezone"
 
-var (
-	DB  *gorm.DB
-	err error
+	"gorm.io/gorm"
 )
 
-// DbConnection create database connection
-func DbConnection(masterDSN, replicaDSN string) error {
-	var db = DB
+// Options are the optional dependencies of a Database.
+type Options struct {
+	// Name tells the databases of one process apart in the pool metrics and
+	// logs. The pools of an unnamed database are named primary and replica-N.
+	Name string
+	// Production disables the capture of slow query plans, see
+	// database.slow_query.explain.
+	Production bool
+}
 
-	logMode, _ := strconv.ParseBool(os.Getenv("DB_LOG_MODE"))
-	debug, _ := strconv.ParseBool(os.Getenv("DEBUG"))
+// Database is a connection to the primary database and its replicas.
+// Databases are independent, several of them can be used in one process.
+type Database struct {
+	name     string
+	driver   string
+	dialect  dialect
+	db       *gorm.DB
+	primary  *sql.DB
+	replicas *replicaRouter
+	tx       config.TransactionConfiguration
+	// unregister removes the pool metrics on Close
+	unregister []func()
+}
 
-	loglevel := logger.Silent
-	if logMode {
-		loglevel = logger.Info
+// New connects to the primary database and the replicas of cfg. Reads are
+// routed to the replicas, see replicaRouter.
+func New(ctx context.Context, cfg config.DatabaseConfiguration, opts Options) (*Database, error) {
+	dialect, ok := dialects[cfg.Driver]
+	if !ok {
+		return nil, fmt.Errorf("unsupported database driver %q", cfg.Driver)
+	}
+	d := &Database{
+		name:     opts.Name,
+		driver:   cfg.Driver,
+		dialect:  dialect,
+		replicas: newReplicaRouter(cfg.Replication, dialect.lagQuery),
+		tx:       cfg.Transaction,
 	}
 
-	db, err = gorm.Open(postgres.Open(masterDSN), &gorm.Config{
-		Logger: logger.Default.LogMode(loglevel),
+	// gorm.Open pings the primary, so a failed attempt is retried
+	err := connectWithRetry(ctx, d.poolName("primary"), cfg.Master.ConnectRetry, func() error {
+		db, err := gorm.Open(dialect.open(cfg.Master.DSN(cfg.Driver)), &gorm.Config{
+			Logger: queryLogger{all: cfg.LogMode, slowThreshold: cfg.SlowQuery.Threshold},
+			// persist every timestamp in UTC
+			NowFunc: timezone.Now,
+		})
+		if err != nil {
+			// a failed ping leaves the pool open
+			if db != nil {
+				if sqlDB, dbErr := db.DB(); dbErr == nil {
+					sqlDB.Close()
+				}
+			}
+			return err
+		}
+		d.db = db
+		return nil
 	})
-	if !debug {
-		db.Use(dbresolver.Register(dbresolver.Config{
-			Replicas: []gorm.Dialector{
-				postgres.Open(replicaDSN),
-			},
-			Policy: dbresolver.RandomPolicy{},
-		}))
+	if err != nil {
+		return nil, err
 	}
+	d.primary, err = d.db.DB()
 	if err != nil {
-		log.Fatalf("Db connection error")
+		return nil, fmt.Errorf("failed to get the primary pool: %w", err)
+	}
+	configurePool(d.primary, cfg.Master.Pool)
+
+	if err := d.setup(ctx, cfg, opts); err != nil {
+		d.Close()
+		return nil, err
+	}
+	return d, nil
+}
+
+// setup opens the replicas and registers the plugins and metrics.
+func (d *Database) setup(ctx context.Context, cfg config.DatabaseConfiguration, opts Options) error {
+	if err := d.registerStats(d.primary, d.poolName("primary")); err != nil {
 		return err
 	}
-	DB = db
+	for i, replicaCfg := range cfg.Replicas() {
+		r, err := openReplica(ctx, d.poolName(fmt.Sprintf("replica-%d", i)), d.driver, replicaCfg)
+		if err != nil {
+			return fmt.Errorf("failed to open replica %d: %w", i, err)
+		}
+		d.replicas.replicas = append(d.replicas.replicas, r)
+		if err := d.registerStats(r.db, r.name); err != nil {
+			return err
+		}
+	}
+	// start with the replicas that are reachable and caught up
+	d.replicas.check(ctx)
+
+	plugins := []gorm.Plugin{d.replicas, metricsPlugin{slowThreshold: cfg.SlowQuery.Threshold}, tracingPlugin{system: d.dialect.system}}
+	if cfg.SlowQuery.Explain && cfg.SlowQuery.Threshold > 0 && d.dialect.explain != "" && !opts.Production {
+		plugins = append(plugins, explainPlugin{threshold: cfg.SlowQuery.Threshold, explain: d.dialect.explain})
+	}
+	for _, plugin := range plugins {
+		if err := d.db.Use(plugin); err != nil {
+			return fmt.Errorf("failed to register the %s plugin: %w", plugin.Name(), err)
+		}
+	}
 	return nil
 }
 
-// GetDB connection
-func GetDB() *gorm.DB {
-	return DB
+// registerStats exports the statistics of a pool until the database is closed.
+func (d *Database) registerStats(db *sql.DB, name string) error {
+	unregister, err := metrics.RegisterDBStats(db, name)
+	if err != nil {
+		return fmt.Errorf("failed to register the %s pool metrics: %w", name, err)
+	}
+	d.unregister = append(d.unregister, unregister)
+	return nil
+}
+
+// poolName prefixes name with the name of the database.
+func (d *Database) poolName(name string) string {
+	if d.name == "" {
+		return name
+	}
+	return d.name + "-" + name
+}
+
+// WithContext returns a session bound to ctx, the entry point of every query.
+// The session belongs to the transaction started by WithTx, if any.
+func (d *Database) WithContext(ctx context.Context) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{db: d}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return d.db.WithContext(ctx)
+}
+
+// Driver returns the database.driver of the database: postgres, mysql or
+// sqlite.
+func (d *Database) Driver() string {
+	return d.driver
+}
+
+// Gorm returns the underlying GORM connection.
+func (d *Database) Gorm() *gorm.DB {
+	return d.db
+}
+
+// PingPrimary verifies the connection to the primary database.
+func (d *Database) PingPrimary(ctx context.Context) error {
+	return d.primary.PingContext(ctx)
+}
+
+// ReplicaChecks returns a health check per replica, by pool name. A check
+// fails while the replica is ejected from the reads.
+func (d *Database) ReplicaChecks() map[string]func(ctx context.Context) error {
+	checks := make(map[string]func(ctx context.Context) error, len(d.replicas.replicas))
+	for _, r := range d.replicas.replicas {
+		r := r
+		checks[r.name] = func(ctx context.Context) error {
+			if !r.isHealthy() {
+				reason, _ := r.reason.Load().(string)
+				return fmt.Errorf("ejected from the reads: %s", reason)
+			}
+			return r.db.PingContext(ctx)
+		}
+	}
+	return checks
+}
+
+// MonitorReplicas checks the health of the replicas every
+// database.replication.check_interval until ctx is done, ejecting the
+// unreachable or lagging ones.
+func (d *Database) MonitorReplicas(ctx context.Context) {
+	if len(d.replicas.replicas) == 0 {
+		return
+	}
+	d.replicas.monitor(ctx)
+}
+
+// Close closes the primary and replica connection pools.
+func (d *Database) Close() error {
+	for _, unregister := range d.unregister {
+		unregister()
+	}
+	d.unregister = nil
+
+	var errs []string
+	for _, replica := range d.replicas.replicas {
+		if err := replica.db.Close(); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", replica.name, err))
+		}
+	}
+	if d.primary != nil {
+		if err := d.primary.Close(); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", d.poolName("primary"), err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to close database: %s", strings.Join(errs, "; "))
+	}
+	return nil
 }
@@ -0,0 +1,30 @@
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/config"
+)
+
+// newTestDatabase opens a SQLite database in a temporary directory, closed
+// at the end of the test. tune adjusts the default configuration.
+func newTestDatabase(t testing.TB, tune func(cfg *config.DatabaseConfiguration)) *Database {
+	t.Helper()
+	cfg := config.Default().Database
+	cfg.Driver = "sqlite"
+	cfg.Master.Dbname = filepath.Join(t.TempDir(), "test.db")
+	if tune != nil {
+		tune(&cfg)
+	}
+	// pool metrics are registered globally, every test needs its own names
+	name := strings.NewReplacer("/", "-", " ", "-").Replace(t.Name())
+	db, err := New(context.Background(), cfg, Options{Name: name})
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
@@ -0,0 +1,41 @@
+// Package dbtest opens SQLite databases for the tests.
+package dbtest
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/adapters/database"
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/adapters/database/migrations"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/config"
+)
+
+// Empty returns a SQLite database without any migration in a temporary
+// directory, closed at the end of the test.
+func Empty(t testing.TB) *database.Database {
+	t.Helper()
+	cfg := config.Default().Database
+	cfg.Driver = "sqlite"
+	cfg.Master.Dbname = filepath.Join(t.TempDir(), "test.db")
+	// pool metrics are registered globally, every test needs its own names
+	name := strings.NewReplacer("/", "-", " ", "-").Replace(t.Name())
+	db, err := database.New(context.Background(), cfg, database.Options{Name: name})
+	if err != nil {
+		t.Fatalf("database New() error: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// Open returns an empty SQLite database with every migration applied, see
+// Empty.
+func Open(t testing.TB) *database.Database {
+	t.Helper()
+	db := Empty(t)
+	if err := migrations.Up(context.Background(), db); err != nil {
+		t.Fatalf("migrations Up() error: %v", err)
+	}
+	return db
+}
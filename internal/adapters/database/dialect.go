@@ -0,0 +1,84 @@
+package database
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/glebarez/sqlite"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgconn"
+	_ "github.com/jackc/pgx/v4/stdlib"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	gormmysql "gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// dialect describes how to use one database server, selected by
+// database.driver.
+type dialect struct {
+	// driverName is the database/sql driver of the replica pools.
+	driverName string
+	open       func(dsn string) gorm.Dialector
+	// lagQuery returns the replication lag of a replica in seconds. Replicas
+	// are only pinged when it is empty.
+	lagQuery string
+	// retryable reports whether err aborted a transaction that may succeed
+	// when run again.
+	retryable func(err error) bool
+	// explain prefixes a query to get its plan as a single JSON value, slow
+	// query plans are not captured when empty.
+	explain string
+	// system is the db.system attribute of the query spans.
+	system attribute.KeyValue
+}
+
+var dialects = map[string]dialect{
+	"postgres": {driverName: "pgx", open: postgres.Open, lagQuery: replicaLagQuery, retryable: postgresRetryable, explain: "EXPLAIN (ANALYZE, FORMAT JSON) ", system: semconv.DBSystemPostgreSQL},
+	"mysql":    {driverName: "mysql", open: gormmysql.Open, retryable: mysqlRetryable, explain: "EXPLAIN FORMAT=JSON ", system: semconv.DBSystemMySQL},
+	"sqlite":   {driverName: sqlite.DriverName, open: sqlite.Open, retryable: func(error) bool { return false }, system: semconv.DBSystemSqlite},
+}
+
+// Postgres error codes of the failures fixed by running a transaction again.
+const (
+	serializationFailure = "40001"
+	deadlockDetected     = "40P01"
+)
+
+// mysqlDeadlock is the MySQL error number of a transaction chosen as the
+// victim of a deadlock.
+const mysqlDeadlock = 1213
+
+func postgresRetryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	return pgErr.Code == serializationFailure || pgErr.Code == deadlockDetected
+}
+
+func mysqlRetryable(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlDeadlock
+}
+
+// likeEscape escapes the LIKE wildcards, it is the escape character of the
+// patterns built by ContainsPattern and PrefixPattern.
+const likeEscape = "!"
+
+var likeReplacer = strings.NewReplacer(likeEscape, likeEscape+likeEscape, "%", likeEscape+"%", "_", likeEscape+"_")
+
+// ContainsPattern returns a lower case LIKE pattern matching the values
+// containing s, to be used as `LOWER(column) LIKE ? ESCAPE '!'`. Lowering
+// both sides keeps the match case-insensitive on every driver, whatever the
+// collation of the column.
+func ContainsPattern(s string) string {
+	return "%" + likeReplacer.Replace(strings.ToLower(s)) + "%"
+}
+
+// PrefixPattern returns a lower case LIKE pattern matching the values
+// starting with s, see ContainsPattern.
+func PrefixPattern(s string) string {
+	return likeReplacer.Replace(strings.ToLower(s)) + "%"
+}
@@ -0,0 +1,69 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/logger"
+
+	"gorm.io/gorm"
+)
+
+const explainStartKey = "explain:start"
+
+type explainKey struct{}
+
+// ExplainIfSlow returns a copy of ctx whose slow reads have their plan
+// logged, when database.slow_query.explain is set outside production.
+func ExplainIfSlow(ctx context.Context) context.Context {
+	return context.WithValue(ctx, explainKey{}, true)
+}
+
+// explainPlugin logs the plan of the slow reads made with ExplainIfSlow. The
+// query is run a second time by the EXPLAIN statement of the dialect.
+type explainPlugin struct {
+	threshold time.Duration
+	explain   string
+}
+
+func (p explainPlugin) Name() string {
+	return "explain"
+}
+
+func (p explainPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	if err := cb.Query().Before("gorm:query").Register("explain:before_query", startExplainTimer); err != nil {
+		return err
+	}
+	return cb.Query().After("gorm:query").Register("explain:after_query", p.capture)
+}
+
+func startExplainTimer(db *gorm.DB) {
+	if requested, _ := db.Statement.Context.Value(explainKey{}).(bool); requested {
+		db.InstanceSet(explainStartKey, time.Now())
+	}
+}
+
+// capture logs the plan of a slow query on the connection that ran it.
+func (p explainPlugin) capture(db *gorm.DB) {
+	value, ok := db.InstanceGet(explainStartKey)
+	if !ok || db.Error != nil {
+		return
+	}
+	start, ok := value.(time.Time)
+	if !ok || time.Since(start) <= p.threshold {
+		return
+	}
+
+	ctx := db.Statement.Context
+	query := db.Statement.SQL.String()
+	var plan string
+	if err := db.Statement.ConnPool.QueryRowContext(ctx, p.explain+query, db.Statement.Vars...).Scan(&plan); err != nil {
+		logger.WithContext(ctx).Warnf("failed to explain a slow query: %v", err)
+		return
+	}
+	logger.WithContext(ctx).
+		WithField("sql", db.Dialector.Explain(query, redactParams(db.Statement.Vars)...)).
+		WithField("plan", plan).
+		Warnf("plan of a slow query on %s", tableName(db))
+}
@@ -0,0 +1,110 @@
+package database
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/config"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+)
+
+// explained counts the logged attempts to explain a query.
+func explained(hook *logtest.Hook) int {
+	n := 0
+	for _, entry := range hook.AllEntries() {
+		if strings.Contains(entry.Message, "plan of a slow query") || strings.Contains(entry.Message, "failed to explain") {
+			n++
+		}
+	}
+	return n
+}
+
+func TestExplainPlugin(t *testing.T) {
+	// a dialect with an EXPLAIN prefix, as postgres and mysql. Its plan has
+	// several columns, so the explained queries log a failure.
+	sqlite := dialects["sqlite"]
+	explaining := sqlite
+	explaining.explain = "EXPLAIN QUERY PLAN "
+	t.Cleanup(func() { dialects["sqlite"] = sqlite })
+
+	tests := []struct {
+		name       string
+		explain    bool
+		threshold  time.Duration
+		prefix     bool
+		production bool
+		want       bool
+	}{
+		{"enabled", true, time.Nanosecond, true, false, true},
+		{"explain off", false, time.Nanosecond, true, false, false},
+		{"no threshold", true, 0, true, false, false},
+		{"dialect without prefix", true, time.Nanosecond, false, false, false},
+		{"production", true, time.Nanosecond, true, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dialects["sqlite"] = sqlite
+			if tt.prefix {
+				dialects["sqlite"] = explaining
+			}
+			cfg := config.Default().Database
+			cfg.Driver = "sqlite"
+			cfg.Master.Dbname = t.TempDir() + "/test.db"
+			cfg.SlowQuery.Explain = tt.explain
+			cfg.SlowQuery.Threshold = tt.threshold
+			name := strings.NewReplacer("/", "-", " ", "-").Replace(t.Name())
+			ctx := context.Background()
+			d, err := New(ctx, cfg, Options{Name: name, Production: tt.production})
+			if err != nil {
+				t.Fatalf("New() error: %v", err)
+			}
+			defer d.Close()
+
+			if _, got := d.Gorm().Config.Plugins["explain"]; got != tt.want {
+				t.Errorf("explain plugin registered = %v, want %v", got, tt.want)
+			}
+
+			hook := captureLogs(t)
+			var names []string
+			if err := d.WithContext(ctx).Table("sqlite_master").Pluck("name", &names).Error; err != nil {
+				t.Fatalf("SELECT error: %v", err)
+			}
+			if n := explained(hook); n != 0 {
+				t.Errorf("explained %d queries not asking for it", n)
+			}
+			if err := d.WithContext(ExplainIfSlow(ctx)).Table("sqlite_master").Pluck("name", &names).Error; err != nil {
+				t.Fatalf("SELECT error: %v", err)
+			}
+			if got := explained(hook) == 1; got != tt.want {
+				t.Errorf("explained = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExplainSQLite(t *testing.T) {
+	ctx := context.Background()
+	d := newTestDatabase(t, func(cfg *config.DatabaseConfiguration) {
+		cfg.SlowQuery.Threshold = time.Nanosecond
+		cfg.SlowQuery.Explain = true
+	})
+	if err := d.WithContext(ctx).Exec("CREATE TABLE items (name TEXT)").Error; err != nil {
+		t.Fatalf("CREATE TABLE error: %v", err)
+	}
+
+	hook := captureLogs(t)
+	var names []string
+	if err := d.WithContext(ExplainIfSlow(ctx)).Table("items").Pluck("name", &names).Error; err != nil {
+		t.Fatalf("SELECT error: %v", err)
+	}
+	// sqlite has no EXPLAIN prefix, the slow query is logged without a plan
+	if n := explained(hook); n != 0 {
+		t.Errorf("explained %d queries on sqlite", n)
+	}
+	if len(hook.AllEntries()) == 0 {
+		t.Error("the slow query was not logged")
+	}
+}
@@ -0,0 +1,47 @@
+package database
+
+import (
+	"time"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/metrics"
+
+	"gorm.io/gorm"
+)
+
+const metricsStartKey = "metrics:start"
+
+// metricsPlugin records the duration of every query by operation and table,
+// and counts the queries slower than slowThreshold.
+type metricsPlugin struct {
+	slowThreshold time.Duration
+}
+
+func (metricsPlugin) Name() string {
+	return "metrics"
+}
+
+func (p metricsPlugin) Initialize(db *gorm.DB) error {
+	return registerCallbacks(db, "metrics", startQueryTimer, p.observeQuery)
+}
+
+func startQueryTimer(db *gorm.DB) {
+	db.InstanceSet(metricsStartKey, time.Now())
+}
+
+func (p metricsPlugin) observeQuery(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(metricsStartKey)
+		if !ok {
+			return
+		}
+		start, ok := value.(time.Time)
+		if !ok {
+			return
+		}
+		duration := time.Since(start)
+		metrics.ObserveDBQuery(operation, tableName(db), duration)
+		if p.slowThreshold > 0 && duration > p.slowThreshold {
+			metrics.ObserveSlowDBQuery(operation, tableName(db))
+		}
+	}
+}
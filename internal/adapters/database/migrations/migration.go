@@ -1,31 +1,228 @@
 package migrations
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
 	"github.com/go-gormigrate/gormigrate/v2"
 	"gorm.io/gorm"
 
 	"github.com/ponyjackal/go-microservice-boilerplate/internal/adapters/database"
-	"github.com/ponyjackal/go-microservice-boilerplate/internal/domain/models"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/logger"
 )
 
-var migrations = []*gormigrate.Migration{}
+// lockID is the Postgres advisory lock held while migrating, so concurrent
+// instances run the migrations one after the other.
+const lockID int64 = 0x6d6967726174650a
 
-func Migrate() {
-	m := gormigrate.New(database.DB, gormigrate.DefaultOptions, migrations)
+// migrationLock holds the statements taking and releasing the migration
+// lock of a driver, called with key.
+type migrationLock struct {
+	acquire, release string
+	key              interface{}
+}
 
-	m.InitSchema(func(tx *gorm.DB) error {
-		err := tx.AutoMigrate(
-			&models.Tag{},
-		)
-		if err != nil {
-			return err
+// locks are the migration locks of each driver. SQLite needs none, the
+// migration transaction locks the database file.
+var locks = map[string]migrationLock{
+	"postgres": {acquire: "SELECT pg_advisory_lock($1)", release: "SELECT pg_advisory_unlock($1)", key: lockID},
+	"mysql":    {acquire: "SELECT GET_LOCK(?, -1)", release: "SELECT RELEASE_LOCK(?)", key: "migrations"},
+}
+
+// options keeps the migration table of the schema initialized before
+// versioned migrations. Each run is applied in a single transaction.
+var options = &gormigrate.Options{
+	TableName:      gormigrate.DefaultOptions.TableName,
+	IDColumnName:   gormigrate.DefaultOptions.IDColumnName,
+	IDColumnSize:   gormigrate.DefaultOptions.IDColumnSize,
+	UseTransaction: true,
+}
+
+// goMigrations are the migrations written in Go, for changes SQL cannot
+// express. They are applied in ID order along with the SQL migrations.
+var goMigrations = []*gormigrate.Migration{
+	addTagsSlug,
+}
+
+// Status tells whether a migration has been applied.
+type Status struct {
+	ID      string
+	Applied bool
+	// Unknown is set for the migrations recorded in the database but not
+	// defined in this binary.
+	Unknown bool
+}
+
+// all returns every Go and SQL migration of driver sorted by ID.
+func all(driver string) ([]*gormigrate.Migration, error) {
+	list, err := sqlMigrations(driver)
+	if err != nil {
+		return nil, err
+	}
+	list = append(list, goMigrations...)
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].ID < list[j].ID
+	})
+	logged := make([]*gormigrate.Migration, len(list))
+	for i, migration := range list {
+		logged[i] = withLogs(migration)
+	}
+	return logged, nil
+}
+
+// withLogs returns a copy of migration logging when it is applied or rolled
+// back.
+func withLogs(migration *gormigrate.Migration) *gormigrate.Migration {
+	logged := &gormigrate.Migration{ID: migration.ID}
+	logged.Migrate = func(tx *gorm.DB) error {
+		logger.Infof("applying migration %s", migration.ID)
+		return migration.Migrate(tx)
+	}
+	if migration.Rollback != nil {
+		logged.Rollback = func(tx *gorm.DB) error {
+			logger.Infof("rolling back migration %s", migration.ID)
+			return migration.Rollback(tx)
 		}
+	}
+	return logged
+}
 
+// Up applies every pending migration.
+func Up(ctx context.Context, db *database.Database) error {
+	return run(ctx, db, func(m *gormigrate.Gormigrate) error {
+		return m.Migrate()
+	})
+}
+
+// Down rolls back the last n applied migrations.
+func Down(ctx context.Context, db *database.Database, n int) error {
+	return run(ctx, db, func(m *gormigrate.Gormigrate) error {
+		for i := 0; i < n; i++ {
+			if err := m.RollbackLast(); err != nil {
+				if errors.Is(err, gormigrate.ErrNoRunMigration) {
+					return fmt.Errorf("only %d migrations were applied", i)
+				}
+				return err
+			}
+		}
 		return nil
 	})
+}
+
+// To applies or rolls back migrations until id is the last one applied.
+func To(ctx context.Context, db *database.Database, id string) error {
+	return run(ctx, db, func(m *gormigrate.Gormigrate) error {
+		applied, err := appliedIDs(ctx, db)
+		if err != nil {
+			return err
+		}
+		if applied[id] {
+			return m.RollbackTo(id)
+		}
+		return m.MigrateTo(id)
+	})
+}
 
-	// Run the migrations
-	if err := m.Migrate(); err != nil {
-		panic(err)
+// Statuses lists the defined migrations followed by the unknown ones.
+func Statuses(ctx context.Context, db *database.Database) ([]Status, error) {
+	list, err := all(db.Driver())
+	if err != nil {
+		return nil, err
+	}
+	applied, err := appliedIDs(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(list))
+	for _, migration := range list {
+		statuses = append(statuses, Status{ID: migration.ID, Applied: applied[migration.ID]})
+		delete(applied, migration.ID)
+	}
+	var unknown []string
+	for id := range applied {
+		unknown = append(unknown, id)
+	}
+	sort.Strings(unknown)
+	for _, id := range unknown {
+		statuses = append(statuses, Status{ID: id, Applied: true, Unknown: true})
+	}
+	return statuses, nil
+}
+
+// CheckState returns an error when a migration has not been applied yet, or
+// when the database holds migrations this binary does not define.
+func CheckState(ctx context.Context, db *database.Database) error {
+	statuses, err := Statuses(ctx, db)
+	if err != nil {
+		return err
+	}
+	pending, unknown := 0, 0
+	for _, status := range statuses {
+		switch {
+		case status.Unknown:
+			unknown++
+		case !status.Applied:
+			pending++
+		}
+	}
+	if pending > 0 {
+		return fmt.Errorf("%d pending migrations", pending)
+	}
+	if unknown > 0 {
+		return fmt.Errorf("%d unknown migrations applied", unknown)
+	}
+	return nil
+}
+
+// run calls fn with a migrator holding the migration lock.
+func run(ctx context.Context, db *database.Database, fn func(m *gormigrate.Gormigrate) error) error {
+	list, err := all(db.Driver())
+	if err != nil {
+		return err
+	}
+
+	if lock, ok := locks[db.Driver()]; ok {
+		sqlDB, err := db.Gorm().DB()
+		if err != nil {
+			return err
+		}
+		conn, err := sqlDB.Conn(ctx)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+		if _, err := conn.ExecContext(ctx, lock.acquire, lock.key); err != nil {
+			return fmt.Errorf("failed to acquire the migration lock: %w", err)
+		}
+		defer func() {
+			if _, err := conn.ExecContext(context.Background(), lock.release, lock.key); err != nil {
+				logger.Errorf("failed to release the migration lock: %v", err)
+			}
+		}()
+	}
+
+	m := gormigrate.New(db.WithContext(database.ReadFromPrimary(ctx)), options, list)
+	return fn(m)
+}
+
+// appliedIDs returns the IDs recorded in the migration table, read on the
+// primary since replicas may lag behind.
+func appliedIDs(ctx context.Context, db *database.Database) (map[string]bool, error) {
+	conn := db.WithContext(database.ReadFromPrimary(ctx))
+	applied := map[string]bool{}
+	if !conn.Migrator().HasTable(options.TableName) {
+		return applied, nil
+	}
+
+	var ids []string
+	if err := conn.Table(options.TableName).Pluck(options.IDColumnName, &ids).Error; err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		applied[id] = true
 	}
+	return applied, nil
 }
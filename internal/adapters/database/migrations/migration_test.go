@@ -0,0 +1,199 @@
+package migrations_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/adapters/database"
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/adapters/database/dbtest"
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/adapters/database/migrations"
+)
+
+// applied returns the IDs of the applied migrations, failing the test on
+// error.
+func applied(t *testing.T, db *database.Database) []string {
+	t.Helper()
+	statuses, err := migrations.Statuses(context.Background(), db)
+	if err != nil {
+		t.Fatalf("Statuses() error: %v", err)
+	}
+	var ids []string
+	for _, status := range statuses {
+		if status.Applied {
+			ids = append(ids, status.ID)
+		}
+	}
+	return ids
+}
+
+func TestUpDown(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.Empty(t)
+
+	statuses, err := migrations.Statuses(ctx, db)
+	if err != nil {
+		t.Fatalf("Statuses() error: %v", err)
+	}
+	if len(statuses) == 0 {
+		t.Fatal("no migration is defined for sqlite")
+	}
+	for i, status := range statuses {
+		if status.Applied || status.Unknown {
+			t.Errorf("status %s = %+v before Up(), want pending", status.ID, status)
+		}
+		if i > 0 && statuses[i-1].ID >= status.ID {
+			t.Errorf("migration %s is listed after %s", status.ID, statuses[i-1].ID)
+		}
+	}
+	if err := migrations.CheckState(ctx, db); err == nil || !strings.Contains(err.Error(), "pending") {
+		t.Errorf("CheckState() before Up() error = %v, want pending migrations", err)
+	}
+
+	if err := migrations.Up(ctx, db); err != nil {
+		t.Fatalf("Up() error: %v", err)
+	}
+	if got := applied(t, db); len(got) != len(statuses) {
+		t.Errorf("%d migrations applied, want %d", len(got), len(statuses))
+	}
+	if err := migrations.CheckState(ctx, db); err != nil {
+		t.Errorf("CheckState() after Up() error: %v", err)
+	}
+	// applying again changes nothing
+	if err := migrations.Up(ctx, db); err != nil {
+		t.Fatalf("second Up() error: %v", err)
+	}
+
+	if err := migrations.Down(ctx, db, 1); err != nil {
+		t.Fatalf("Down(1) error: %v", err)
+	}
+	if got := applied(t, db); len(got) != len(statuses)-1 || got[len(got)-1] != statuses[len(statuses)-2].ID {
+		t.Errorf("applied after Down(1) = %q, want every migration but the last", got)
+	}
+
+	if err := migrations.Down(ctx, db, len(statuses)-1); err != nil {
+		t.Fatalf("Down() of every migration error: %v", err)
+	}
+	if got := applied(t, db); len(got) != 0 {
+		t.Errorf("applied after the rollback = %q, want none", got)
+	}
+	if db.WithContext(ctx).Migrator().HasTable("tags") {
+		t.Error("the tags table is left after the rollback")
+	}
+	if err := migrations.Down(ctx, db, 1); err == nil || !strings.Contains(err.Error(), "only 0 migrations were applied") {
+		t.Errorf("Down() without migration error = %v", err)
+	}
+}
+
+func TestTo(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.Empty(t)
+	statuses, err := migrations.Statuses(ctx, db)
+	if err != nil {
+		t.Fatalf("Statuses() error: %v", err)
+	}
+	first, last := statuses[0].ID, statuses[len(statuses)-1].ID
+
+	if err := migrations.To(ctx, db, first); err != nil {
+		t.Fatalf("To(%s) error: %v", first, err)
+	}
+	if got := applied(t, db); len(got) != 1 || got[0] != first {
+		t.Errorf("applied = %q, want [%s]", got, first)
+	}
+
+	// forward to the last one
+	if err := migrations.To(ctx, db, last); err != nil {
+		t.Fatalf("To(%s) error: %v", last, err)
+	}
+	if got := applied(t, db); len(got) != len(statuses) {
+		t.Errorf("%d migrations applied, want %d", len(got), len(statuses))
+	}
+
+	// and back to the first one, which stays applied
+	if err := migrations.To(ctx, db, first); err != nil {
+		t.Fatalf("To(%s) back error: %v", first, err)
+	}
+	if got := applied(t, db); len(got) != 1 || got[0] != first {
+		t.Errorf("applied after rolling back = %q, want [%s]", got, first)
+	}
+
+	if err := migrations.To(ctx, db, "20000101000000_unknown"); err == nil {
+		t.Error("To() an unknown migration returned no error")
+	}
+}
+
+func TestCheckStateUnknown(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.Open(t)
+
+	// recorded by a newer release
+	if err := db.WithContext(ctx).Exec("INSERT INTO migrations (id) VALUES (?)", "29991231000000_from_the_future").Error; err != nil {
+		t.Fatalf("INSERT error: %v", err)
+	}
+	statuses, err := migrations.Statuses(ctx, db)
+	if err != nil {
+		t.Fatalf("Statuses() error: %v", err)
+	}
+	if last := statuses[len(statuses)-1]; last.ID != "29991231000000_from_the_future" || !last.Applied || !last.Unknown {
+		t.Errorf("last status = %+v, want the unknown migration", last)
+	}
+	if err := migrations.CheckState(ctx, db); err == nil || !strings.Contains(err.Error(), "1 unknown migrations") {
+		t.Errorf("CheckState() error = %v, want an unknown migration", err)
+	}
+}
+
+func TestCheckStateGap(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.Open(t)
+	ids := applied(t, db)
+
+	// a migration missing before applied ones
+	if err := db.WithContext(ctx).Exec("DELETE FROM migrations WHERE id = ?", ids[1]).Error; err != nil {
+		t.Fatalf("DELETE error: %v", err)
+	}
+	if err := migrations.CheckState(ctx, db); err == nil || !strings.Contains(err.Error(), "1 pending migrations") {
+		t.Errorf("CheckState() error = %v, want a pending migration", err)
+	}
+}
+
+func TestCreate(t *testing.T) {
+	dir := t.TempDir()
+	for _, driver := range []string{"postgres", "sqlite"} {
+		if err := os.Mkdir(filepath.Join(dir, driver), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// files next to the driver directories are ignored
+	if err := os.WriteFile(filepath.Join(dir, "README"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	paths, err := migrations.Create(dir, "add_tag_color")
+	if err != nil {
+		t.Fatalf("Create() error: %v", err)
+	}
+	if len(paths) != 4 {
+		t.Fatalf("Create() wrote %q, want an up and a down file per driver", paths)
+	}
+	id := strings.TrimSuffix(filepath.Base(paths[0]), ".up.sql")
+	if !strings.HasSuffix(id, "_add_tag_color") || len(id) != len("20060102150405_add_tag_color") {
+		t.Errorf("ID = %q, want a timestamp and the name", id)
+	}
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("ReadFile() error: %v", err)
+		}
+		if want := "-- " + filepath.Base(path) + "\n"; string(content) != want {
+			t.Errorf("%s = %q, want %q", path, content, want)
+		}
+	}
+
+	for _, name := range []string{"AddColor", "add-color", ""} {
+		if _, err := migrations.Create(dir, name); err == nil {
+			t.Errorf("Create(%q) returned no error", name)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package migrations
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// SQLDir is the directory of the SQL migrations, relative to the repository
+// root. It holds one directory per database driver, whose migrations share
+// their IDs. Its files are embedded in the binary.
+const SQLDir = "internal/adapters/database/migrations/sql"
+
+// idLayout prefixes migration IDs so they sort in creation order.
+const idLayout = "20060102150405"
+
+//go:embed sql/*/*.sql
+var sqlFiles embed.FS
+
+// migrationName restricts the names given to create.
+var migrationName = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// sqlMigrations reads the embedded <id>.up.sql and <id>.down.sql files of
+// driver. A migration without a down file cannot be rolled back.
+func sqlMigrations(driver string) ([]*gormigrate.Migration, error) {
+	dir := path.Join("sql", driver)
+	entries, err := fs.ReadDir(sqlFiles, dir)
+	if err != nil {
+		return nil, fmt.Errorf("no SQL migrations for the %s driver: %w", driver, err)
+	}
+
+	var list []*gormigrate.Migration
+	for _, entry := range entries {
+		id := strings.TrimSuffix(entry.Name(), ".up.sql")
+		if id == entry.Name() {
+			if !strings.HasSuffix(entry.Name(), ".down.sql") {
+				return nil, fmt.Errorf("unexpected migration file %s, expected <id>.up.sql or <id>.down.sql", entry.Name())
+			}
+			continue
+		}
+
+		up, err := sqlFiles.ReadFile(path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		migration := &gormigrate.Migration{ID: id, Migrate: execSQL(string(up))}
+		if down, err := sqlFiles.ReadFile(path.Join(dir, id+".down.sql")); err == nil {
+			migration.Rollback = execSQL(string(down))
+		}
+		list = append(list, migration)
+	}
+	return list, nil
+}
+
+// execSQL runs the statements of a migration file.
+func execSQL(statements string) func(*gorm.DB) error {
+	return func(tx *gorm.DB) error {
+		return tx.Exec(statements).Error
+	}
+}
+
+// Create writes empty up and down SQL files for a new migration in every
+// driver directory of dir and returns their paths. The files are embedded on
+// the next build.
+func Create(dir, name string) ([]string, error) {
+	if !migrationName.MatchString(name) {
+		return nil, fmt.Errorf("invalid migration name %q, use lower case letters, digits and underscores", name)
+	}
+	drivers, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	id := time.Now().UTC().Format(idLayout) + "_" + name
+	var paths []string
+	for _, driver := range drivers {
+		if !driver.IsDir() {
+			continue
+		}
+		paths = append(paths,
+			filepath.Join(dir, driver.Name(), id+".up.sql"),
+			filepath.Join(dir, driver.Name(), id+".down.sql"),
+		)
+	}
+	for _, path := range paths {
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err != nil {
+			return nil, err
+		}
+		_, err = fmt.Fprintf(file, "-- %s\n", filepath.Base(path))
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return paths, nil
+}
@@ -0,0 +1,10 @@
+-- ids are generated by the application, see models.Tag
+CREATE TABLE IF NOT EXISTS tags (
+    id char(36) NOT NULL PRIMARY KEY,
+    name varchar(255) NOT NULL,
+    created_at datetime(3) NULL,
+    updated_at datetime(3) NULL,
+    deleted_at datetime(3) NULL,
+    UNIQUE KEY unique_tag_name (name),
+    KEY idx_tags_deleted_at (deleted_at)
+);
@@ -0,0 +1,2 @@
+-- the number of uses of a tag, ranking the autocompletions
+ALTER TABLE tags ADD COLUMN usage_count bigint NOT NULL DEFAULT 0;
@@ -0,0 +1,11 @@
+-- alternative names resolved to their canonical tag, dropped with it
+CREATE TABLE IF NOT EXISTS tag_aliases (
+    id char(36) NOT NULL PRIMARY KEY,
+    tag_id char(36) NOT NULL,
+    name varchar(255) NOT NULL,
+    slug varchar(255) NOT NULL,
+    created_at datetime(3) NULL,
+    UNIQUE KEY unique_tag_alias_slug (slug),
+    KEY idx_tag_aliases_tag_id (tag_id),
+    CONSTRAINT fk_tag_aliases_tag FOREIGN KEY (tag_id) REFERENCES tags (id) ON DELETE CASCADE
+);
@@ -0,0 +1,4 @@
+ALTER TABLE tags
+    DROP FOREIGN KEY fk_tags_parent,
+    DROP KEY idx_tags_parent_id,
+    DROP COLUMN parent_id;
@@ -0,0 +1,5 @@
+-- the parent of a tag in the hierarchy, NULL for the roots
+ALTER TABLE tags
+    ADD COLUMN parent_id char(36) NULL,
+    ADD KEY idx_tags_parent_id (parent_id),
+    ADD CONSTRAINT fk_tags_parent FOREIGN KEY (parent_id) REFERENCES tags (id) ON DELETE SET NULL;
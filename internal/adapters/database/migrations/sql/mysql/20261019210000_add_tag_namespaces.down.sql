@@ -0,0 +1,15 @@
+-- fails when a name or slug is used in several namespaces
+ALTER TABLE tag_aliases
+    DROP INDEX unique_tag_alias_slug,
+    ADD UNIQUE KEY unique_tag_alias_slug (slug),
+    DROP COLUMN namespace;
+
+ALTER TABLE tags
+    DROP FOREIGN KEY fk_tags_namespace,
+    DROP INDEX unique_tag_name,
+    DROP INDEX unique_tag_slug,
+    ADD UNIQUE KEY unique_tag_name (name),
+    ADD UNIQUE KEY unique_tag_slug (slug),
+    DROP COLUMN namespace;
+
+DROP TABLE IF EXISTS tag_namespaces;
@@ -0,0 +1,24 @@
+-- independent vocabularies, the tag names and slugs are unique per namespace
+CREATE TABLE IF NOT EXISTS tag_namespaces (
+    name varchar(63) NOT NULL PRIMARY KEY,
+    max_tags bigint NOT NULL DEFAULT 0,
+    allowed_characters varchar(20) NOT NULL DEFAULT 'any',
+    created_at datetime(3) NULL,
+    updated_at datetime(3) NULL
+);
+
+-- holds the existing tags and the ones created without a namespace
+INSERT INTO tag_namespaces (name, created_at, updated_at) VALUES ('default', NOW(3), NOW(3));
+
+ALTER TABLE tags
+    ADD COLUMN namespace varchar(63) NOT NULL DEFAULT 'default',
+    DROP INDEX unique_tag_name,
+    DROP INDEX unique_tag_slug,
+    ADD UNIQUE KEY unique_tag_name (namespace, name),
+    ADD UNIQUE KEY unique_tag_slug (namespace, slug),
+    ADD CONSTRAINT fk_tags_namespace FOREIGN KEY (namespace) REFERENCES tag_namespaces (name);
+
+ALTER TABLE tag_aliases
+    ADD COLUMN namespace varchar(63) NOT NULL DEFAULT 'default',
+    DROP INDEX unique_tag_alias_slug,
+    ADD UNIQUE KEY unique_tag_alias_slug (namespace, slug);
@@ -0,0 +1,14 @@
+-- gen_random_uuid is built in from Postgres 13
+CREATE EXTENSION IF NOT EXISTS pgcrypto;
+
+-- IF NOT EXISTS adopts the table created before versioned migrations
+CREATE TABLE IF NOT EXISTS tags (
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    name text NOT NULL,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS unique_tag_name ON tags (name);
+CREATE INDEX IF NOT EXISTS idx_tags_deleted_at ON tags (deleted_at);
@@ -0,0 +1,6 @@
+-- trigram similarity for SearchTags, the other drivers search without an index
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- serves the LIKE filters and the %> operator of SearchTags, which compare
+-- LOWER(name) of the table itself
+CREATE INDEX IF NOT EXISTS idx_tags_name_trgm ON tags USING gin (LOWER(name) gin_trgm_ops);
@@ -0,0 +1,13 @@
+-- alternative names resolved to their canonical tag, dropped with it
+CREATE TABLE IF NOT EXISTS tag_aliases (
+    id uuid PRIMARY KEY,
+    tag_id uuid NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+    name text NOT NULL,
+    slug text NOT NULL,
+    created_at timestamptz
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS unique_tag_alias_slug ON tag_aliases (slug);
+CREATE INDEX IF NOT EXISTS idx_tag_aliases_tag_id ON tag_aliases (tag_id);
+-- serves SearchTags like idx_tags_name_trgm
+CREATE INDEX IF NOT EXISTS idx_tag_aliases_name_trgm ON tag_aliases USING gin (LOWER(name) gin_trgm_ops);
@@ -0,0 +1,21 @@
+-- independent vocabularies, the tag names and slugs are unique per namespace
+CREATE TABLE IF NOT EXISTS tag_namespaces (
+    name varchar(63) PRIMARY KEY,
+    max_tags bigint NOT NULL DEFAULT 0,
+    allowed_characters varchar(20) NOT NULL DEFAULT 'any',
+    created_at timestamptz,
+    updated_at timestamptz
+);
+
+-- holds the existing tags and the ones created without a namespace
+INSERT INTO tag_namespaces (name, created_at, updated_at) VALUES ('default', NOW(), NOW());
+
+ALTER TABLE tags ADD COLUMN namespace varchar(63) NOT NULL DEFAULT 'default' REFERENCES tag_namespaces (name);
+ALTER TABLE tag_aliases ADD COLUMN namespace varchar(63) NOT NULL DEFAULT 'default';
+
+DROP INDEX unique_tag_name;
+DROP INDEX unique_tag_slug;
+DROP INDEX unique_tag_alias_slug;
+CREATE UNIQUE INDEX unique_tag_name ON tags (namespace, name);
+CREATE UNIQUE INDEX unique_tag_slug ON tags (namespace, slug);
+CREATE UNIQUE INDEX unique_tag_alias_slug ON tag_aliases (namespace, slug);
@@ -0,0 +1,11 @@
+-- ids are generated by the application, see models.Tag
+CREATE TABLE IF NOT EXISTS tags (
+    id text NOT NULL PRIMARY KEY,
+    name text NOT NULL,
+    created_at datetime,
+    updated_at datetime,
+    deleted_at datetime
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS unique_tag_name ON tags (name);
+CREATE INDEX IF NOT EXISTS idx_tags_deleted_at ON tags (deleted_at);
@@ -0,0 +1,11 @@
+-- alternative names resolved to their canonical tag, dropped with it
+CREATE TABLE IF NOT EXISTS tag_aliases (
+    id text NOT NULL PRIMARY KEY,
+    tag_id text NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+    name text NOT NULL,
+    slug text NOT NULL,
+    created_at datetime
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS unique_tag_alias_slug ON tag_aliases (slug);
+CREATE INDEX IF NOT EXISTS idx_tag_aliases_tag_id ON tag_aliases (tag_id);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_tags_parent_id;
+ALTER TABLE tags DROP COLUMN parent_id;
@@ -0,0 +1,4 @@
+-- the parent of a tag in the hierarchy, NULL for the roots
+ALTER TABLE tags ADD COLUMN parent_id text REFERENCES tags (id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_tags_parent_id ON tags (parent_id);
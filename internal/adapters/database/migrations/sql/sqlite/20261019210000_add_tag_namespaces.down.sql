@@ -0,0 +1,11 @@
+-- fails when a name or slug is used in several namespaces
+DROP INDEX unique_tag_alias_slug;
+DROP INDEX unique_tag_slug;
+DROP INDEX unique_tag_name;
+CREATE UNIQUE INDEX unique_tag_name ON tags (name);
+CREATE UNIQUE INDEX unique_tag_slug ON tags (slug);
+CREATE UNIQUE INDEX unique_tag_alias_slug ON tag_aliases (slug);
+
+ALTER TABLE tag_aliases DROP COLUMN namespace;
+ALTER TABLE tags DROP COLUMN namespace;
+DROP TABLE IF EXISTS tag_namespaces;
@@ -0,0 +1,23 @@
+-- independent vocabularies, the tag names and slugs are unique per namespace
+CREATE TABLE IF NOT EXISTS tag_namespaces (
+    name text NOT NULL PRIMARY KEY,
+    max_tags integer NOT NULL DEFAULT 0,
+    allowed_characters text NOT NULL DEFAULT 'any',
+    created_at datetime,
+    updated_at datetime
+);
+
+-- holds the existing tags and the ones created without a namespace
+INSERT INTO tag_namespaces (name, created_at, updated_at) VALUES ('default', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP);
+
+-- SQLite cannot add a column referencing another table with a default, the
+-- namespaces are only deleted once they have no tags
+ALTER TABLE tags ADD COLUMN namespace text NOT NULL DEFAULT 'default';
+ALTER TABLE tag_aliases ADD COLUMN namespace text NOT NULL DEFAULT 'default';
+
+DROP INDEX unique_tag_name;
+DROP INDEX unique_tag_slug;
+DROP INDEX unique_tag_alias_slug;
+CREATE UNIQUE INDEX unique_tag_name ON tags (namespace, name);
+CREATE UNIQUE INDEX unique_tag_slug ON tags (namespace, slug);
+CREATE UNIQUE INDEX unique_tag_alias_slug ON tag_aliases (namespace, slug);
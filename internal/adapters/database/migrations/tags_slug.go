@@ -0,0 +1,77 @@
+package migrations
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/logger"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/tagname"
+)
+
+// slugColumnTypes are the types of tags.slug for each driver, MySQL needs a
+// bounded length to index it.
+var slugColumnTypes = map[string]string{
+	"postgres": "text",
+	"mysql":    "varchar(255)",
+	"sqlite":   "text",
+}
+
+// addTagsSlug adds the unique slugs of the tags. The slugs of the existing
+// tags, soft deleted ones included, are derived from their names. A tag whose
+// slug is taken by an older one gets the start of its ID appended, each
+// collision is logged so the names can be fixed.
+var addTagsSlug = &gormigrate.Migration{
+	ID: "20261019180000_add_tags_slug",
+	Migrate: func(tx *gorm.DB) error {
+		columnType, ok := slugColumnTypes[tx.Dialector.Name()]
+		if !ok {
+			return fmt.Errorf("unsupported driver %q", tx.Dialector.Name())
+		}
+		if err := tx.Exec("ALTER TABLE tags ADD COLUMN slug " + columnType + " NOT NULL DEFAULT ''").Error; err != nil {
+			return err
+		}
+
+		var tags []struct {
+			ID        string
+			Name      string
+			CreatedAt time.Time
+		}
+		if err := tx.Raw("SELECT id, name, created_at FROM tags ORDER BY created_at, id").Scan(&tags).Error; err != nil {
+			return err
+		}
+		owners := make(map[string]string, len(tags))
+		collisions := 0
+		for _, tag := range tags {
+			slug := tagname.Slug(tag.Name)
+			switch owner, taken := owners[slug]; {
+			case slug == "":
+				logger.Warnf("tag %s %q has no letter or digit, its slug is its ID", tag.ID, tag.Name)
+				slug = tag.ID
+			case taken:
+				deduplicated := slug + "-" + tag.ID[:8]
+				if _, taken := owners[deduplicated]; taken {
+					deduplicated = slug + "-" + tag.ID
+				}
+				logger.Warnf("slug %q of tag %s %q is taken by tag %s, stored as %q", slug, tag.ID, tag.Name, owner, deduplicated)
+				slug = deduplicated
+				collisions++
+			}
+			owners[slug] = tag.ID
+			if err := tx.Exec("UPDATE tags SET slug = ? WHERE id = ?", slug, tag.ID).Error; err != nil {
+				return err
+			}
+		}
+		logger.Infof("backfilled the slugs of %d tags, %d collisions", len(tags), collisions)
+
+		return tx.Exec("CREATE UNIQUE INDEX unique_tag_slug ON tags (slug)").Error
+	},
+	Rollback: func(tx *gorm.DB) error {
+		if err := tx.Migrator().DropIndex("tags", "unique_tag_slug"); err != nil {
+			return err
+		}
+		return tx.Exec("ALTER TABLE tags DROP COLUMN slug").Error
+	},
+}
@@ -0,0 +1,84 @@
+package migrations_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/adapters/database/dbtest"
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/adapters/database/migrations"
+)
+
+func TestAddTagsSlug(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.Empty(t)
+	if err := migrations.To(ctx, db, "20261019170000_add_tags_usage_count"); err != nil {
+		t.Fatalf("To() error: %v", err)
+	}
+
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	deleted := created.Add(time.Hour)
+	tags := []struct {
+		id, name string
+		deleted  *time.Time
+	}{
+		{"aaaaaaaa-0000-4000-8000-000000000001", "Go", nil},
+		// the first collision gets the start of its ID
+		{"aaaaaaaa-0000-4000-8000-000000000002", "go", nil},
+		// the start of its ID is taken as well, it gets the whole ID
+		{"aaaaaaaa-0000-4000-8000-000000000003", "GO!", nil},
+		// soft deleted tags keep their slug too
+		{"bbbbbbbb-0000-4000-8000-000000000004", " go ", &deleted},
+		{"cccccccc-0000-4000-8000-000000000005", "Kubernetes Operators", nil},
+		{"dddddddd-0000-4000-8000-000000000006", "!!!", nil},
+	}
+	for i, tag := range tags {
+		at := created.Add(time.Duration(i) * time.Minute)
+		err := db.WithContext(ctx).Exec("INSERT INTO tags (id, name, created_at, updated_at, deleted_at) VALUES (?, ?, ?, ?, ?)",
+			tag.id, tag.name, at, at, tag.deleted).Error
+		if err != nil {
+			t.Fatalf("INSERT %q error: %v", tag.name, err)
+		}
+	}
+
+	if err := migrations.To(ctx, db, "20261019180000_add_tags_slug"); err != nil {
+		t.Fatalf("To() error: %v", err)
+	}
+
+	var rows []struct{ ID, Slug string }
+	if err := db.WithContext(ctx).Raw("SELECT id, slug FROM tags").Scan(&rows).Error; err != nil {
+		t.Fatalf("SELECT error: %v", err)
+	}
+	slugs := make(map[string]string, len(rows))
+	for _, row := range rows {
+		slugs[row.ID] = row.Slug
+	}
+	want := map[string]string{
+		"aaaaaaaa-0000-4000-8000-000000000001": "go",
+		"aaaaaaaa-0000-4000-8000-000000000002": "go-aaaaaaaa",
+		"aaaaaaaa-0000-4000-8000-000000000003": "go-aaaaaaaa-0000-4000-8000-000000000003",
+		"bbbbbbbb-0000-4000-8000-000000000004": "go-bbbbbbbb",
+		"cccccccc-0000-4000-8000-000000000005": "kubernetes-operators",
+		// without a letter or digit the slug is the ID
+		"dddddddd-0000-4000-8000-000000000006": "dddddddd-0000-4000-8000-000000000006",
+	}
+	for id, slug := range want {
+		if slugs[id] != slug {
+			t.Errorf("slug of %s = %q, want %q", id, slugs[id], slug)
+		}
+	}
+
+	// the slugs are unique from now on
+	err := db.WithContext(ctx).Exec("INSERT INTO tags (id, name, slug) VALUES (?, ?, ?)",
+		"eeeeeeee-0000-4000-8000-000000000007", "golang", "go").Error
+	if err == nil {
+		t.Error("INSERT of a taken slug succeeded")
+	}
+
+	if err := migrations.Down(ctx, db, 1); err != nil {
+		t.Fatalf("Down() error: %v", err)
+	}
+	if db.WithContext(ctx).Migrator().HasColumn("tags", "slug") {
+		t.Error("the slug column is left after the rollback")
+	}
+}
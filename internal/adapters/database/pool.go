@@ -0,0 +1,45 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/config"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/logger"
+)
+
+// configurePool applies the pool settings of a connection.
+func configurePool(db *sql.DB, cfg config.PoolConfiguration) {
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+}
+
+// connectWithRetry calls connect until it succeeds or the attempts are
+// exhausted, doubling the delay between attempts.
+func connectWithRetry(ctx context.Context, name string, cfg config.ConnectRetryConfiguration, connect func() error) error {
+	backoff := cfg.Backoff
+	for attempt := 1; ; attempt++ {
+		err := connect()
+		if err == nil {
+			return nil
+		}
+		if attempt >= cfg.Attempts {
+			return fmt.Errorf("failed to connect to %s database after %d attempts: %w", name, attempt, err)
+		}
+
+		logger.Warnf("failed to connect to %s database (attempt %d/%d), retrying in %s: %v", name, attempt, cfg.Attempts, backoff, err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > cfg.MaxBackoff {
+			backoff = cfg.MaxBackoff
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/config"
+)
+
+func TestConnectWithRetry(t *testing.T) {
+	errRefused := errors.New("connection refused")
+	// the delays are read back from the warnings
+	retry := config.ConnectRetryConfiguration{Attempts: 5, Backoff: time.Millisecond, MaxBackoff: 4 * time.Millisecond}
+
+	tests := []struct {
+		name     string
+		failures int
+		wantErr  bool
+		attempts int
+		delays   []string
+	}{
+		{"first attempt", 0, false, 1, nil},
+		{"after failures", 2, false, 3, []string{"1ms", "2ms"}},
+		// the delay doubles up to MaxBackoff
+		{"exhausted", 10, true, 5, []string{"1ms", "2ms", "4ms", "4ms"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hook := captureLogs(t)
+			attempts := 0
+			err := connectWithRetry(context.Background(), "primary", retry, func() error {
+				attempts++
+				if attempts <= tt.failures {
+					return errRefused
+				}
+				return nil
+			})
+			if attempts != tt.attempts {
+				t.Errorf("connect called %d times, want %d", attempts, tt.attempts)
+			}
+			if tt.wantErr {
+				if !errors.Is(err, errRefused) || !strings.Contains(err.Error(), fmt.Sprintf("after %d attempts", tt.attempts)) {
+					t.Errorf("connectWithRetry() error = %v", err)
+				}
+			} else if err != nil {
+				t.Errorf("connectWithRetry() error: %v", err)
+			}
+
+			var delays []string
+			for _, entry := range hook.AllEntries() {
+				if i := strings.Index(entry.Message, "retrying in "); i >= 0 {
+					delays = append(delays, strings.SplitN(entry.Message[i+len("retrying in "):], ":", 2)[0])
+				}
+			}
+			if fmt.Sprint(delays) != fmt.Sprint(tt.delays) {
+				t.Errorf("delays = %q, want %q", delays, tt.delays)
+			}
+		})
+	}
+}
+
+func TestConnectWithRetryCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	retry := config.ConnectRetryConfiguration{Attempts: 5, Backoff: time.Hour, MaxBackoff: time.Hour}
+
+	attempts := 0
+	done := make(chan error, 1)
+	go func() {
+		done <- connectWithRetry(ctx, "primary", retry, func() error {
+			attempts++
+			cancel()
+			return errors.New("connection refused")
+		})
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("connectWithRetry() error = %v, want %v", err, context.Canceled)
+		}
+		if attempts != 1 {
+			t.Errorf("connect called %d times, want 1", attempts)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("connectWithRetry() waited for the backoff after the cancellation")
+	}
+}
@@ -0,0 +1,78 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/logger"
+
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// queryLogger is the GORM logger. It writes through pkg/logger with the
+// request ID and trace IDs of the query context, and replaces the query
+// parameters with logger.RedactedValue.
+type queryLogger struct {
+	// all logs every query, see database.log_mode
+	all bool
+	// slowThreshold logs the queries running longer, zero disables it
+	slowThreshold time.Duration
+}
+
+// LogMode enables the logging of every query from the Info level, as done
+// by gorm.DB.Debug.
+func (l queryLogger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	l.all = level >= gormlogger.Info
+	return l
+}
+
+func (l queryLogger) Info(ctx context.Context, msg string, data ...interface{}) {
+	logger.WithContext(ctx).Infof(msg, data...)
+}
+
+func (l queryLogger) Warn(ctx context.Context, msg string, data ...interface{}) {
+	logger.WithContext(ctx).Warnf(msg, data...)
+}
+
+func (l queryLogger) Error(ctx context.Context, msg string, data ...interface{}) {
+	logger.WithContext(ctx).Errorf(msg, data...)
+}
+
+// Trace logs a query when it is slow or when every query is logged.
+func (l queryLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	elapsed := time.Since(begin)
+	slow := l.slowThreshold > 0 && elapsed > l.slowThreshold
+	if !slow && !l.all {
+		return
+	}
+
+	sql, rows := fc()
+	entry := logger.WithContext(ctx).
+		WithField("duration_ms", float64(elapsed.Microseconds())/1000).
+		WithField("rows", rows).
+		WithField("sql", sql)
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound):
+		entry.WithField("error", err.Error()).Error("query failed")
+	case slow:
+		entry.Warnf("slow query over %s", l.slowThreshold)
+	default:
+		entry.Info("query")
+	}
+}
+
+// ParamsFilter redacts the parameters of the logged queries.
+func (l queryLogger) ParamsFilter(ctx context.Context, sql string, params ...interface{}) (string, []interface{}) {
+	return sql, redactParams(params)
+}
+
+// redactParams replaces every parameter with logger.RedactedValue.
+func redactParams(params []interface{}) []interface{} {
+	redacted := make([]interface{}, len(params))
+	for i := range params {
+		redacted[i] = logger.RedactedValue
+	}
+	return redacted
+}
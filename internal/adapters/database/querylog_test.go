@@ -0,0 +1,151 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/config"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/logger"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/metrics"
+
+	"github.com/sirupsen/logrus"
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"gorm.io/gorm"
+)
+
+// captureLogs records the entries of pkg/logger until the end of the test.
+func captureLogs(t *testing.T) *logtest.Hook {
+	t.Helper()
+	l := logger.WithContext(context.Background()).Logger
+	previous := make(logrus.LevelHooks, len(l.Hooks))
+	for level, hooks := range l.Hooks {
+		previous[level] = append([]logrus.Hook{}, hooks...)
+	}
+	t.Cleanup(func() { l.ReplaceHooks(previous) })
+	return logtest.NewLocal(l)
+}
+
+// slowQueries returns the count of slow queries on table.
+func slowQueries(t *testing.T, operation, table string) float64 {
+	t.Helper()
+	families, err := metrics.Registry.Gather()
+	if err != nil {
+		t.Fatalf("Gather() error: %v", err)
+	}
+	for _, family := range families {
+		if family.GetName() != "service_db_slow_queries_total" {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			labels := map[string]string{}
+			for _, label := range m.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			if labels["operation"] == operation && labels["table"] == table {
+				return m.GetCounter().GetValue()
+			}
+		}
+	}
+	return 0
+}
+
+func TestQueryLoggerTrace(t *testing.T) {
+	begin := time.Now().Add(-10 * time.Millisecond)
+	tests := []struct {
+		name  string
+		l     queryLogger
+		err   error
+		level logrus.Level
+		msg   string
+	}{
+		{"slow", queryLogger{slowThreshold: 5 * time.Millisecond}, nil, logrus.WarnLevel, "slow query over 5ms"},
+		{"slow not found", queryLogger{slowThreshold: 5 * time.Millisecond}, gorm.ErrRecordNotFound, logrus.WarnLevel, "slow query over 5ms"},
+		{"slow failure", queryLogger{slowThreshold: 5 * time.Millisecond}, errors.New("locked"), logrus.ErrorLevel, "query failed"},
+		{"every query", queryLogger{all: true, slowThreshold: time.Hour}, nil, logrus.InfoLevel, "query"},
+		{"fast", queryLogger{slowThreshold: time.Hour}, nil, 0, ""},
+		{"disabled", queryLogger{}, nil, 0, ""},
+		// failures are left to the callers unless every query is logged
+		{"fast failure", queryLogger{slowThreshold: time.Hour}, errors.New("locked"), 0, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hook := captureLogs(t)
+			tt.l.Trace(context.Background(), begin, func() (string, int64) { return "SELECT 1", 1 }, tt.err)
+
+			entries := hook.AllEntries()
+			if tt.msg == "" {
+				if len(entries) != 0 {
+					t.Fatalf("logged %q, want nothing", entries[0].Message)
+				}
+				return
+			}
+			if len(entries) != 1 {
+				t.Fatalf("logged %d entries, want 1", len(entries))
+			}
+			entry := entries[0]
+			if entry.Level != tt.level || entry.Message != tt.msg {
+				t.Errorf("logged %s %q, want %s %q", entry.Level, entry.Message, tt.level, tt.msg)
+			}
+			if entry.Data["sql"] != "SELECT 1" || entry.Data["rows"] != int64(1) {
+				t.Errorf("fields = %v, want the query and its rows", entry.Data)
+			}
+		})
+	}
+}
+
+func TestSlowQueries(t *testing.T) {
+	tests := []struct {
+		name      string
+		threshold time.Duration
+		slow      bool
+	}{
+		{"over the threshold", time.Nanosecond, true},
+		{"under the threshold", time.Hour, false},
+		{"disabled", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			d := newTestDatabase(t, func(cfg *config.DatabaseConfiguration) {
+				cfg.SlowQuery.Threshold = tt.threshold
+			})
+			// a table per test, the metrics are global
+			table := strings.ReplaceAll(strings.ToLower(t.Name()), "/", "_")
+			table = strings.ReplaceAll(table, " ", "_")
+			if err := d.WithContext(ctx).Exec("CREATE TABLE " + table + " (name TEXT)").Error; err != nil {
+				t.Fatalf("CREATE TABLE error: %v", err)
+			}
+
+			hook := captureLogs(t)
+			var names []string
+			if err := d.WithContext(ctx).Table(table).Where("name = ?", "golang").Pluck("name", &names).Error; err != nil {
+				t.Fatalf("SELECT error: %v", err)
+			}
+
+			var logged []*logrus.Entry
+			for _, entry := range hook.AllEntries() {
+				if strings.HasPrefix(entry.Message, "slow query") {
+					logged = append(logged, entry)
+				}
+			}
+			count := slowQueries(t, "query", table)
+			if !tt.slow {
+				if len(logged) != 0 || count != 0 {
+					t.Errorf("logged %d and counted %v slow queries, want none", len(logged), count)
+				}
+				return
+			}
+			if len(logged) != 1 || count != 1 {
+				t.Fatalf("logged %d and counted %v slow queries, want 1", len(logged), count)
+			}
+			// the bound parameters are not logged
+			sql, _ := logged[0].Data["sql"].(string)
+			if strings.Contains(sql, "golang") || !strings.Contains(sql, logger.RedactedValue) {
+				t.Errorf("logged sql %q, want the parameter redacted", sql)
+			}
+		})
+	}
+}
@@ -0,0 +1,290 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/config"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/logger"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/metrics"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/session"
+
+	"gorm.io/gorm"
+)
+
+// replicaLagQuery returns how far, in seconds, a Postgres replica is behind
+// the primary. A replica that replayed everything it received is not lagging
+// even when the primary has been idle for a while.
+const replicaLagQuery = `SELECT CASE
+	WHEN NOT pg_is_in_recovery() OR pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+	ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+END`
+
+// maxTrackedSessions bounds the sessions remembered by the read-your-writes
+// window. Session IDs come from the clients, so the tracker must not grow
+// with them.
+const maxTrackedSessions = 100000
+
+type primaryKey struct{}
+
+// ReadFromPrimary returns a copy of ctx whose reads are sent to the primary.
+func ReadFromPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+// replica is a read-only pool ejected while it is unreachable or lagging.
+type replica struct {
+	name    string
+	db      *sql.DB
+	healthy int32
+	// reason is why the replica was last ejected
+	reason atomic.Value
+}
+
+func (r *replica) isHealthy() bool {
+	return atomic.LoadInt32(&r.healthy) == 1
+}
+
+// setHealthy records the health of the replica and logs every change.
+func (r *replica) setHealthy(healthy bool, reason error) {
+	value := int32(0)
+	if healthy {
+		value = 1
+	}
+	if reason != nil {
+		r.reason.Store(reason.Error())
+	}
+	if atomic.SwapInt32(&r.healthy, value) == value {
+		return
+	}
+	if healthy {
+		logger.Infof("readmitting %s", r.name)
+	} else {
+		logger.Warnf("ejecting %s: %v", r.name, reason)
+	}
+}
+
+// openReplica opens the pool of a replica. A replica that cannot be reached
+// starts ejected instead of failing the startup.
+func openReplica(ctx context.Context, name, driver string, cfg config.ConnectionConfiguration) (*replica, error) {
+	db, err := sql.Open(dialects[driver].driverName, cfg.DSN(driver))
+	if err != nil {
+		return nil, err
+	}
+	configurePool(db, cfg.Pool)
+	if err := connectWithRetry(ctx, name, cfg.ConnectRetry, func() error {
+		return db.PingContext(ctx)
+	}); err != nil {
+		logger.Warnf("starting without %s: %v", name, err)
+	}
+	return &replica{name: name, db: db}, nil
+}
+
+// replicaRouter is a GORM plugin sending reads to a healthy replica, in
+// turn, and everything else to the primary. Reads stay on the primary inside
+// transactions, with a locking clause, when no replica is healthy and for a
+// window after their session wrote.
+type replicaRouter struct {
+	replicas []*replica
+	cfg      config.ReplicationConfiguration
+	// lagQuery measures the lag of a replica, see dialect
+	lagQuery string
+	next     uint32
+	writes   *writeTracker
+}
+
+func newReplicaRouter(cfg config.ReplicationConfiguration, lagQuery string) *replicaRouter {
+	return &replicaRouter{
+		cfg:      cfg,
+		lagQuery: lagQuery,
+		writes:   newWriteTracker(cfg.ReadYourWritesWindow, maxTrackedSessions),
+	}
+}
+
+func (r *replicaRouter) Name() string {
+	return "replicas"
+}
+
+func (r *replicaRouter) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	for _, err := range []error{
+		cb.Query().Before("gorm:query").Register("replicas:route_query", r.routeRead),
+		cb.Row().Before("gorm:row").Register("replicas:route_row", r.routeRead),
+		cb.Create().After("gorm:create").Register("replicas:after_create", r.recordWrite),
+		cb.Update().After("gorm:update").Register("replicas:after_update", r.recordWrite),
+		cb.Delete().After("gorm:delete").Register("replicas:after_delete", r.recordWrite),
+		cb.Raw().After("gorm:raw").Register("replicas:after_raw", r.recordWrite),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// routeRead switches the connection of a read to a healthy replica.
+func (r *replicaRouter) routeRead(db *gorm.DB) {
+	if db.Error != nil {
+		return
+	}
+	stmt := db.Statement
+	if _, ok := stmt.ConnPool.(gorm.TxCommitter); ok {
+		return
+	}
+	if _, ok := stmt.Clauses["FOR"]; ok {
+		return
+	}
+	if stmt.SQL.Len() > 0 && !isPlainSelect(stmt.SQL.String()) {
+		return
+	}
+	if replica := r.readReplica(stmt.Context); replica != nil {
+		stmt.ConnPool = replica.db
+	}
+}
+
+// readReplica returns the replica serving the reads of ctx, nil when they
+// stay on the primary.
+func (r *replicaRouter) readReplica(ctx context.Context) *replica {
+	if primary, _ := ctx.Value(primaryKey{}).(bool); primary {
+		return nil
+	}
+	if r.writes.pinned(session.IDFromContext(ctx)) {
+		return nil
+	}
+	return r.pick()
+}
+
+// recordWrite pins the reads of the session to the primary. Without
+// replicas every read already goes to the primary.
+func (r *replicaRouter) recordWrite(db *gorm.DB) {
+	if db.Error != nil || len(r.replicas) == 0 {
+		return
+	}
+	r.writes.record(session.IDFromContext(db.Statement.Context))
+}
+
+// pick returns the next healthy replica, nil when there is none.
+func (r *replicaRouter) pick() *replica {
+	n := len(r.replicas)
+	if n == 0 {
+		return nil
+	}
+	start := atomic.AddUint32(&r.next, 1)
+	for i := 0; i < n; i++ {
+		if replica := r.replicas[(int(start)+i)%n]; replica.isHealthy() {
+			return replica
+		}
+	}
+	return nil
+}
+
+// check measures the lag of every replica and ejects the unreachable or
+// lagging ones.
+func (r *replicaRouter) check(ctx context.Context) {
+	for _, replica := range r.replicas {
+		checkCtx, cancel := context.WithTimeout(ctx, r.cfg.CheckInterval)
+		var lag float64
+		var err error
+		if r.lagQuery == "" {
+			err = replica.db.PingContext(checkCtx)
+		} else {
+			err = replica.db.QueryRowContext(checkCtx, r.lagQuery).Scan(&lag)
+		}
+		cancel()
+
+		lagDuration := time.Duration(lag * float64(time.Second))
+		if err == nil && lagDuration > r.cfg.MaxLag {
+			err = fmt.Errorf("replication lag %s exceeds %s", lagDuration.Round(time.Millisecond), r.cfg.MaxLag)
+		}
+		replica.setHealthy(err == nil, err)
+		metrics.ObserveReplica(replica.name, err == nil, lagDuration)
+	}
+}
+
+// monitor checks the replicas periodically until ctx is done.
+func (r *replicaRouter) monitor(ctx context.Context) {
+	ticker := time.NewTicker(r.cfg.CheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.check(ctx)
+		}
+	}
+}
+
+// isPlainSelect reports whether a raw statement is a read that does not lock
+// rows.
+func isPlainSelect(sql string) bool {
+	sql = strings.ToLower(strings.TrimSpace(sql))
+	return strings.HasPrefix(sql, "select") && !strings.Contains(sql, " for update") && !strings.Contains(sql, " for share")
+}
+
+// writeTracker remembers when each session last wrote. Callers without a
+// session share the empty one, so their writes pin every anonymous read.
+// Expired sessions are forgotten as new ones write, and once max sessions
+// are pinned every read is pinned for a window instead of remembering more.
+type writeTracker struct {
+	window time.Duration
+	max    int
+	now    func() time.Time
+	mu     sync.Mutex
+	last   map[string]time.Time
+	// pruned is when the expired sessions were last forgotten
+	pruned time.Time
+	// overflow is when a session was last refused for lack of room
+	overflow time.Time
+}
+
+func newWriteTracker(window time.Duration, max int) *writeTracker {
+	return &writeTracker{window: window, max: max, now: time.Now, last: map[string]time.Time{}}
+}
+
+func (t *writeTracker) record(id string) {
+	if t.window <= 0 {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := t.now()
+	if _, ok := t.last[id]; !ok && (len(t.last) >= t.max || now.Sub(t.pruned) >= t.window) {
+		t.prune(now)
+	}
+	if _, ok := t.last[id]; ok || len(t.last) < t.max {
+		t.last[id] = now
+		return
+	}
+	t.overflow = now
+}
+
+// pinned reports whether the session wrote within the window.
+func (t *writeTracker) pinned(id string) bool {
+	if t.window <= 0 {
+		return false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := t.now()
+	if now.Sub(t.overflow) < t.window {
+		return true
+	}
+	last, ok := t.last[id]
+	return ok && now.Sub(last) < t.window
+}
+
+// prune forgets the sessions whose window has expired, t.mu must be held.
+func (t *writeTracker) prune(now time.Time) {
+	t.pruned = now
+	for id, last := range t.last {
+		if now.Sub(last) >= t.window {
+			delete(t.last, id)
+		}
+	}
+}
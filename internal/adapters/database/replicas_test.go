@@ -0,0 +1,107 @@
+package database
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// clock is a manual time source for the write tracker.
+type clock struct{ t time.Time }
+
+func (c *clock) now() time.Time          { return c.t }
+func (c *clock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newTestTracker(window time.Duration, max int) (*writeTracker, *clock) {
+	c := &clock{t: time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)}
+	t := newWriteTracker(window, max)
+	t.now = c.now
+	return t, c
+}
+
+func TestWriteTrackerWindow(t *testing.T) {
+	tracker, clock := newTestTracker(5*time.Second, 10)
+
+	if tracker.pinned("alice") {
+		t.Fatal("pinned before writing")
+	}
+	tracker.record("alice")
+	if !tracker.pinned("alice") {
+		t.Fatal("not pinned right after writing")
+	}
+	if tracker.pinned("bob") {
+		t.Fatal("another session is pinned")
+	}
+	clock.advance(4 * time.Second)
+	if !tracker.pinned("alice") {
+		t.Fatal("not pinned within the window")
+	}
+	clock.advance(time.Second)
+	if tracker.pinned("alice") {
+		t.Fatal("still pinned once the window expired")
+	}
+}
+
+func TestWriteTrackerDisabled(t *testing.T) {
+	tracker, _ := newTestTracker(0, 10)
+	tracker.record("alice")
+	if tracker.pinned("alice") || len(tracker.last) != 0 {
+		t.Fatal("a zero window tracks sessions")
+	}
+}
+
+func TestWriteTrackerPrunes(t *testing.T) {
+	tracker, clock := newTestTracker(5*time.Second, 1000)
+	for i := 0; i < 100; i++ {
+		tracker.record(fmt.Sprintf("session-%d", i))
+	}
+	clock.advance(5 * time.Second)
+	tracker.record("alice")
+	if len(tracker.last) != 1 {
+		t.Fatalf("got %d sessions after the window, want 1", len(tracker.last))
+	}
+	if !tracker.pinned("alice") {
+		t.Fatal("the new session is not pinned")
+	}
+}
+
+func TestWriteTrackerCap(t *testing.T) {
+	tracker, clock := newTestTracker(5*time.Second, 3)
+	for _, id := range []string{"a", "b", "c"} {
+		tracker.record(id)
+	}
+	if tracker.pinned("d") {
+		t.Fatal("pinned before the tracker is full")
+	}
+
+	tracker.record("d")
+	if len(tracker.last) != 3 {
+		t.Fatalf("got %d sessions, want at most 3", len(tracker.last))
+	}
+	// the refused session must still read its writes
+	if !tracker.pinned("d") || !tracker.pinned("anyone") {
+		t.Fatal("reads are not pinned once the tracker is full")
+	}
+
+	clock.advance(5 * time.Second)
+	if tracker.pinned("d") {
+		t.Fatal("still pinned once the window expired")
+	}
+	tracker.record("e")
+	if len(tracker.last) != 1 || !tracker.pinned("e") {
+		t.Fatalf("expired sessions were not pruned to make room: %v", tracker.last)
+	}
+}
+
+func TestRecordWriteWithoutReplicas(t *testing.T) {
+	db := newTestDatabase(t, nil)
+	if err := db.Gorm().Exec("CREATE TABLE items (id INTEGER PRIMARY KEY)").Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Gorm().Exec("INSERT INTO items (id) VALUES (1)").Error; err != nil {
+		t.Fatal(err)
+	}
+	if n := len(db.replicas.writes.last); n != 0 {
+		t.Fatalf("got %d sessions tracked without replicas, want 0", n)
+	}
+}
@@ -1,42 +1,250 @@
 package seeds
 
 import (
+	"context"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+
 	"github.com/ponyjackal/go-microservice-boilerplate/internal/adapters/database"
 	"github.com/ponyjackal/go-microservice-boilerplate/internal/domain/models"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/config"
 	"github.com/ponyjackal/go-microservice-boilerplate/pkg/logger"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/tagname"
+
+	"gopkg.in/yaml.v3"
+	"gorm.io/gorm"
 )
 
-func SeedData() error {
-	tags := []models.Tag{
-		{
-			Name: "tag1",
-		},
-		{
-			Name: "tag2",
-		},
-		{
-			Name: "tag3",
-		},
-	}
-	if err := createRecords(&tags); err != nil {
-		logger.Errorf("Failed to create records: %s", err)
+//go:embed fixtures
+var embeddedFixtures embed.FS
+
+// fixtureFiles holds the fixtures directory, the embedded one outside the
+// tests.
+var fixtureFiles fs.FS = embeddedFixtures
+
+// registry maps the model names used in fixtures to a value of the model.
+var registry = map[string]interface{}{
+	"tag":       &models.Tag{},
+	"tag_alias": &models.TagAlias{},
+	"namespace": &models.Namespace{},
+}
+
+// normalizers prepare the records of a model like its service does before
+// saving them. They return the columns to write, including those they set.
+var normalizers = map[string]func(value interface{}, columns []string) []string{
+	"tag": func(value interface{}, columns []string) []string {
+		tag := value.(*models.Tag)
+		tag.Name, tag.Slug = normalizeName(tag.Name)
+		return append(columns, "slug")
+	},
+	"tag_alias": func(value interface{}, columns []string) []string {
+		alias := value.(*models.TagAlias)
+		alias.Name, alias.Slug = normalizeName(alias.Name)
+		return append(columns, "slug")
+	},
+}
+
+// normalizeName returns name normalized with the configured policy and its
+// slug, like SaveTag.
+func normalizeName(name string) (string, string) {
+	name = tagname.Normalize(name, config.NormalizationPolicy())
+	return name, tagname.Slug(name)
+}
+
+// Register makes a model available to fixtures under name.
+func Register(name string, model interface{}) {
+	registry[name] = model
+}
+
+// fixture holds records of one model. Records are written with column names
+// and identified by their natural key, so loading a fixture again updates
+// them instead of creating duplicates.
+type fixture struct {
+	name    string
+	Model   string                   `yaml:"model" json:"model"`
+	Key     []string                 `yaml:"key" json:"key"`
+	Records []map[string]interface{} `yaml:"records" json:"records"`
+}
+
+// Options select what Seed loads.
+type Options struct {
+	// Names restricts the fixtures loaded, by file name without extension.
+	// Every fixture of the environment is loaded when empty.
+	Names []string
+	// Reset deletes every record of the fixture models first, including
+	// soft deleted ones.
+	Reset bool
+}
+
+// Environments lists the environments that have fixtures.
+func Environments() []string {
+	entries, _ := fs.ReadDir(fixtureFiles, "fixtures")
+	var envs []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			envs = append(envs, entry.Name())
+		}
+	}
+	return envs
+}
+
+// Seed upserts the fixtures of env, e.g. dev, test or demo, in one
+// transaction. Fixtures are loaded in file name order.
+func Seed(ctx context.Context, db *database.Database, env string, opts Options) error {
+	fixtures, err := readFixtures(env, opts.Names)
+	if err != nil {
 		return err
 	}
 
-	return nil
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if opts.Reset {
+			// delete in reverse order so dependent records go first
+			for i := len(fixtures) - 1; i >= 0; i-- {
+				if err := reset(db.WithContext(ctx), fixtures[i]); err != nil {
+					return err
+				}
+			}
+		}
+		for _, f := range fixtures {
+			created, updated, err := apply(ctx, db.WithContext(ctx), f)
+			if err != nil {
+				return fmt.Errorf("fixture %s/%s: %w", env, f.name, err)
+			}
+			logger.WithContext(ctx).Infof("seeded %s/%s: %d created, %d updated", env, f.name, created, updated)
+		}
+		return nil
+	})
 }
 
-func createRecords(data interface{}) error {
-	if err := database.DB.Create(data).Error; err != nil {
-		logger.Errorf("Failed to create records: %s", err)
-		return err
+// Load seeds the named fixtures of env, every fixture when names is empty.
+// It is meant for tests preparing their data.
+func Load(ctx context.Context, db *database.Database, env string, names ...string) error {
+	return Seed(ctx, db, env, Options{Names: names})
+}
+
+// readFixtures parses the YAML and JSON fixtures of env.
+func readFixtures(env string, names []string) ([]*fixture, error) {
+	dir := path.Join("fixtures", env)
+	entries, err := fs.ReadDir(fixtureFiles, dir)
+	if err != nil {
+		return nil, fmt.Errorf("unknown seed environment %q, expected one of %s", env, strings.Join(Environments(), ", "))
+	}
+
+	wanted := make(map[string]bool, len(names))
+	for _, name := range names {
+		wanted[name] = true
+	}
+
+	var fixtures []*fixture
+	for _, entry := range entries {
+		ext := path.Ext(entry.Name())
+		name := strings.TrimSuffix(entry.Name(), ext)
+		if entry.IsDir() || (len(wanted) > 0 && !wanted[name]) {
+			continue
+		}
+		data, err := fs.ReadFile(fixtureFiles, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		f := &fixture{name: name}
+		switch ext {
+		case ".yaml", ".yml":
+			err = yaml.Unmarshal(data, f)
+		case ".json":
+			err = json.Unmarshal(data, f)
+		default:
+			return nil, fmt.Errorf("unsupported fixture %s, expected .yaml, .yml or .json", entry.Name())
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse fixture %s: %w", entry.Name(), err)
+		}
+		if _, ok := registry[f.Model]; !ok {
+			return nil, fmt.Errorf("fixture %s: unknown model %q", entry.Name(), f.Model)
+		}
+		if len(f.Key) == 0 {
+			return nil, fmt.Errorf("fixture %s: key is required", entry.Name())
+		}
+		fixtures = append(fixtures, f)
+		delete(wanted, name)
 	}
 
-	return nil
+	if len(wanted) > 0 {
+		missing := make([]string, 0, len(wanted))
+		for name := range wanted {
+			missing = append(missing, name)
+		}
+		sort.Strings(missing)
+		return nil, fmt.Errorf("unknown fixtures in %s: %s", env, strings.Join(missing, ", "))
+	}
+	return fixtures, nil
+}
+
+// apply upserts the records of a fixture by their key.
+func apply(ctx context.Context, db *gorm.DB, f *fixture) (created, updated int, err error) {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(registry[f.Model]); err != nil {
+		return 0, 0, err
+	}
+	schema := stmt.Schema
+
+	for i, record := range f.Records {
+		value := reflect.New(schema.ModelType)
+		columns := make([]string, 0, len(record))
+		for column, v := range record {
+			field := schema.LookUpField(column)
+			if field == nil {
+				return created, updated, fmt.Errorf("record %d: unknown column %q", i, column)
+			}
+			if err := field.Set(ctx, value.Elem(), v); err != nil {
+				return created, updated, fmt.Errorf("record %d: invalid %s: %w", i, column, err)
+			}
+			columns = append(columns, field.DBName)
+		}
+		if normalize := normalizers[f.Model]; normalize != nil && record["name"] != nil {
+			columns = normalize(value.Interface(), columns)
+		}
+		sort.Strings(columns)
+
+		// the key is matched once normalized
+		where := make(map[string]interface{}, len(f.Key))
+		for _, column := range f.Key {
+			if _, ok := record[column]; !ok {
+				return created, updated, fmt.Errorf("record %d: missing key %q", i, column)
+			}
+			where[column], _ = schema.LookUpField(column).ValueOf(ctx, value.Elem())
+		}
+
+		// soft deleted records keep their key, they are updated in place
+		existing := reflect.New(schema.ModelType).Interface()
+		err := db.Unscoped().Where(where).Take(existing).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			if err := db.Create(value.Interface()).Error; err != nil {
+				return created, updated, fmt.Errorf("record %d: %w", i, err)
+			}
+			created++
+		case err != nil:
+			return created, updated, fmt.Errorf("record %d: %w", i, err)
+		default:
+			if err := db.Unscoped().Model(existing).Select(columns).Updates(value.Interface()).Error; err != nil {
+				return created, updated, fmt.Errorf("record %d: %w", i, err)
+			}
+			updated++
+		}
+	}
+	return created, updated, nil
 }
 
-func IsSeedDataExists() bool {
-	var count int64
-	database.DB.Model(&models.Tag{}).Count(&count)
-	return count > 0
+// reset hard deletes every record of the model of a fixture.
+func reset(db *gorm.DB, f *fixture) error {
+	model := reflect.New(reflect.TypeOf(registry[f.Model]).Elem()).Interface()
+	return db.Session(&gorm.Session{AllowGlobalUpdate: true}).Unscoped().Delete(model).Error
 }
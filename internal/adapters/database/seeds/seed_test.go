@@ -0,0 +1,171 @@
+package seeds
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/adapters/database"
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/adapters/database/dbtest"
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/domain/models"
+)
+
+// useFixtures replaces the fixtures until the end of the test.
+func useFixtures(t *testing.T, files fstest.MapFS) {
+	t.Helper()
+	previous := fixtureFiles
+	fixtureFiles = files
+	t.Cleanup(func() { fixtureFiles = previous })
+}
+
+// tags returns the tags of the database by name, soft deleted ones included.
+func tags(t *testing.T, db *database.Database) map[string]models.Tag {
+	t.Helper()
+	var list []models.Tag
+	if err := db.WithContext(context.Background()).Unscoped().Find(&list).Error; err != nil {
+		t.Fatalf("SELECT error: %v", err)
+	}
+	byName := make(map[string]models.Tag, len(list))
+	for _, tag := range list {
+		byName[tag.Name] = tag
+	}
+	return byName
+}
+
+func names(byName map[string]models.Tag) []string {
+	var list []string
+	for name := range byName {
+		list = append(list, name)
+	}
+	sort.Strings(list)
+	return list
+}
+
+func TestLoadIsIdempotent(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.Open(t)
+
+	if err := Load(ctx, db, "test", "tags"); err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	first := tags(t, db)
+	if got, want := names(first), []string{"test-tag-1", "test-tag-2"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("tags = %q, want %q", got, want)
+	}
+
+	if err := Load(ctx, db, "test"); err != nil {
+		t.Fatalf("second Load() error: %v", err)
+	}
+	second := tags(t, db)
+	if len(second) != len(first) {
+		t.Fatalf("got %d tags after loading twice, want %d", len(second), len(first))
+	}
+	for name, tag := range first {
+		if second[name].ID != tag.ID {
+			t.Errorf("tag %s was recreated", name)
+		}
+	}
+}
+
+func TestSeedReset(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.Open(t)
+	extra := models.Tag{Name: "extra"}
+	if err := db.WithContext(ctx).Create(&extra).Error; err != nil {
+		t.Fatalf("INSERT error: %v", err)
+	}
+	// soft deleted records are deleted too
+	if err := db.WithContext(ctx).Delete(&extra).Error; err != nil {
+		t.Fatalf("DELETE error: %v", err)
+	}
+	if err := Load(ctx, db, "test"); err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+
+	if err := Seed(ctx, db, "dev", Options{Reset: true}); err != nil {
+		t.Fatalf("Seed() error: %v", err)
+	}
+	if got, want := names(tags(t, db)), []string{"tag1", "tag2", "tag3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("tags after the reset = %q, want %q", got, want)
+	}
+}
+
+func TestSeedErrors(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.Open(t)
+
+	tests := []struct {
+		name  string
+		env   string
+		names []string
+		want  string
+	}{
+		{"unknown env", "staging", nil, `unknown seed environment "staging"`},
+		{"unknown fixture", "test", []string{"tags", "users"}, "unknown fixtures in test: users"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Seed(ctx, db, tt.env, Options{Names: tt.names})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Seed() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+	// nothing was written
+	if n := len(tags(t, db)); n != 0 {
+		t.Errorf("got %d tags after the errors, want 0", n)
+	}
+
+	useFixtures(t, fstest.MapFS{
+		"fixtures/bad/tags.yaml": {Data: []byte("model: tag\nkey: [namespace, name]\nrecords:\n  - name: go\n")},
+	})
+	if err := Seed(ctx, db, "bad", Options{}); err == nil || !strings.Contains(err.Error(), `missing key "namespace"`) {
+		t.Errorf("Seed() without the key error = %v", err)
+	}
+}
+
+func TestSeedUpdatesListedColumns(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.Open(t)
+	useFixtures(t, fstest.MapFS{
+		"fixtures/env/tags.yaml": {Data: []byte("model: tag\nkey: [namespace, name]\nrecords:\n  - namespace: default\n    name: \" Go  Lang \"\n    usage_count: 3\n")},
+	})
+	if err := Seed(ctx, db, "env", Options{}); err != nil {
+		t.Fatalf("Seed() error: %v", err)
+	}
+	// the name is normalized and the slug computed like SaveTag
+	tag, ok := tags(t, db)["go lang"]
+	if !ok || tag.Slug != "go-lang" || tag.UsageCount != 3 {
+		t.Fatalf("tags = %+v, want go lang used 3 times", tags(t, db))
+	}
+
+	// a column set outside the fixture is kept, the key matches once
+	// normalized
+	parent := models.Tag{Name: "languages"}
+	if err := db.WithContext(ctx).Create(&parent).Error; err != nil {
+		t.Fatalf("INSERT error: %v", err)
+	}
+	if err := db.WithContext(ctx).Model(&tag).Update("parent_id", parent.ID).Error; err != nil {
+		t.Fatalf("UPDATE error: %v", err)
+	}
+	fixtureFiles.(fstest.MapFS)["fixtures/env/tags.yaml"].Data = []byte("model: tag\nkey: [namespace, name]\nrecords:\n  - namespace: default\n    name: Go Lang\n    usage_count: 7\n")
+	if err := Seed(ctx, db, "env", Options{}); err != nil {
+		t.Fatalf("second Seed() error: %v", err)
+	}
+	updated := tags(t, db)["go lang"]
+	if updated.ID != tag.ID || updated.UsageCount != 7 {
+		t.Errorf("tag = %+v, want %s used 7 times", updated, tag.ID)
+	}
+	if updated.ParentID == nil || *updated.ParentID != parent.ID {
+		t.Errorf("parent = %v, want %s kept", updated.ParentID, parent.ID)
+	}
+
+	// the fixture files must be YAML or JSON
+	fixtureFiles.(fstest.MapFS)["fixtures/env/tags.txt"] = &fstest.MapFile{Data: []byte("go")}
+	if err := Seed(ctx, db, "env", Options{}); err == nil || !strings.Contains(err.Error(), "unsupported fixture tags.txt") {
+		t.Errorf("Seed() of a text file error = %v", err)
+	}
+}
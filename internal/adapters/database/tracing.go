@@ -0,0 +1,62 @@
+package database
+
+import (
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const tracingSpanKey = "tracing:span"
+
+// tracingPlugin creates a client span for every query, as a child of the
+// span found in the statement context.
+type tracingPlugin struct {
+	// system is the db.system attribute of the driver
+	system attribute.KeyValue
+}
+
+func (tracingPlugin) Name() string {
+	return "tracing"
+}
+
+func (p tracingPlugin) Initialize(db *gorm.DB) error {
+	return registerCallbacks(db, "tracing", startSpan, p.endSpan)
+}
+
+func startSpan(db *gorm.DB) {
+	ctx, span := tracing.Tracer().Start(db.Statement.Context, "gorm.query", trace.WithSpanKind(trace.SpanKindClient))
+	db.Statement.Context = ctx
+	db.InstanceSet(tracingSpanKey, span)
+}
+
+func (p tracingPlugin) endSpan(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(tracingSpanKey)
+		if !ok {
+			return
+		}
+		span, ok := value.(trace.Span)
+		if !ok {
+			return
+		}
+		defer span.End()
+
+		table := tableName(db)
+		span.SetName("gorm." + operation + " " + table)
+		span.SetAttributes(
+			p.system,
+			semconv.DBOperation(operation),
+			semconv.DBSQLTable(table),
+			semconv.DBStatement(db.Statement.SQL.String()),
+			attribute.Int64("db.rows_affected", db.RowsAffected),
+		)
+		if db.Error != nil && db.Error != gorm.ErrRecordNotFound {
+			span.RecordError(db.Error)
+			span.SetStatus(codes.Error, db.Error.Error())
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/tracing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+func TestQuerySpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := tracing.NewProvider(recorder, resource.Empty(), 1)
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	db := newTestDatabase(t, nil)
+	if err := db.Gorm().Exec("CREATE TABLE items (id INTEGER PRIMARY KEY)").Error; err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, parent := tracing.Tracer().Start(context.Background(), "request")
+	var count int64
+	if err := db.WithContext(ctx).Table("items").Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+	parent.End()
+
+	var query sdktrace.ReadOnlySpan
+	for _, span := range recorder.Ended() {
+		if span.Name() == "gorm.query items" {
+			query = span
+		}
+	}
+	if query == nil {
+		t.Fatalf("no span for the query among %d spans", len(recorder.Ended()))
+	}
+	if query.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Errorf("the query span is not a child of the request span")
+	}
+	var system string
+	for _, attr := range query.Attributes() {
+		if attr.Key == semconv.DBSystemKey {
+			system = attr.Value.AsString()
+		}
+	}
+	if system != "sqlite" {
+		t.Errorf("got db.system %q, want sqlite", system)
+	}
+}
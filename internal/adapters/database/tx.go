@@ -0,0 +1,93 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"math/rand"
+	"time"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/logger"
+
+	"gorm.io/gorm"
+)
+
+// txKey stores the transaction of a database in a context.
+type txKey struct {
+	db *Database
+}
+
+// WithTx runs fn in a transaction and commits it when fn returns nil. Every
+// query made through WithContext with the context given to fn, or one derived
+// from it, joins the transaction.
+//
+// Nested calls run in a savepoint, so a failing inner call only rolls back
+// its own changes. The outermost call is retried on serialization failures
+// and deadlocks, fn must therefore be safe to run more than once.
+func (d *Database) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	run := func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{db: d}, tx))
+	}
+	if tx, ok := ctx.Value(txKey{db: d}).(*gorm.DB); ok {
+		return tx.WithContext(ctx).Transaction(run)
+	}
+
+	opts := &sql.TxOptions{Isolation: isolationLevel(d.tx.Isolation)}
+	backoff := d.tx.RetryBackoff
+	if backoff > d.tx.MaxRetryBackoff {
+		backoff = d.tx.MaxRetryBackoff
+	}
+	for attempt := 1; ; attempt++ {
+		err := d.db.WithContext(ctx).Transaction(run, opts)
+		if err == nil || attempt >= d.tx.MaxAttempts || !d.dialect.retryable(err) {
+			return err
+		}
+
+		delay := jitter(backoff)
+		logger.WithContext(ctx).Warnf("transaction failed (attempt %d/%d), retrying in %s: %v", attempt, d.tx.MaxAttempts, delay, err)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+		backoff *= 2
+		if backoff > d.tx.MaxRetryBackoff {
+			backoff = d.tx.MaxRetryBackoff
+		}
+	}
+}
+
+// jitter returns a random delay between half of backoff and backoff.
+func jitter(backoff time.Duration) time.Duration {
+	if backoff <= 1 {
+		return backoff
+	}
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+}
+
+// WithReadTx runs fn in a read-only transaction on the replica that would
+// serve the reads of ctx, or on the primary. It suits reads needing settings
+// local to a transaction, such as set_config(..., true). It is not retried,
+// and joins the transaction of ctx if there is one.
+func (d *Database) WithReadTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{db: d}).(*gorm.DB); ok {
+		return fn(ctx)
+	}
+	db := d.db.WithContext(ctx)
+	if replica := d.replicas.readReplica(ctx); replica != nil {
+		db.Statement.ConnPool = replica.db
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{db: d}, tx))
+	}, &sql.TxOptions{ReadOnly: true})
+}
+
+// isolationLevel converts a database.transaction.isolation value.
+func isolationLevel(name string) sql.IsolationLevel {
+	switch name {
+	case "repeatable_read":
+		return sql.LevelRepeatableRead
+	case "serializable":
+		return sql.LevelSerializable
+	}
+	return sql.LevelReadCommitted
+}
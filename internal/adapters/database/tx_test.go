@@ -0,0 +1,240 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/config"
+)
+
+var (
+	errConflict = errors.New("conflict")
+	errFailure  = errors.New("failure")
+)
+
+// newTxDatabase returns a test database with an items table, retrying the
+// transactions failing with errConflict. The retries would wait for an hour
+// if the backoff was not capped.
+func newTxDatabase(t *testing.T) *Database {
+	t.Helper()
+	d := newTestDatabase(t, func(cfg *config.DatabaseConfiguration) {
+		cfg.Transaction.RetryBackoff = time.Hour
+		cfg.Transaction.MaxRetryBackoff = time.Millisecond
+	})
+	d.dialect.retryable = func(err error) bool { return errors.Is(err, errConflict) }
+	if err := d.WithContext(context.Background()).Exec("CREATE TABLE items (name TEXT NOT NULL)").Error; err != nil {
+		t.Fatalf("CREATE TABLE error: %v", err)
+	}
+	return d
+}
+
+func insertItem(ctx context.Context, d *Database, name string) error {
+	return d.WithContext(ctx).Exec("INSERT INTO items (name) VALUES (?)", name).Error
+}
+
+// items returns the names of the items visible from ctx.
+func items(t *testing.T, ctx context.Context, d *Database) []string {
+	t.Helper()
+	var names []string
+	if err := d.WithContext(ctx).Raw("SELECT name FROM items ORDER BY name").Scan(&names).Error; err != nil {
+		t.Fatalf("SELECT error: %v", err)
+	}
+	return names
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestWithTxNested(t *testing.T) {
+	ctx := context.Background()
+	d := newTxDatabase(t)
+
+	err := d.WithTx(ctx, func(ctx context.Context) error {
+		if err := insertItem(ctx, d, "outer"); err != nil {
+			return err
+		}
+		return d.WithTx(ctx, func(ctx context.Context) error {
+			// the savepoint sees the changes of the transaction
+			if got := items(t, ctx, d); !equal(got, []string{"outer"}) {
+				t.Errorf("items in the savepoint = %q, want [outer]", got)
+			}
+			return insertItem(ctx, d, "inner")
+		})
+	})
+	if err != nil {
+		t.Fatalf("WithTx() error: %v", err)
+	}
+	if got := items(t, ctx, d); !equal(got, []string{"inner", "outer"}) {
+		t.Errorf("items = %q, want [inner outer]", got)
+	}
+}
+
+func TestWithTxInnerRollback(t *testing.T) {
+	ctx := context.Background()
+	d := newTxDatabase(t)
+
+	err := d.WithTx(ctx, func(ctx context.Context) error {
+		if err := insertItem(ctx, d, "outer"); err != nil {
+			return err
+		}
+		err := d.WithTx(ctx, func(ctx context.Context) error {
+			if err := insertItem(ctx, d, "inner"); err != nil {
+				return err
+			}
+			return errConflict
+		})
+		if !errors.Is(err, errConflict) {
+			t.Errorf("inner WithTx() error = %v, want %v", err, errConflict)
+		}
+		return insertItem(ctx, d, "after")
+	})
+	if err != nil {
+		t.Fatalf("WithTx() error: %v", err)
+	}
+	if got := items(t, ctx, d); !equal(got, []string{"after", "outer"}) {
+		t.Errorf("items = %q, want [after outer]", got)
+	}
+}
+
+func TestWithTxRollback(t *testing.T) {
+	ctx := context.Background()
+	d := newTxDatabase(t)
+
+	err := d.WithTx(ctx, func(ctx context.Context) error {
+		if err := insertItem(ctx, d, "outer"); err != nil {
+			return err
+		}
+		return errFailure
+	})
+	if !errors.Is(err, errFailure) {
+		t.Fatalf("WithTx() error = %v, want %v", err, errFailure)
+	}
+	if got := items(t, ctx, d); len(got) != 0 {
+		t.Errorf("items = %q, want none", got)
+	}
+}
+
+func TestWithTxRetries(t *testing.T) {
+	tests := []struct {
+		name     string
+		failures int
+		err      error
+		wantRuns int
+		wantErr  error
+	}{
+		{name: "succeeds", failures: 2, err: errConflict, wantRuns: 3},
+		{name: "gives up", failures: 5, err: errConflict, wantRuns: 3, wantErr: errConflict},
+		{name: "not retryable", failures: 5, err: errFailure, wantRuns: 1, wantErr: errFailure},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			d := newTxDatabase(t)
+
+			runs := 0
+			err := d.WithTx(ctx, func(ctx context.Context) error {
+				runs++
+				if err := insertItem(ctx, d, "item"); err != nil {
+					return err
+				}
+				if runs <= tt.failures {
+					return tt.err
+				}
+				return nil
+			})
+			if runs != tt.wantRuns {
+				t.Errorf("runs = %d, want %d", runs, tt.wantRuns)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("WithTx() error = %v, want %v", err, tt.wantErr)
+			}
+
+			// the failed runs were rolled back
+			want := []string{"item"}
+			if err != nil {
+				want = nil
+			}
+			if got := items(t, ctx, d); !equal(got, want) {
+				t.Errorf("items = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestWithTxRetriesOnlyOutermost(t *testing.T) {
+	ctx := context.Background()
+	d := newTxDatabase(t)
+
+	outer, inner := 0, 0
+	err := d.WithTx(ctx, func(ctx context.Context) error {
+		outer++
+		return d.WithTx(ctx, func(ctx context.Context) error {
+			inner++
+			if outer == 1 {
+				return errConflict
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		t.Fatalf("WithTx() error: %v", err)
+	}
+	if outer != 2 || inner != 2 {
+		t.Errorf("runs = %d outer, %d inner, want 2 of each", outer, inner)
+	}
+}
+
+func TestJitter(t *testing.T) {
+	for _, backoff := range []time.Duration{0, 1, 2, time.Millisecond, time.Second} {
+		for i := 0; i < 100; i++ {
+			if got := jitter(backoff); got < backoff/2 || got > backoff {
+				t.Fatalf("jitter(%s) = %s, want between %s and %s", backoff, got, backoff/2, backoff)
+			}
+		}
+	}
+}
+
+func TestWithReadTx(t *testing.T) {
+	ctx := context.Background()
+	d := newTxDatabase(t)
+	if err := insertItem(ctx, d, "a"); err != nil {
+		t.Fatalf("insert error: %v", err)
+	}
+
+	err := d.WithReadTx(ctx, func(ctx context.Context) error {
+		if got := items(t, ctx, d); !equal(got, []string{"a"}) {
+			t.Errorf("items = %q, want [a]", got)
+		}
+		return errFailure
+	})
+	if !errors.Is(err, errFailure) {
+		t.Errorf("WithReadTx() error = %v, want %v", err, errFailure)
+	}
+
+	// inside a transaction it joins it and sees its writes
+	err = d.WithTx(ctx, func(ctx context.Context) error {
+		if err := insertItem(ctx, d, "b"); err != nil {
+			return err
+		}
+		return d.WithReadTx(ctx, func(ctx context.Context) error {
+			if got := items(t, ctx, d); !equal(got, []string{"a", "b"}) {
+				t.Errorf("items = %q, want [a b]", got)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		t.Fatalf("WithTx() error: %v", err)
+	}
+}
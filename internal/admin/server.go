@@ -0,0 +1,68 @@
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/config"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/flags"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/logger"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/metrics"
+)
+
+// NewHandler returns the handler of the admin server, kept separate from the
+// public API so it can be exposed on an internal port only.
+func NewHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+	mux.HandleFunc("/debug/config", debugConfig)
+	mux.HandleFunc("/debug/flags", debugFlags)
+	return mux
+}
+
+// debugConfig dumps the effective configuration with secrets redacted.
+func debugConfig(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(config.Get().Redacted()); err != nil {
+		logger.Errorf("failed to write config: %v", err)
+	}
+}
+
+// debugFlags lists the feature flag definitions and the active overrides.
+func debugFlags(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(map[string]interface{}{
+		"flags":     flags.All(),
+		"overrides": flags.Overrides(),
+	}); err != nil {
+		logger.Errorf("failed to write flags: %v", err)
+	}
+}
+
+// NewServer creates the admin server listening on server.admin_port. It
+// returns nil when the admin server is disabled.
+func NewServer() *http.Server {
+	server := config.Get().Server
+	if server.AdminPort == "" {
+		return nil
+	}
+
+	logger.Infof("Serving admin endpoints on %s:%s", server.Host, server.AdminPort)
+	return &http.Server{
+		Addr:    fmt.Sprintf(":%s", server.AdminPort),
+		Handler: NewHandler(),
+	}
+}
@@ -0,0 +1,60 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/config"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/logger"
+)
+
+func TestDebugConfig(t *testing.T) {
+	t.Setenv("MASTER_DB_PASSWORD", "hunter2")
+	t.Setenv("SECRET", "signing-key")
+	err := config.SetupConfig([]string{"--database.driver=sqlite", "--database.master.name=test.db"})
+	if err != nil {
+		t.Fatalf("SetupConfig() error: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	NewHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/config", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /debug/config = %d, want 200", rec.Code)
+	}
+	body := rec.Body.String()
+	for _, secret := range []string{"hunter2", "signing-key"} {
+		if strings.Contains(body, secret) {
+			t.Errorf("GET /debug/config shows the secret %q", secret)
+		}
+	}
+
+	var dump struct {
+		Server struct {
+			Secret string `json:"secret"`
+		} `json:"server"`
+		Database struct {
+			Master struct {
+				Name     string `json:"name"`
+				Password string `json:"password"`
+			} `json:"master"`
+		} `json:"database"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &dump); err != nil {
+		t.Fatalf("GET /debug/config returned invalid JSON: %v", err)
+	}
+	if dump.Server.Secret != logger.RedactedValue || dump.Database.Master.Password != logger.RedactedValue {
+		t.Errorf("secrets = %q and %q, want %s", dump.Server.Secret, dump.Database.Master.Password, logger.RedactedValue)
+	}
+	if dump.Database.Master.Name != "test.db" {
+		t.Errorf("database.master.name = %q, want test.db", dump.Database.Master.Name)
+	}
+
+	rec = httptest.NewRecorder()
+	NewHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/debug/config", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST /debug/config = %d, want 405", rec.Code)
+	}
+}
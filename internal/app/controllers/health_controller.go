@@ -0,0 +1,37 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/health"
+
+	"github.com/gin-gonic/gin"
+)
+
+type HealthController struct {
+	checker *health.Checker
+}
+
+func NewHealthController(checker *health.Checker) *HealthController {
+	return &HealthController{
+		checker: checker,
+	}
+}
+
+// Livez reports that the process is running, without checking dependencies.
+// Probes are served outside the /api/v1 base path and are not part of the
+// swagger document.
+func (c *HealthController) Livez(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, gin.H{"status": health.StatusUp})
+}
+
+// Readyz reports the status and latency of every dependency, answering 503
+// when one of them is down or the service is shutting down.
+func (c *HealthController) Readyz(ctx *gin.Context) {
+	report := c.checker.Readiness(ctx.Request.Context())
+	if report.Status != health.StatusUp {
+		ctx.JSON(http.StatusServiceUnavailable, report)
+		return
+	}
+	ctx.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,155 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/domain/services"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/logger"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/utils"
+	pbTag "github.com/ponyjackal/go-microservice-boilerplate/proto/tag"
+
+	"github.com/bufbuild/protovalidate-go"
+	"github.com/gin-gonic/gin"
+)
+
+type NamespaceController struct {
+	namespaceService *services.NamespaceService
+	validator        *protovalidate.Validator
+}
+
+func NewNamespaceController(namespaceService *services.NamespaceService) *NamespaceController {
+	// Create a new validator
+	validator, err := protovalidate.New()
+	if err != nil {
+		logger.Errorf("failed to initialize validator: %v", err)
+	}
+
+	return &NamespaceController{
+		namespaceService: namespaceService,
+		validator:        validator,
+	}
+}
+
+// GetNamespaces godoc
+// @Summary List namespaces
+// @Description Get every tag namespace with its settings sorted by name
+// @Tags Namespaces
+// @Produce json
+// @Success 200 {object} pbTag.GetNamespacesResponse "Namespaces"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /namespaces [get]
+func (c *NamespaceController) GetNamespaces(ctx *gin.Context) {
+	response, err := c.namespaceService.GetNamespaces(ctx.Request.Context())
+	if err != nil {
+		utils.GRPCErrorHandler(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetNamespace godoc
+// @Summary Get namespace
+// @Description Get a tag namespace by name
+// @Tags Namespaces
+// @Produce json
+// @Param name path string true "Namespace name"
+// @Success 200 {object} pbTag.Namespace "Namespace"
+// @Failure 404 {object} map[string]string "Not Found"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /namespaces/{name} [get]
+func (c *NamespaceController) GetNamespace(ctx *gin.Context) {
+	response, err := c.namespaceService.GetNamespace(ctx.Request.Context(), &pbTag.NamespaceName{
+		Name: ctx.Param("name"),
+	})
+	if err != nil {
+		utils.GRPCErrorHandler(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// CreateNamespace godoc
+// @Summary Create namespace
+// @Description Create a tag namespace with its settings
+// @Tags Namespaces
+// @Accept json
+// @Produce json
+// @Param namespace body pbTag.SaveNamespaceRequest true "Namespace"
+// @Success 201 {object} pbTag.Namespace "Successfully created namespace"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 409 {object} map[string]string "Conflict"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /namespaces [post]
+func (c *NamespaceController) CreateNamespace(ctx *gin.Context) {
+	var request pbTag.SaveNamespaceRequest
+	if err := ctx.BindJSON(&request); err != nil {
+		invalidRequest(ctx, err)
+		return
+	}
+	if err := c.validator.Validate(&request); err != nil {
+		invalidRequest(ctx, err)
+		return
+	}
+
+	response, err := c.namespaceService.CreateNamespace(ctx.Request.Context(), &request)
+	if err != nil {
+		utils.GRPCErrorHandler(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, response)
+}
+
+// UpdateNamespace godoc
+// @Summary Update namespace
+// @Description Update the settings of a tag namespace, they apply to the tags created or renamed afterwards
+// @Tags Namespaces
+// @Accept json
+// @Produce json
+// @Param name path string true "Namespace name"
+// @Param namespace body pbTag.SaveNamespaceRequest true "Settings, the name is taken from the path"
+// @Success 200 {object} pbTag.Namespace "Successfully updated namespace"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 404 {object} map[string]string "Not Found"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /namespaces/{name} [put]
+func (c *NamespaceController) UpdateNamespace(ctx *gin.Context) {
+	var request pbTag.SaveNamespaceRequest
+	if err := ctx.BindJSON(&request); err != nil {
+		invalidRequest(ctx, err)
+		return
+	}
+	request.Name = ctx.Param("name")
+	if err := c.validator.Validate(&request); err != nil {
+		invalidRequest(ctx, err)
+		return
+	}
+
+	response, err := c.namespaceService.UpdateNamespace(ctx.Request.Context(), &request)
+	if err != nil {
+		utils.GRPCErrorHandler(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// DeleteNamespace godoc
+// @Summary Remove namespace
+// @Description Remove a tag namespace without tags, along with its deleted tags. The default namespace cannot be removed
+// @Tags Namespaces
+// @Produce json
+// @Param name path string true "Namespace name"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 404 {object} map[string]string "Not Found"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /namespaces/{name} [delete]
+func (c *NamespaceController) DeleteNamespace(ctx *gin.Context) {
+	err := c.namespaceService.DeleteNamespace(ctx.Request.Context(), &pbTag.NamespaceName{
+		Name: ctx.Param("name"),
+	})
+	if err != nil {
+		utils.GRPCErrorHandler(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "Namespace deleted successfully"})
+}
@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/ponyjackal/go-microservice-boilerplate/internal/domain/services"
 	"github.com/ponyjackal/go-microservice-boilerplate/pkg/logger"
@@ -32,18 +33,103 @@ func NewTagController(tagService *services.TagService) *TagController {
 
 // GetTags godoc
 // @Summary Retrieve a list of tags
-// @Description Get a list of tags filtered by the name parameter
+// @Description Get a list of tags filtered by the name and parent_id parameters
 // @Tags Tags
 // @Accept json
 // @Produce json
 // @Param name query string false "Name of the tag to filter by"
+// @Param parent_id query string false "Only the tags right below this tag"
+// @Param namespace query string false "Namespace of the tags, the default one when empty"
+// @Param X-Timezone header string false "IANA timezone of the *_local fields, e.g. Europe/Paris"
 // @Success 200 {object} pbTag.GetTagsResponse "Successful retrieval of tags"
+// @Failure 400 {object} map[string]string "Bad Request"
 // @Failure 500 {object} map[string]string "Internal Server Error"
 // @Router /tags [get]
 func (c *TagController) GetTags(ctx *gin.Context) {
+	namespace := ctx.Query("namespace")
 	name := ctx.Query("name")
+	parentID := ctx.Query("parent_id")
 
-	response, err := c.tagService.GetTags(name)
+	response, err := c.tagService.GetTags(ctx.Request.Context(), namespace, name, parentID)
+	if err != nil {
+		utils.GRPCErrorHandler(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// SearchTags godoc
+// @Summary Search tags
+// @Description Search tags by name, ranking exact, prefix and fuzzy matches. Fuzzy matches tolerate typos down to the similarity threshold
+// @Tags Tags
+// @Produce json
+// @Param query query string true "Text to search in tag names"
+// @Param threshold query number false "Minimum similarity, between 0 and 1, of a fuzzy match"
+// @Param limit query integer false "Maximum number of matches"
+// @Param namespace query string false "Namespace of the tags, the default one when empty"
+// @Param X-Timezone header string false "IANA timezone of the *_local fields, e.g. Europe/Paris"
+// @Success 200 {object} pbTag.SearchTagsResponse "Ranked matches"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /tags:search [get]
+func (c *TagController) SearchTags(ctx *gin.Context) {
+	query := pbTag.SearchTagsQuery{Query: ctx.Query("query"), Namespace: ctx.Query("namespace")}
+	if threshold := ctx.Query("threshold"); threshold != "" {
+		value, err := strconv.ParseFloat(threshold, 64)
+		if err != nil {
+			invalidRequest(ctx, err)
+			return
+		}
+		query.Threshold = value
+	}
+	if limit := ctx.Query("limit"); limit != "" {
+		value, err := strconv.ParseInt(limit, 10, 32)
+		if err != nil {
+			invalidRequest(ctx, err)
+			return
+		}
+		query.Limit = int32(value)
+	}
+	if err := c.validator.Validate(&query); err != nil {
+		invalidRequest(ctx, err)
+		return
+	}
+
+	response, err := c.tagService.SearchTags(ctx.Request.Context(), &query)
+	if err != nil {
+		utils.GRPCErrorHandler(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// AutocompleteTags godoc
+// @Summary Autocomplete tags
+// @Description Suggest the tags whose name starts with the prefix, ignoring case, the most used first
+// @Tags Tags
+// @Produce json
+// @Param prefix query string true "Start of the tag names"
+// @Param limit query integer false "Maximum number of suggestions"
+// @Param namespace query string false "Namespace of the tags, the default one when empty"
+// @Success 200 {object} pbTag.AutocompleteTagsResponse "Suggestions"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Router /tags:autocomplete [get]
+func (c *TagController) AutocompleteTags(ctx *gin.Context) {
+	query := pbTag.AutocompleteTagsQuery{Prefix: ctx.Query("prefix"), Namespace: ctx.Query("namespace")}
+	if limit := ctx.Query("limit"); limit != "" {
+		value, err := strconv.ParseInt(limit, 10, 32)
+		if err != nil {
+			invalidRequest(ctx, err)
+			return
+		}
+		query.Limit = int32(value)
+	}
+	if err := c.validator.Validate(&query); err != nil {
+		invalidRequest(ctx, err)
+		return
+	}
+
+	response, err := c.tagService.AutocompleteTags(ctx.Request.Context(), &query)
 	if err != nil {
 		utils.GRPCErrorHandler(ctx, err)
 		return
@@ -53,18 +139,21 @@ func (c *TagController) GetTags(ctx *gin.Context) {
 
 // GetTagById godoc
 // @Summary Retrieve a tag
-// @Description Get tag by id from the database
+// @Description Get tag by id from the database, the id of an alias resolves to its tag with redirected_from set
 // @Tags Tags
 // @Produce json
 // @Param id path string true "Tag ID"
+// @Param namespace query string false "Namespace of the tag, the default one when empty"
+// @Param X-Timezone header string false "IANA timezone of the *_local fields, e.g. Europe/Paris"
 // @Success 200 {object} pbTag.Tag "Successfully retrieved a tag"
 // @Failure 500 {object} map[string]string "Internal Server Error"
 // @Router /tags/{id} [get]
 func (c *TagController) GetTagById(ctx *gin.Context) {
 	id := ctx.Param("id")
 
-	response, err := c.tagService.GetTagById(&pbTag.TagId{
-		Id: id,
+	response, err := c.tagService.GetTagById(ctx.Request.Context(), &pbTag.TagId{
+		Id:        id,
+		Namespace: ctx.Query("namespace"),
 	})
 	if err != nil {
 		utils.GRPCErrorHandler(ctx, err)
@@ -73,6 +162,33 @@ func (c *TagController) GetTagById(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, response)
 }
 
+// GetTagBySlug godoc
+// @Summary Retrieve a tag by slug
+// @Description Get tag by the slug derived from its name, the slug of an alias resolves to its tag with redirected_from set
+// @Tags Tags
+// @Produce json
+// @Param slug path string true "Tag slug"
+// @Param namespace query string false "Namespace of the tag, the default one when empty"
+// @Param X-Timezone header string false "IANA timezone of the *_local fields, e.g. Europe/Paris"
+// @Success 200 {object} pbTag.Tag "Successfully retrieved a tag"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 404 {object} map[string]string "Not Found"
+// @Router /tags/by-slug/{slug} [get]
+func (c *TagController) GetTagBySlug(ctx *gin.Context) {
+	request := pbTag.TagSlug{Slug: ctx.Param("slug"), Namespace: ctx.Query("namespace")}
+	if err := c.validator.Validate(&request); err != nil {
+		invalidRequest(ctx, err)
+		return
+	}
+
+	response, err := c.tagService.GetTagBySlug(ctx.Request.Context(), &request)
+	if err != nil {
+		utils.GRPCErrorHandler(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
 // SaveTag godoc
 // @Summary Add a new tag
 // @Description Add a tag with the provided information
@@ -80,26 +196,27 @@ func (c *TagController) GetTagById(ctx *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param tag body pbTag.SaveTagRequest true "Tag Object"
+// @Param X-Timezone header string false "IANA timezone of the *_local fields, e.g. Europe/Paris"
 // @Success 201 {object} models.Tag "Successfully created tag"
 // @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 404 {object} map[string]string "Parent Not Found"
+// @Failure 409 {object} map[string]string "Conflict"
 // @Failure 500 {object} map[string]string "Internal Server Error"
 // @Router /tags [post]
 func (c *TagController) SaveTag(ctx *gin.Context) {
 	var tagReq pbTag.SaveTagRequest
 
 	if err := ctx.BindJSON(&tagReq); err != nil {
-		logger.Errorf("Invalid request: %s", err)
-		ctx.JSON(http.StatusBadRequest, gin.H{"message": "Invalid request", "error": err})
+		invalidRequest(ctx, err)
 		return
 	}
 	// Validate the request
 	if err := c.validator.Validate(&tagReq); err != nil {
-		logger.Errorf("Invalid request: %s", err)
-		ctx.JSON(http.StatusBadRequest, gin.H{"message": "Invalid request", "error": err})
+		invalidRequest(ctx, err)
 		return
 	}
 
-	response, err := c.tagService.SaveTag(&tagReq)
+	response, err := c.tagService.SaveTag(ctx.Request.Context(), &tagReq)
 	if err != nil {
 		utils.GRPCErrorHandler(ctx, err)
 		return
@@ -116,8 +233,10 @@ func (c *TagController) SaveTag(ctx *gin.Context) {
 // @Produce json
 // @Param id path string true "Tag ID"
 // @Param tag body pbTag.SaveTagRequest true "Tag Object"
+// @Param X-Timezone header string false "IANA timezone of the *_local fields, e.g. Europe/Paris"
 // @Success 200 {object} pbTag.Tag "Successfully updated a tag"
 // @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 409 {object} map[string]string "Conflict"
 // @Failure 500 {object} map[string]string "Internal Server Error"
 // @Router /tags/{id} [put]
 func (c *TagController) UpdateTag(ctx *gin.Context) {
@@ -125,12 +244,11 @@ func (c *TagController) UpdateTag(ctx *gin.Context) {
 
 	var tagReq pbTag.SaveTagRequest
 	if err := ctx.BindJSON(&tagReq); err != nil {
-		logger.Errorf("Invalid request: %s", err)
-		ctx.JSON(http.StatusBadRequest, gin.H{"message": "Invalid request", "error": err})
+		invalidRequest(ctx, err)
 		return
 	}
 
-	tag, err := c.tagService.UpdateTag(&pbTag.UpdateTagRequest{
+	tag, err := c.tagService.UpdateTag(ctx.Request.Context(), &pbTag.UpdateTagRequest{
 		Id:     id,
 		TagReq: &tagReq,
 	})
@@ -142,6 +260,260 @@ func (c *TagController) UpdateTag(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, &tag)
 }
 
+// GetTagAliases godoc
+// @Summary List tag aliases
+// @Description Get the aliases of a tag sorted by name
+// @Tags Tags
+// @Produce json
+// @Param id path string true "Tag ID"
+// @Param namespace query string false "Namespace of the tag, the default one when empty"
+// @Success 200 {object} pbTag.TagAliases "Aliases"
+// @Failure 404 {object} map[string]string "Not Found"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /tags/{id}/aliases [get]
+func (c *TagController) GetTagAliases(ctx *gin.Context) {
+	response, err := c.tagService.GetTagAliases(ctx.Request.Context(), &pbTag.TagId{
+		Id:        ctx.Param("id"),
+		Namespace: ctx.Query("namespace"),
+	})
+	if err != nil {
+		utils.GRPCErrorHandler(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// AddTagAlias godoc
+// @Summary Add a tag alias
+// @Description Add an alternative name resolved to the tag
+// @Tags Tags
+// @Accept json
+// @Produce json
+// @Param id path string true "Tag ID"
+// @Param alias body pbTag.AddTagAliasRequest true "Alias, its tag_id is taken from the path"
+// @Success 201 {object} pbTag.TagAlias "Successfully added alias"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 404 {object} map[string]string "Not Found"
+// @Failure 409 {object} map[string]string "Conflict"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /tags/{id}/aliases [post]
+func (c *TagController) AddTagAlias(ctx *gin.Context) {
+	var request pbTag.AddTagAliasRequest
+	if err := ctx.BindJSON(&request); err != nil {
+		invalidRequest(ctx, err)
+		return
+	}
+	request.TagId = ctx.Param("id")
+	if err := c.validator.Validate(&request); err != nil {
+		invalidRequest(ctx, err)
+		return
+	}
+
+	response, err := c.tagService.AddTagAlias(ctx.Request.Context(), &request)
+	if err != nil {
+		utils.GRPCErrorHandler(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, response)
+}
+
+// RemoveTagAlias godoc
+// @Summary Remove a tag alias
+// @Description Remove an alias of a tag by id
+// @Tags Tags
+// @Produce json
+// @Param id path string true "Tag ID"
+// @Param alias_id path string true "Alias ID"
+// @Param namespace query string false "Namespace of the tag, the default one when empty"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string "Not Found"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /tags/{id}/aliases/{alias_id} [delete]
+func (c *TagController) RemoveTagAlias(ctx *gin.Context) {
+	err := c.tagService.RemoveTagAlias(ctx.Request.Context(), &pbTag.TagAliasId{
+		TagId:     ctx.Param("id"),
+		Id:        ctx.Param("alias_id"),
+		Namespace: ctx.Query("namespace"),
+	})
+	if err != nil {
+		utils.GRPCErrorHandler(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "Tag alias removed successfully"})
+}
+
+// GetTagChildren godoc
+// @Summary List tag children
+// @Description Get the tags right below a tag sorted by name
+// @Tags Tags
+// @Produce json
+// @Param id path string true "Tag ID"
+// @Param namespace query string false "Namespace of the tag, the default one when empty"
+// @Param X-Timezone header string false "IANA timezone of the *_local fields, e.g. Europe/Paris"
+// @Success 200 {object} pbTag.GetTagsResponse "Children"
+// @Failure 404 {object} map[string]string "Not Found"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /tags/{id}/children [get]
+func (c *TagController) GetTagChildren(ctx *gin.Context) {
+	response, err := c.tagService.GetTagChildren(ctx.Request.Context(), &pbTag.TagId{
+		Id:        ctx.Param("id"),
+		Namespace: ctx.Query("namespace"),
+	})
+	if err != nil {
+		utils.GRPCErrorHandler(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetTagAncestors godoc
+// @Summary List tag ancestors
+// @Description Get the ancestors of a tag from its root down to its parent
+// @Tags Tags
+// @Produce json
+// @Param id path string true "Tag ID"
+// @Param namespace query string false "Namespace of the tag, the default one when empty"
+// @Param X-Timezone header string false "IANA timezone of the *_local fields, e.g. Europe/Paris"
+// @Success 200 {object} pbTag.GetTagsResponse "Ancestors"
+// @Failure 404 {object} map[string]string "Not Found"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /tags/{id}/ancestors [get]
+func (c *TagController) GetTagAncestors(ctx *gin.Context) {
+	response, err := c.tagService.GetTagAncestors(ctx.Request.Context(), &pbTag.TagId{
+		Id:        ctx.Param("id"),
+		Namespace: ctx.Query("namespace"),
+	})
+	if err != nil {
+		utils.GRPCErrorHandler(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetTagSubtree godoc
+// @Summary List a tag subtree
+// @Description Get every descendant of a tag with its depth, level by level
+// @Tags Tags
+// @Produce json
+// @Param id path string true "Tag ID"
+// @Param namespace query string false "Namespace of the tag, the default one when empty"
+// @Param X-Timezone header string false "IANA timezone of the *_local fields, e.g. Europe/Paris"
+// @Success 200 {object} pbTag.TagSubtree "Descendants"
+// @Failure 404 {object} map[string]string "Not Found"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /tags/{id}/subtree [get]
+func (c *TagController) GetTagSubtree(ctx *gin.Context) {
+	response, err := c.tagService.GetTagSubtree(ctx.Request.Context(), &pbTag.TagId{
+		Id:        ctx.Param("id"),
+		Namespace: ctx.Query("namespace"),
+	})
+	if err != nil {
+		utils.GRPCErrorHandler(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// RecordTagUsage godoc
+// @Summary Record tag usage
+// @Description Add to the usage count of a tag, which ranks the autocompletions. Called by the services tagging items, with a negative delta when they untag them
+// @Tags Tags
+// @Accept json
+// @Produce json
+// @Param id path string true "Tag ID"
+// @Param usage body pbTag.RecordTagUsageRequest true "Delta, 1 when 0, the id is taken from the path"
+// @Param X-Timezone header string false "IANA timezone of the *_local fields, e.g. Europe/Paris"
+// @Success 200 {object} pbTag.Tag "Tag with its new usage count"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 404 {object} map[string]string "Not Found"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /tags/{id}/usage [post]
+func (c *TagController) RecordTagUsage(ctx *gin.Context) {
+	var request pbTag.RecordTagUsageRequest
+	if err := ctx.BindJSON(&request); err != nil {
+		invalidRequest(ctx, err)
+		return
+	}
+	request.Id = ctx.Param("id")
+	if err := c.validator.Validate(&request); err != nil {
+		invalidRequest(ctx, err)
+		return
+	}
+
+	response, err := c.tagService.RecordTagUsage(ctx.Request.Context(), &request)
+	if err != nil {
+		utils.GRPCErrorHandler(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// MoveTag godoc
+// @Summary Move tag
+// @Description Move a tag and its subtree under another parent, or to the roots with an empty parent_id. Rejects cycles and subtrees deeper than the maximum depth
+// @Tags Tags
+// @Accept json
+// @Produce json
+// @Param id path string true "Tag ID"
+// @Param move body pbTag.MoveTagRequest true "New parent, the id is taken from the path"
+// @Param X-Timezone header string false "IANA timezone of the *_local fields, e.g. Europe/Paris"
+// @Success 200 {object} pbTag.Tag "Moved tag"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 404 {object} map[string]string "Not Found"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /tags/{id}/parent [put]
+func (c *TagController) MoveTag(ctx *gin.Context) {
+	var request pbTag.MoveTagRequest
+	if err := ctx.BindJSON(&request); err != nil {
+		invalidRequest(ctx, err)
+		return
+	}
+	request.Id = ctx.Param("id")
+	if err := c.validator.Validate(&request); err != nil {
+		invalidRequest(ctx, err)
+		return
+	}
+
+	response, err := c.tagService.MoveTag(ctx.Request.Context(), &request)
+	if err != nil {
+		utils.GRPCErrorHandler(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// MergeTags godoc
+// @Summary Merge tags
+// @Description Soft delete the source tags, turn their names into aliases of the target and move their aliases, children and usage counts to it, in one transaction. A dry run reports the changes without applying them
+// @Tags Tags
+// @Accept json
+// @Produce json
+// @Param merge body pbTag.MergeTagsRequest true "Sources and target"
+// @Param X-Timezone header string false "IANA timezone of the *_local fields, e.g. Europe/Paris"
+// @Success 200 {object} pbTag.MergeTagsResponse "Changes"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 404 {object} map[string]string "Not Found"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /tags:merge [post]
+func (c *TagController) MergeTags(ctx *gin.Context) {
+	var request pbTag.MergeTagsRequest
+	if err := ctx.BindJSON(&request); err != nil {
+		invalidRequest(ctx, err)
+		return
+	}
+	if err := c.validator.Validate(&request); err != nil {
+		invalidRequest(ctx, err)
+		return
+	}
+
+	response, err := c.tagService.MergeTags(ctx.Request.Context(), &request)
+	if err != nil {
+		utils.GRPCErrorHandler(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
 // DeleteTag godoc
 // @Summary Delete tag
 // @Description Delete a tag with the provided information
@@ -149,6 +521,7 @@ func (c *TagController) UpdateTag(ctx *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path string true "Tag ID"
+// @Param namespace query string false "Namespace of the tag, the default one when empty"
 // @Success 200 {object} map[string]string
 // @Failure 400 {object} map[string]string "Bad Request"
 // @Failure 500 {object} map[string]string "Internal Server Error"
@@ -156,8 +529,9 @@ func (c *TagController) UpdateTag(ctx *gin.Context) {
 func (c *TagController) DeleteTag(ctx *gin.Context) {
 	id := ctx.Param("id")
 
-	err := c.tagService.DeleteTag(&pbTag.TagId{
-		Id: id,
+	err := c.tagService.DeleteTag(ctx.Request.Context(), &pbTag.TagId{
+		Id:        id,
+		Namespace: ctx.Query("namespace"),
 	})
 	if err != nil {
 		utils.GRPCErrorHandler(ctx, err)
@@ -166,3 +540,10 @@ func (c *TagController) DeleteTag(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, gin.H{"message": "Tag deleted successfully"})
 }
+
+// invalidRequest logs a redacted summary of a request error and responds with 400.
+func invalidRequest(ctx *gin.Context, err error) {
+	message := logger.Redact(err.Error())
+	logger.WithContext(ctx.Request.Context()).Warnf("Invalid request: %s", message)
+	ctx.JSON(http.StatusBadRequest, gin.H{"message": "Invalid request", "error": message})
+}
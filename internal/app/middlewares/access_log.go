@@ -0,0 +1,48 @@
+package middlewares
+
+import (
+	"time"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AccessLogMiddleware writes one structured access log entry per request.
+func AccessLogMiddleware(accessLogger *logger.AccessLogger) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+		ctx.Next()
+
+		// use the route template, falling back to the raw path for unmatched routes
+		route := ctx.FullPath()
+		if route == "" {
+			route = ctx.Request.URL.Path
+		}
+		if accessLogger.Skip(route) {
+			return
+		}
+
+		entry := logger.AccessEntry{
+			Transport: logger.TransportHTTP,
+			Method:    ctx.Request.Method,
+			Route:     route,
+			Status:    ctx.Writer.Status(),
+			Latency:   time.Since(start),
+			BytesIn:   ctx.Request.ContentLength,
+			BytesOut:  int64(ctx.Writer.Size()),
+			Peer:      ctx.ClientIP(),
+			UserAgent: ctx.Request.UserAgent(),
+		}
+		if entry.BytesIn < 0 {
+			entry.BytesIn = 0
+		}
+		if entry.BytesOut < 0 {
+			entry.BytesOut = 0
+		}
+		if len(ctx.Errors) > 0 {
+			entry.Error = ctx.Errors.String()
+		}
+		accessLogger.Log(ctx.Request.Context(), entry)
+	}
+}
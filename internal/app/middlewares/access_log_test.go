@@ -0,0 +1,165 @@
+package middlewares
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	logtest "github.com/sirupsen/logrus/hooks/test"
+)
+
+// captureLogs records the entries of pkg/logger until the end of the test.
+func captureLogs(t *testing.T) *logtest.Hook {
+	t.Helper()
+	l := logger.WithContext(context.Background()).Logger
+	previous := make(logrus.LevelHooks, len(l.Hooks))
+	for level, hooks := range l.Hooks {
+		previous[level] = append([]logrus.Hook{}, hooks...)
+	}
+	t.Cleanup(func() { l.ReplaceHooks(previous) })
+	return logtest.NewLocal(l)
+}
+
+// newAccessLogRouter returns a router logging with opts. /tags/:id answers
+// the status given in the code query parameter.
+func newAccessLogRouter(opts logger.AccessLogOptions) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RequestIDMiddleware(), AccessLogMiddleware(logger.NewAccessLogger(opts)))
+	router.POST("/tags/:id", func(ctx *gin.Context) {
+		code := http.StatusOK
+		switch ctx.Query("code") {
+		case "404":
+			code = http.StatusNotFound
+		case "500":
+			code = http.StatusInternalServerError
+			ctx.Error(errTest)
+		}
+		ctx.String(code, "tag")
+	})
+	router.GET("/health", func(ctx *gin.Context) { ctx.String(http.StatusOK, "ok") })
+	return router
+}
+
+var errTest = errors.New("database is down")
+
+func TestAccessLogFields(t *testing.T) {
+	hook := captureLogs(t)
+	router := newAccessLogRouter(logger.AccessLogOptions{SampleRate: 1})
+
+	req := httptest.NewRequest(http.MethodPost, "/tags/42", strings.NewReader(`{"name":"go"}`))
+	req.Header.Set("User-Agent", "tests/1.0")
+	req.RemoteAddr = "192.0.2.1:1234"
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	entries := hook.AllEntries()
+	if len(entries) != 1 {
+		t.Fatalf("logged %d entries, want 1", len(entries))
+	}
+	entry := entries[0]
+	if entry.Level != logrus.InfoLevel || entry.Message != "POST /tags/:id 200" {
+		t.Errorf("logged %s %q, want info POST /tags/:id 200", entry.Level, entry.Message)
+	}
+	want := map[string]interface{}{
+		"transport":  logger.TransportHTTP,
+		"method":     http.MethodPost,
+		"route":      "/tags/:id",
+		"status":     http.StatusOK,
+		"bytes_in":   int64(len(`{"name":"go"}`)),
+		"bytes_out":  int64(len("tag")),
+		"peer":       "192.0.2.1",
+		"user_agent": "tests/1.0",
+	}
+	for field, value := range want {
+		if entry.Data[field] != value {
+			t.Errorf("%s = %v (%T), want %v", field, entry.Data[field], entry.Data[field], value)
+		}
+	}
+	if _, ok := entry.Data["latency_ms"].(float64); !ok {
+		t.Errorf("latency_ms = %v, want milliseconds", entry.Data["latency_ms"])
+	}
+	if id, _ := entry.Data["request_id"].(string); id == "" {
+		t.Errorf("fields = %v, want the request ID", entry.Data)
+	}
+}
+
+func TestAccessLogLevels(t *testing.T) {
+	router := newAccessLogRouter(logger.AccessLogOptions{SampleRate: 1})
+	tests := []struct {
+		code  string
+		level logrus.Level
+		err   string
+	}{
+		{"200", logrus.InfoLevel, ""},
+		{"404", logrus.WarnLevel, ""},
+		{"500", logrus.ErrorLevel, "database is down"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			hook := captureLogs(t)
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/tags/42?code="+tt.code, nil))
+			entry := hook.LastEntry()
+			if entry == nil {
+				t.Fatal("nothing was logged")
+			}
+			if entry.Level != tt.level || !strings.HasSuffix(entry.Message, tt.code) {
+				t.Errorf("logged %s %q, want %s", entry.Level, entry.Message, tt.level)
+			}
+			if got, _ := entry.Data["error"].(string); !strings.Contains(got, tt.err) || (got == "") != (tt.err == "") {
+				t.Errorf("error = %q, want %q", got, tt.err)
+			}
+		})
+	}
+}
+
+func TestAccessLogSkipPaths(t *testing.T) {
+	hook := captureLogs(t)
+	router := newAccessLogRouter(logger.AccessLogOptions{SampleRate: 1, SkipPaths: []string{"/health", "/tags/:id"}})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+	// routes are skipped by template, even when they fail
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/tags/42?code=500", nil))
+	if n := len(hook.AllEntries()); n != 0 {
+		t.Fatalf("logged %d entries of skipped routes", n)
+	}
+
+	// unmatched requests are logged with their path
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/missing", nil))
+	if entry := hook.LastEntry(); entry == nil || entry.Data["route"] != "/missing" {
+		t.Errorf("logged %v, want the unmatched path", entry)
+	}
+}
+
+func TestAccessLogSampling(t *testing.T) {
+	count := func(rate float64, code string, n int) int {
+		hook := captureLogs(t)
+		router := newAccessLogRouter(logger.AccessLogOptions{SampleRate: rate})
+		for i := 0; i < n; i++ {
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/tags/42?code="+code, nil))
+		}
+		logged := len(hook.AllEntries())
+		hook.Reset()
+		return logged
+	}
+
+	if got := count(0, "200", 50); got != 0 {
+		t.Errorf("logged %d successes at the rate 0, want 0", got)
+	}
+	if got := count(1, "200", 50); got != 50 {
+		t.Errorf("logged %d successes at the rate 1, want 50", got)
+	}
+	// failures are always logged
+	if got := count(0, "404", 50); got != 50 {
+		t.Errorf("logged %d failures at the rate 0, want 50", got)
+	}
+	if got := count(0.5, "200", 1000); got < 350 || got > 650 {
+		t.Errorf("logged %d of 1000 successes at the rate 0.5", got)
+	}
+}
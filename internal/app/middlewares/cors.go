@@ -1,17 +1,26 @@
 package middlewares
 
 import (
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/config"
 	"github.com/ponyjackal/go-microservice-boilerplate/pkg/logger"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/utils"
 
 	"github.com/gin-gonic/gin"
 )
 
 func CORSMiddleware() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
-		ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+		// read on every request so origins follow config reloads
+		origins := config.Get().Server.CORS.AllowedOrigins
+		if origin := ctx.GetHeader("Origin"); utils.Contains(origins, "*") {
+			ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+		} else if origin != "" && utils.Contains(origins, origin) {
+			ctx.Writer.Header().Set("Access-Control-Allow-Origin", origin)
+			ctx.Writer.Header().Add("Vary", "Origin")
+		}
 		ctx.Writer.Header().Set("Access-Control-Max-Age", "86400")
 		ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, GET, OPTIONS, PUT, DELETE, UPDATE")
-		ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Origin, Content-Type, api_key, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization")
+		ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Origin, Content-Type, api_key, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-Timezone, X-Tenant-Id, X-User-Id, X-Session-Id")
 		ctx.Writer.Header().Set("Access-Control-Expose-Headers", "Content-Length")
 		ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
 		ctx.Writer.Header().Set("Cache-Control", "no-cache")
@@ -0,0 +1,31 @@
+package middlewares
+
+import (
+	"strings"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/constants"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/flags"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FlagsMiddleware stores the tenant, user and headers of the request in its
+// context so feature flags can target them.
+func FlagsMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		headers := make(map[string]string, len(ctx.Request.Header))
+		for name, values := range ctx.Request.Header {
+			if len(values) > 0 {
+				headers[strings.ToLower(name)] = values[0]
+			}
+		}
+
+		attrs := flags.Attributes{
+			Tenant:  ctx.GetHeader(constants.TenantIDHeader),
+			User:    ctx.GetHeader(constants.UserIDHeader),
+			Headers: headers,
+		}
+		ctx.Request = ctx.Request.WithContext(flags.ContextWithAttributes(ctx.Request.Context(), attrs))
+		ctx.Next()
+	}
+}
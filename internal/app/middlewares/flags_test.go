@@ -0,0 +1,59 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/constants"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/flags"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestFlagsMiddlewareTargetsRequests(t *testing.T) {
+	previous := flags.All()
+	err := flags.Set(map[string]flags.Flag{
+		"beta": {
+			Type: flags.TypeBoolean,
+			Rules: []flags.Rule{
+				{Attribute: flags.AttributeTenant, Values: []string{"acme"}, Serve: flags.On},
+				{Attribute: "header:X-Beta", Values: []string{"yes"}, Serve: flags.On},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { flags.Set(previous) })
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(FlagsMiddleware())
+	router.GET("/", func(ctx *gin.Context) {
+		ctx.String(http.StatusOK, flags.Variant(ctx.Request.Context(), "beta"))
+	})
+
+	for _, tt := range []struct {
+		name    string
+		headers map[string]string
+		want    string
+	}{
+		{"anonymous", nil, flags.Off},
+		{"targeted tenant", map[string]string{constants.TenantIDHeader: "acme"}, flags.On},
+		{"other tenant", map[string]string{constants.TenantIDHeader: "globex"}, flags.Off},
+		{"targeted header", map[string]string{"X-Beta": "yes"}, flags.On},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			for name, value := range tt.headers {
+				req.Header.Set(name, value)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+			if got := rec.Body.String(); got != tt.want {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package middlewares
+
+import (
+	"time"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/logger"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/metrics"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MetricsMiddleware records RED metrics per route template.
+func MetricsMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+		done := metrics.HTTPStarted(logger.TransportHTTP)
+		defer done()
+
+		ctx.Next()
+
+		route := ctx.FullPath()
+		if route == "" {
+			route = metrics.UnmatchedRoute
+		}
+		metrics.ObserveHTTP(logger.TransportHTTP, ctx.Request.Method, route, ctx.Writer.Status(), time.Since(start))
+	}
+}
@@ -0,0 +1,43 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/metrics"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestMetricsMiddlewareRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(MetricsMiddleware())
+	router.GET("/tags/:id", func(ctx *gin.Context) { ctx.String(http.StatusOK, "tag") })
+
+	for _, path := range []string{"/tags/1", "/tags/2", "/tags/3", "/missing", "/also/missing"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	rec := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	exposition := rec.Body.String()
+	for _, series := range []string{
+		// the route template, whatever the ID
+		`service_http_requests_total{method="GET",route="/tags/:id",status="200",transport="http"} 3`,
+		// every unmatched path under a single label
+		`service_http_requests_total{method="GET",route="unmatched",status="404",transport="http"} 2`,
+		`service_http_request_errors_total{method="GET",route="unmatched",status="404",transport="http"} 2`,
+	} {
+		if !strings.Contains(exposition, series+"\n") {
+			t.Errorf("missing %s", series)
+		}
+	}
+	for _, path := range []string{"/tags/1", "/missing"} {
+		if strings.Contains(exposition, `route="`+path+`"`) {
+			t.Errorf("the raw path %s is a label", path)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package middlewares
+
+import (
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/constants"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RequestIDMiddleware propagates the incoming request ID or generates a new
+// one, and stores it in the request context for logging.
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		requestID := ctx.GetHeader(constants.RequestIDHeader)
+		if requestID == "" {
+			requestID = uuid.NewString()
+		}
+
+		ctx.Writer.Header().Set(constants.RequestIDHeader, requestID)
+		ctx.Request = ctx.Request.WithContext(logger.ContextWithRequestID(ctx.Request.Context(), requestID))
+		ctx.Next()
+	}
+}
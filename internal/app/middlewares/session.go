@@ -0,0 +1,23 @@
+package middlewares
+
+import (
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/constants"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/session"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SessionMiddleware stores the session sent with the X-Session-Id header in
+// the request context, falling back to the user ID.
+func SessionMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id := ctx.GetHeader(constants.SessionIDHeader)
+		if id == "" {
+			id = ctx.GetHeader(constants.UserIDHeader)
+		}
+		if id != "" {
+			ctx.Request = ctx.Request.WithContext(session.ContextWithID(ctx.Request.Context(), id))
+		}
+		ctx.Next()
+	}
+}
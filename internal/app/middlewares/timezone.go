@@ -0,0 +1,32 @@
+package middlewares
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/constants"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/timezone"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TimezoneMiddleware stores the timezone requested with the X-Timezone header
+// in the request context. Unknown timezones are rejected with 400.
+func TimezoneMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		name := ctx.GetHeader(constants.TimezoneHeader)
+		if name == "" {
+			ctx.Next()
+			return
+		}
+
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("unknown timezone %q", name)})
+			return
+		}
+		ctx.Request = ctx.Request.WithContext(timezone.ContextWithLocation(ctx.Request.Context(), loc))
+		ctx.Next()
+	}
+}
@@ -0,0 +1,62 @@
+package routers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/adapters/database/dbtest"
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/domain/events"
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/domain/repositories"
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/domain/services"
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/health"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/config"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/logger"
+)
+
+func TestHealthRoutes(t *testing.T) {
+	db := dbtest.Open(t)
+	tagRepo := repositories.NewTagRepository(db)
+	namespaceRepo := repositories.NewNamespaceRepository(db)
+	tagService := services.NewTagService(db, tagRepo, repositories.NewTagAliasRepository(db), namespaceRepo, events.LogPublisher{})
+	namespaceService := services.NewNamespaceService(db, namespaceRepo, tagRepo)
+	checker := health.NewChecker(time.Second)
+	checker.AddCheck("primary", db.PingPrimary)
+	router := SetupRoute(tagService, namespaceService, logger.NewAccessLogger(config.AccessLogConfig()), checker)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	tests := []struct {
+		path string
+		code int
+		body string
+	}{
+		// the legacy check keeps its body
+		{"/api/v1/health", http.StatusOK, `{"live":"good"}`},
+		{"/livez", http.StatusOK, `{"status":"up"}`},
+		{"/readyz", http.StatusOK, ""},
+	}
+	for _, tt := range tests {
+		rec := get(tt.path)
+		if rec.Code != tt.code {
+			t.Errorf("GET %s = %d, want %d: %s", tt.path, rec.Code, tt.code, rec.Body)
+		}
+		if tt.body != "" && rec.Body.String() != tt.body {
+			t.Errorf("GET %s body = %s, want %s", tt.path, rec.Body, tt.body)
+		}
+	}
+
+	// a shutdown drains the traffic while the process is still live
+	checker.Shutdown()
+	if rec := get("/readyz"); rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("GET /readyz while shutting down = %d, want 503", rec.Code)
+	}
+	if rec := get("/livez"); rec.Code != http.StatusOK {
+		t.Errorf("GET /livez while shutting down = %d, want 200", rec.Code)
+	}
+}
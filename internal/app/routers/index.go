@@ -2,9 +2,11 @@ package routers
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/ponyjackal/go-microservice-boilerplate/internal/app/controllers"
 	"github.com/ponyjackal/go-microservice-boilerplate/internal/domain/services"
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/health"
 
 	_ "github.com/ponyjackal/go-microservice-boilerplate/docs"
 
@@ -14,26 +16,74 @@ import (
 func RegisterRoutes(
 	route *gin.Engine,
 	tagService *services.TagService,
+	namespaceService *services.NamespaceService,
+	checker *health.Checker,
 ) {
 	/* Controllers */
+	healthController := controllers.NewHealthController(checker)
 	tagController := controllers.NewTagController(tagService)
+	namespaceController := controllers.NewNamespaceController(namespaceService)
 
 	route.NoRoute(func(ctx *gin.Context) {
 		ctx.JSON(http.StatusNotFound, gin.H{"status": http.StatusNotFound, "message": "Route Not Found"})
 	})
 
+	// liveness and readiness probes
+	route.GET("livez", healthController.Livez)
+	route.GET("readyz", healthController.Readyz)
+
 	v1 := route.Group("api/v1")
 	{
 		// health check
 		v1.GET("health", func(ctx *gin.Context) { ctx.JSON(http.StatusOK, gin.H{"live": "good"}) })
+		// tag custom methods, e.g. tags:search
+		v1.GET("tags:method", customMethods(map[string]gin.HandlerFunc{
+			"search":       tagController.SearchTags,
+			"autocomplete": tagController.AutocompleteTags,
+		}))
+		v1.POST("tags:method", customMethods(map[string]gin.HandlerFunc{
+			"merge": tagController.MergeTags,
+		}))
 		// tags
 		tags := v1.Group("tags")
 		{
 			tags.GET("", tagController.GetTags)
 			tags.GET(":id", tagController.GetTagById)
+			tags.GET("by-slug/:slug", tagController.GetTagBySlug)
 			tags.POST("", tagController.SaveTag)
 			tags.PUT(":id", tagController.UpdateTag)
 			tags.DELETE(":id", tagController.DeleteTag)
+			tags.GET(":id/aliases", tagController.GetTagAliases)
+			tags.POST(":id/aliases", tagController.AddTagAlias)
+			tags.DELETE(":id/aliases/:alias_id", tagController.RemoveTagAlias)
+			tags.GET(":id/children", tagController.GetTagChildren)
+			tags.GET(":id/ancestors", tagController.GetTagAncestors)
+			tags.GET(":id/subtree", tagController.GetTagSubtree)
+			tags.PUT(":id/parent", tagController.MoveTag)
+			tags.POST(":id/usage", tagController.RecordTagUsage)
+		}
+		// tag namespaces
+		namespaces := v1.Group("namespaces")
+		{
+			namespaces.GET("", namespaceController.GetNamespaces)
+			namespaces.GET(":name", namespaceController.GetNamespace)
+			namespaces.POST("", namespaceController.CreateNamespace)
+			namespaces.PUT(":name", namespaceController.UpdateNamespace)
+			namespaces.DELETE(":name", namespaceController.DeleteNamespace)
+		}
+	}
+}
+
+// customMethods serves the custom methods of a collection, such as
+// /tags:search, by name. Gin matches every suffix following the colon with a
+// single route parameter.
+func customMethods(handlers map[string]gin.HandlerFunc) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		handler, ok := handlers[strings.TrimPrefix(ctx.Param("method"), ":")]
+		if !ok {
+			ctx.JSON(http.StatusNotFound, gin.H{"status": http.StatusNotFound, "message": "Route Not Found"})
+			return
 		}
+		handler(ctx)
 	}
 }
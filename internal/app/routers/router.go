@@ -1,36 +1,44 @@
 package routers
 
 import (
-	"os"
-	"strconv"
-
 	"github.com/ponyjackal/go-microservice-boilerplate/internal/app/middlewares"
 	"github.com/ponyjackal/go-microservice-boilerplate/internal/domain/services"
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/health"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/config"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/logger"
 
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 )
 
 func SetupRoute(
 	tagService *services.TagService,
+	namespaceService *services.NamespaceService,
+	accessLogger *logger.AccessLogger,
+	checker *health.Checker,
 ) *gin.Engine {
 
-	// Convert string to bool
-	environment, _ := strconv.ParseBool(os.Getenv("DEBUG"))
+	server := config.Get().Server
 
-	if environment {
+	if server.Debug {
 		gin.SetMode(gin.DebugMode)
 	} else {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
-	allowedHosts := os.Getenv("ALLOWED_HOSTS")
 	router := gin.New()
-	router.SetTrustedProxies([]string{allowedHosts})
-	router.Use(gin.Logger())
+	router.SetTrustedProxies(server.AllowedHosts)
+	router.Use(otelgin.Middleware(config.TracingConfig().ServiceName))
+	router.Use(middlewares.RequestIDMiddleware())
+	router.Use(middlewares.AccessLogMiddleware(accessLogger))
+	router.Use(middlewares.MetricsMiddleware())
 	router.Use(gin.Recovery())
 	router.Use(middlewares.CORSMiddleware())
+	router.Use(middlewares.TimezoneMiddleware())
+	router.Use(middlewares.FlagsMiddleware())
+	router.Use(middlewares.SessionMiddleware())
 
-	RegisterRoutes(router, tagService) //routes register
+	RegisterRoutes(router, tagService, namespaceService, checker) //routes register
 
 	return router
 }
@@ -0,0 +1,85 @@
+package routers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/adapters/database/dbtest"
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/domain/events"
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/domain/repositories"
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/domain/services"
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/health"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/config"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/logger"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/tracing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestRouterTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(tracing.NewProvider(recorder, resource.Empty(), 1))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+
+	db := dbtest.Open(t)
+	tagRepo := repositories.NewTagRepository(db)
+	namespaceRepo := repositories.NewNamespaceRepository(db)
+	tagService := services.NewTagService(db, tagRepo, repositories.NewTagAliasRepository(db), namespaceRepo, events.LogPublisher{})
+	namespaceService := services.NewNamespaceService(db, namespaceRepo, tagRepo)
+	router := SetupRoute(tagService, namespaceService, logger.NewAccessLogger(config.AccessLogConfig()), health.NewChecker(time.Second))
+
+	// the caller's trace is continued
+	caller := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0xca, 0x11},
+		SpanID:     trace.SpanID{0xca, 0x11},
+		TraceFlags: trace.FlagsSampled,
+	})
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/tags", nil)
+	req.Header.Set("traceparent", "00-"+caller.TraceID().String()+"-"+caller.SpanID().String()+"-01")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("got status %d: %s", rec.Code, rec.Body)
+	}
+
+	var route sdktrace.ReadOnlySpan
+	var queries []sdktrace.ReadOnlySpan
+	for _, span := range recorder.Ended() {
+		if span.SpanContext().TraceID() != caller.TraceID() {
+			continue
+		}
+		switch {
+		case span.Name() == "/api/v1/tags" && span.SpanKind() == trace.SpanKindServer:
+			route = span
+		case strings.HasPrefix(span.Name(), "gorm."):
+			queries = append(queries, span)
+		}
+	}
+	if route == nil {
+		t.Fatal("no span for the route in the trace of the caller")
+	}
+	if route.Parent().SpanID() != caller.SpanID() {
+		t.Error("the route span is not a child of the caller span")
+	}
+	if len(queries) == 0 {
+		t.Fatal("no query span in the trace of the request")
+	}
+	for _, query := range queries {
+		if query.Parent().SpanID() != route.SpanContext().SpanID() {
+			t.Errorf("the query span %q is not a child of the route span", query.Name())
+		}
+	}
+}
@@ -0,0 +1,177 @@
+// Package autocomplete completes tag names from memory.
+package autocomplete
+
+import (
+	"container/heap"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Entry is a tag known to the index.
+type Entry struct {
+	ID         string
+	Namespace  string
+	Name       string
+	UsageCount int64
+}
+
+// keyed is an entry with its namespace and lower case name, the sort key of
+// the index.
+type keyed struct {
+	key string
+	Entry
+}
+
+// Index holds the tags sorted by namespace then lower case name, so the tags
+// of a namespace starting with a prefix are found by binary search. It is
+// safe for concurrent use.
+type Index struct {
+	mu sync.RWMutex
+	// entries are pointers so that inserting moves less memory
+	entries []*keyed
+	// keys maps an ID to the key of its entry
+	keys map[string]string
+}
+
+// NewIndex returns an empty index.
+func NewIndex() *Index {
+	return &Index{keys: map[string]string{}}
+}
+
+// Replace swaps the content of the index with entries.
+func (x *Index) Replace(entries []Entry) {
+	sorted := make([]*keyed, len(entries))
+	keys := make(map[string]string, len(entries))
+	for i, entry := range entries {
+		sorted[i] = &keyed{key: indexKey(entry.Namespace, entry.Name), Entry: entry}
+		keys[entry.ID] = sorted[i].key
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return less(sorted[i], sorted[j])
+	})
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.entries, x.keys = sorted, keys
+}
+
+// Put adds an entry or replaces the one with the same ID.
+func (x *Index) Put(entry Entry) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.remove(entry.ID)
+
+	e := &keyed{key: indexKey(entry.Namespace, entry.Name), Entry: entry}
+	i := sort.Search(len(x.entries), func(i int) bool {
+		return !less(x.entries[i], e)
+	})
+	x.entries = append(x.entries, nil)
+	copy(x.entries[i+1:], x.entries[i:])
+	x.entries[i] = e
+	x.keys[entry.ID] = e.key
+}
+
+// Remove deletes the entry with id, if any.
+func (x *Index) Remove(id string) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.remove(id)
+}
+
+func (x *Index) remove(id string) {
+	key, ok := x.keys[id]
+	if !ok {
+		return
+	}
+	delete(x.keys, id)
+	for i := sort.Search(len(x.entries), func(i int) bool {
+		return x.entries[i].key >= key
+	}); i < len(x.entries) && x.entries[i].key == key; i++ {
+		if x.entries[i].ID == id {
+			copy(x.entries[i:], x.entries[i+1:])
+			x.entries[len(x.entries)-1] = nil
+			x.entries = x.entries[:len(x.entries)-1]
+			return
+		}
+	}
+}
+
+// Len returns the number of entries.
+func (x *Index) Len() int {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	return len(x.entries)
+}
+
+// Complete returns up to limit entries of namespace whose name starts with
+// prefix, ignoring case, the most used first then by name.
+func (x *Index) Complete(namespace string, prefix string, limit int) []Entry {
+	if limit <= 0 {
+		return nil
+	}
+	prefix = indexKey(namespace, prefix)
+
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	start := sort.Search(len(x.entries), func(i int) bool {
+		return x.entries[i].key >= prefix
+	})
+	// keep the best limit entries in a heap whose root is the worst of them
+	best := &ranking{}
+	for i := start; i < len(x.entries) && strings.HasPrefix(x.entries[i].key, prefix); i++ {
+		entry := x.entries[i]
+		if best.Len() < limit {
+			heap.Push(best, entry)
+		} else if ranksBefore(entry, (*best)[0]) {
+			(*best)[0] = entry
+			heap.Fix(best, 0)
+		}
+	}
+
+	entries := make([]Entry, best.Len())
+	for i := len(entries) - 1; i >= 0; i-- {
+		entries[i] = heap.Pop(best).(*keyed).Entry
+	}
+	return entries
+}
+
+// indexKey returns the sort key of a name, the namespace comes first and
+// cannot contain the separator.
+func indexKey(namespace string, name string) string {
+	return namespace + "\x00" + strings.ToLower(name)
+}
+
+// less orders the entries of the index by key then ID.
+func less(a, b *keyed) bool {
+	if a.key != b.key {
+		return a.key < b.key
+	}
+	return a.ID < b.ID
+}
+
+// ranksBefore orders completions: the most used first, then the shortest
+// and alphabetical names.
+func ranksBefore(a, b *keyed) bool {
+	if a.UsageCount != b.UsageCount {
+		return a.UsageCount > b.UsageCount
+	}
+	if len(a.key) != len(b.key) {
+		return len(a.key) < len(b.key)
+	}
+	return less(a, b)
+}
+
+// ranking is a heap of completions whose root ranks last.
+type ranking []*keyed
+
+func (r ranking) Len() int            { return len(r) }
+func (r ranking) Less(i, j int) bool  { return ranksBefore(r[j], r[i]) }
+func (r ranking) Swap(i, j int)       { r[i], r[j] = r[j], r[i] }
+func (r *ranking) Push(x interface{}) { *r = append(*r, x.(*keyed)) }
+func (r *ranking) Pop() interface{} {
+	old := *r
+	last := old[len(old)-1]
+	*r = old[:len(old)-1]
+	return last
+}
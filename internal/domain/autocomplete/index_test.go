@@ -0,0 +1,128 @@
+package autocomplete
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+)
+
+func names(entries []Entry) []string {
+	out := make([]string, len(entries))
+	for i, entry := range entries {
+		out[i] = entry.Name
+	}
+	return out
+}
+
+func TestComplete(t *testing.T) {
+	x := NewIndex()
+	x.Replace([]Entry{
+		{ID: "1", Namespace: "default", Name: "Go", UsageCount: 3},
+		{ID: "2", Namespace: "default", Name: "golang", UsageCount: 10},
+		{ID: "3", Namespace: "default", Name: "Gopher", UsageCount: 3},
+		{ID: "4", Namespace: "default", Name: "rust", UsageCount: 50},
+		{ID: "5", Namespace: "books", Name: "Go in Action", UsageCount: 100},
+	})
+
+	for _, tt := range []struct {
+		namespace, prefix string
+		limit             int
+		want              string
+	}{
+		// the most used first, then the shortest names
+		{"default", "go", 10, "golang,Go,Gopher"},
+		{"default", "GO", 2, "golang,Go"},
+		{"default", "gop", 10, "Gopher"},
+		{"default", "", 1, "rust"},
+		{"default", "java", 10, ""},
+		// namespaces never mix
+		{"books", "go", 10, "Go in Action"},
+		{"music", "go", 10, ""},
+		{"default", "go", 0, ""},
+	} {
+		got := strings.Join(names(x.Complete(tt.namespace, tt.prefix, tt.limit)), ",")
+		if got != tt.want {
+			t.Errorf("Complete(%q, %q, %d) = %q, want %q", tt.namespace, tt.prefix, tt.limit, got, tt.want)
+		}
+	}
+}
+
+func TestPutAndRemove(t *testing.T) {
+	x := NewIndex()
+	x.Put(Entry{ID: "1", Namespace: "default", Name: "kubernetes"})
+	x.Put(Entry{ID: "2", Namespace: "default", Name: "kafka", UsageCount: 1})
+	if got := names(x.Complete("default", "k", 10)); strings.Join(got, ",") != "kafka,kubernetes" {
+		t.Fatalf("got %v", got)
+	}
+
+	// a put replaces the entry with the same ID, renamed or more used
+	x.Put(Entry{ID: "1", Namespace: "default", Name: "k8s", UsageCount: 5})
+	if got := names(x.Complete("default", "k", 10)); strings.Join(got, ",") != "k8s,kafka" {
+		t.Fatalf("got %v after a rename", got)
+	}
+	if x.Len() != 2 {
+		t.Fatalf("got %d entries, want 2", x.Len())
+	}
+
+	x.Remove("2")
+	x.Remove("unknown")
+	if got := names(x.Complete("default", "k", 10)); strings.Join(got, ",") != "k8s" {
+		t.Fatalf("got %v after a removal", got)
+	}
+}
+
+// benchmarkEntries returns n random tags of the default namespace with a
+// skewed usage count.
+func benchmarkEntries(n int) []Entry {
+	rng := rand.New(rand.NewSource(1))
+	const letters = "abcdefghijklmnopqrstuvwxyz"
+	entries := make([]Entry, n)
+	for i := range entries {
+		name := make([]byte, 4+rng.Intn(12))
+		for j := range name {
+			name[j] = letters[rng.Intn(len(letters))]
+		}
+		entries[i] = Entry{
+			ID:         fmt.Sprint(i),
+			Namespace:  "default",
+			Name:       string(name),
+			UsageCount: int64(rng.ExpFloat64() * 100),
+		}
+	}
+	return entries
+}
+
+// BenchmarkComplete completes prefixes among 100k tags. A one letter prefix
+// ranks about 4k tags, the worst case of the tag picker.
+func BenchmarkComplete(b *testing.B) {
+	x := NewIndex()
+	x.Replace(benchmarkEntries(100000))
+
+	for _, prefix := range []string{"k", "ku", "kub", "zzzz"} {
+		b.Run("prefix="+prefix, func(b *testing.B) {
+			b.ReportAllocs()
+			start := time.Now()
+			for i := 0; i < b.N; i++ {
+				x.Complete("default", prefix, 10)
+			}
+			if perOp := time.Since(start) / time.Duration(b.N); perOp > time.Millisecond {
+				b.Errorf("completing %q took %s, want under a millisecond", prefix, perOp)
+			}
+		})
+	}
+}
+
+func BenchmarkPut(b *testing.B) {
+	x := NewIndex()
+	entries := benchmarkEntries(100000)
+	x.Replace(entries)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		entry := entries[i%len(entries)]
+		entry.UsageCount++
+		x.Put(entry)
+	}
+}
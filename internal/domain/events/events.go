@@ -0,0 +1,59 @@
+// Package events describes the changes of the tags, published once their
+// transaction is committed.
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/logger"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/timezone"
+)
+
+// Type names a change of a tag.
+type Type string
+
+const (
+	TagCreated Type = "tag.created"
+	TagUpdated Type = "tag.updated"
+	TagDeleted Type = "tag.deleted"
+	// TagMerged is published for every source of a merge, which is soft
+	// deleted and becomes an alias of the target.
+	TagMerged Type = "tag.merged"
+)
+
+// Event is a change of a tag.
+type Event struct {
+	Type      Type
+	TagID     string
+	Namespace string
+	// Name is the name of the tag after the change.
+	Name string
+	// TargetID is the tag a merged tag was merged into.
+	TargetID string
+	Time     time.Time
+}
+
+// Publisher delivers the events, in order. It must not block the request
+// publishing them.
+type Publisher interface {
+	Publish(ctx context.Context, events ...Event)
+}
+
+// LogPublisher writes the events to the log.
+type LogPublisher struct{}
+
+func (LogPublisher) Publish(ctx context.Context, events ...Event) {
+	for _, event := range events {
+		entry := logger.WithContext(ctx).
+			WithField("event", string(event.Type)).
+			WithField("tag_id", event.TagID).
+			WithField("namespace", event.Namespace).
+			WithField("name", event.Name).
+			WithField("occurred_at", timezone.Format(event.Time))
+		if event.TargetID != "" {
+			entry = entry.WithField("target_id", event.TargetID)
+		}
+		entry.Info("tag event")
+	}
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/tagname"
+)
+
+// DefaultNamespace holds the tags created without a namespace. It cannot be
+// deleted.
+const DefaultNamespace = "default"
+
+// Namespace is an independent vocabulary of tags: the names and slugs of its
+// tags and aliases are unique within it, and its settings limit them.
+type Namespace struct {
+	Name string `gorm:"column:name;primaryKey" json:"name"`
+	/* Settings */
+	// MaxTags bounds the number of live tags, unlimited when 0.
+	MaxTags int64 `gorm:"not null;default:0" json:"max_tags"`
+	// AllowedCharacters restricts the names of the tags and aliases.
+	AllowedCharacters tagname.Charset `gorm:"not null;default:any" json:"allowed_characters"`
+	/* Timestamp */
+	CreatedAt time.Time `json:"-"`
+	UpdatedAt time.Time `json:"-"`
+}
+
+// TableName is Database TableName of this model
+func (e *Namespace) TableName() string {
+	return "tag_namespaces"
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/tagname"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TagAlias is an alternative name of a tag, such as "golang" for "go". The
+// lookups by ID or slug and the searches matching it resolve to the tag.
+type TagAlias struct {
+	ID    uuid.UUID `gorm:"column:id;primaryKey" json:"id"`
+	TagID uuid.UUID `gorm:"not null;index" json:"tag_id"`
+	// Namespace is the namespace of the tag.
+	Namespace string `gorm:"not null;default:default;uniqueIndex:unique_tag_alias_slug,priority:1" json:"namespace"`
+	/* Fields */
+	Name string `gorm:"not null" json:"name"`
+	// Slug is unique among the slugs of the tags and the aliases of the
+	// namespace.
+	Slug string `gorm:"not null;uniqueIndex:unique_tag_alias_slug,priority:2" json:"slug"`
+	/* Timestamp */
+	CreatedAt time.Time `json:"-"`
+}
+
+// BeforeCreate generates the ID, the slug and the namespace when they are
+// not set, see Tag.BeforeCreate.
+func (e *TagAlias) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	if e.Namespace == "" {
+		e.Namespace = DefaultNamespace
+	}
+	if e.Slug == "" {
+		e.Slug = tagname.Slug(e.Name)
+	}
+	return nil
+}
+
+// TableName is Database TableName of this model
+func (e *TagAlias) TableName() string {
+	return "tag_aliases"
+}
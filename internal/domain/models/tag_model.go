@@ -3,20 +3,47 @@ package models
 import (
 	"time"
 
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/tagname"
+
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
 type Tag struct {
-	ID uuid.UUID `gorm:"type:uuid;column:id;primaryKey;default:gen_random_uuid()" json:"id"`
+	ID uuid.UUID `gorm:"column:id;primaryKey" json:"id"`
+	// Namespace is the vocabulary of the tag, the name and slug are unique
+	// within it.
+	Namespace string `gorm:"not null;default:default;uniqueIndex:unique_tag_name,priority:1;uniqueIndex:unique_tag_slug,priority:1" json:"namespace"`
 	/* Fields */
-	Name string `gorm:"not null;uniqueIndex:unique_tag_name" json:"name"`
+	Name string `gorm:"not null;uniqueIndex:unique_tag_name,priority:2" json:"name"`
+	// Slug identifies the tag in URLs, see tagname.Slug. It is unique so
+	// names differing only by case or punctuation conflict.
+	Slug string `gorm:"not null;uniqueIndex:unique_tag_slug,priority:2" json:"slug"`
+	// ParentID places the tag in the hierarchy, nil for the roots
+	ParentID *uuid.UUID `gorm:"index" json:"parent_id"`
+	// UsageCount ranks the autocompletions, the most used tags first
+	UsageCount int64 `gorm:"not null;default:0" json:"usage_count"`
 	/* Timestamp */
 	CreatedAt time.Time      `json:"-"`
 	UpdatedAt time.Time      `json:"-"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
 }
 
+// BeforeCreate generates the ID in Go, so every database driver stores the
+// same UUIDs, the slug and the namespace when they are not set.
+func (e *Tag) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	if e.Namespace == "" {
+		e.Namespace = DefaultNamespace
+	}
+	if e.Slug == "" {
+		e.Slug = tagname.Slug(e.Name)
+	}
+	return nil
+}
+
 // TableName is Database TableName of this model
 func (e *Tag) TableName() string {
 	return "tags"
@@ -0,0 +1,74 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/adapters/database"
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/domain/models"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/logger"
+
+	"gorm.io/gorm/clause"
+)
+
+type NamespaceRepository struct {
+	db *database.Database
+}
+
+func NewNamespaceRepository(db *database.Database) *NamespaceRepository {
+	return &NamespaceRepository{db: db}
+}
+
+func (r *NamespaceRepository) Save(ctx context.Context, namespace *models.Namespace) error {
+	err := r.db.WithContext(ctx).Create(namespace).Error
+	if err != nil {
+		logger.WithContext(ctx).Errorf("failed to save data: %v", err)
+	}
+	return err
+}
+
+// GetNamespaces returns every namespace sorted by name.
+func (r *NamespaceRepository) GetNamespaces(ctx context.Context) ([]models.Namespace, error) {
+	var namespaces []models.Namespace
+	err := r.db.WithContext(ctx).Order("name").Find(&namespaces).Error
+	if err != nil {
+		return nil, err
+	}
+	return namespaces, nil
+}
+
+func (r *NamespaceRepository) GetNamespace(ctx context.Context, name string) (*models.Namespace, error) {
+	var namespace models.Namespace
+	err := r.db.WithContext(ctx).First(&namespace, "name = ?", name).Error
+	if err != nil {
+		return nil, err
+	}
+	return &namespace, nil
+}
+
+// LockNamespace reads a namespace and locks it until the end of the
+// transaction, see TagRepository.LockTagById.
+func (r *NamespaceRepository) LockNamespace(ctx context.Context, name string) (*models.Namespace, error) {
+	var namespace models.Namespace
+	err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).First(&namespace, "name = ?", name).Error
+	if err != nil {
+		return nil, err
+	}
+	return &namespace, nil
+}
+
+func (r *NamespaceRepository) Update(ctx context.Context, namespace *models.Namespace) error {
+	return r.db.WithContext(ctx).Save(namespace).Error
+}
+
+// Delete removes a namespace along with its soft deleted tags and their
+// aliases. It must have no live tags.
+func (r *NamespaceRepository) Delete(ctx context.Context, namespace *models.Namespace) error {
+	db := r.db.WithContext(ctx)
+	if err := db.Delete(&models.TagAlias{}, "namespace = ?", namespace.Name).Error; err != nil {
+		return err
+	}
+	if err := db.Unscoped().Delete(&models.Tag{}, "namespace = ?", namespace.Name).Error; err != nil {
+		return err
+	}
+	return db.Delete(namespace).Error
+}
@@ -0,0 +1,76 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/adapters/database"
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/domain/models"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/logger"
+)
+
+type TagAliasRepository struct {
+	db *database.Database
+}
+
+func NewTagAliasRepository(db *database.Database) *TagAliasRepository {
+	return &TagAliasRepository{db: db}
+}
+
+func (r *TagAliasRepository) Save(ctx context.Context, alias *models.TagAlias) error {
+	err := r.db.WithContext(ctx).Create(alias).Error
+	if err != nil {
+		logger.WithContext(ctx).Errorf("failed to save data: %v", err)
+	}
+	return err
+}
+
+func (r *TagAliasRepository) GetAliasById(ctx context.Context, namespace string, id string) (*models.TagAlias, error) {
+	var alias models.TagAlias
+	err := r.db.WithContext(ctx).First(&alias, "id = ? AND namespace = ?", id, namespace).Error
+	if err != nil {
+		return nil, err
+	}
+	return &alias, nil
+}
+
+func (r *TagAliasRepository) GetAliasBySlug(ctx context.Context, namespace string, slug string) (*models.TagAlias, error) {
+	var alias models.TagAlias
+	err := r.db.WithContext(ctx).First(&alias, "namespace = ? AND slug = ?", namespace, slug).Error
+	if err != nil {
+		return nil, err
+	}
+	return &alias, nil
+}
+
+// GetAliases returns the aliases of a tag sorted by name.
+func (r *TagAliasRepository) GetAliases(ctx context.Context, tagID string) ([]models.TagAlias, error) {
+	var aliases []models.TagAlias
+	err := r.db.WithContext(ctx).Order("name").Find(&aliases, "tag_id = ?", tagID).Error
+	if err != nil {
+		return nil, err
+	}
+	return aliases, nil
+}
+
+// GetAliasesOfTags returns the aliases of several tags.
+func (r *TagAliasRepository) GetAliasesOfTags(ctx context.Context, tagIDs []string) ([]models.TagAlias, error) {
+	var aliases []models.TagAlias
+	err := r.db.WithContext(ctx).Order("name").Find(&aliases, "tag_id IN ?", tagIDs).Error
+	if err != nil {
+		return nil, err
+	}
+	return aliases, nil
+}
+
+// MoveAliases hands the aliases of the tags with fromIDs over to the tag
+// with toID.
+func (r *TagAliasRepository) MoveAliases(ctx context.Context, fromIDs []string, toID string) error {
+	return r.db.WithContext(ctx).Model(&models.TagAlias{}).Where("tag_id IN ?", fromIDs).Update("tag_id", toID).Error
+}
+
+// Delete removes an alias of a tag of a namespace and reports whether it
+// existed.
+func (r *TagAliasRepository) Delete(ctx context.Context, namespace string, tagID string, id string) (bool, error) {
+	result := r.db.WithContext(ctx).Delete(&models.TagAlias{}, "id = ? AND tag_id = ? AND namespace = ?", id, tagID, namespace)
+	return result.RowsAffected > 0, result.Error
+}
@@ -1,63 +1,440 @@
 package repositories
 
 import (
+	"context"
+	"math"
+	"sort"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/ponyjackal/go-microservice-boilerplate/internal/adapters/database"
 	"github.com/ponyjackal/go-microservice-boilerplate/internal/domain/models"
 	"github.com/ponyjackal/go-microservice-boilerplate/pkg/logger"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/trigram"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Match tiers of SearchTags, from the best to the worst.
+const (
+	MatchExact = iota
+	MatchPrefix
+	MatchFuzzy
 )
 
-type TagRepository struct{}
+// TagMatch is a tag found by SearchTags, with its tier and the similarity of
+// the query to its name, or to one of its aliases.
+type TagMatch struct {
+	models.Tag
+	Tier  int
+	Score float64
+	// Alias is the name of the alias matching the query best, empty when it
+	// is the name of the tag.
+	Alias string
+}
+
+// searchQuery ranks the tags of @namespace whose name or an alias matches
+// @query by tier then similarity, each tag keeping its best matching name.
+// The filters apply to LOWER(name) of each table, so the trigram indexes
+// serve the LIKE and the %> operator, with the threshold of the transaction.
+const searchQuery = `SELECT tags.*, matches.tier, matches.score, matches.alias
+FROM (
+	SELECT DISTINCT ON (tag_id) tag_id, alias,
+		CASE WHEN lower_name = @query THEN 0 WHEN lower_name LIKE @prefix ESCAPE '!' THEN 1 ELSE 2 END AS tier,
+		word_similarity(@query, lower_name) AS score
+	FROM (
+		SELECT id AS tag_id, '' AS alias, LOWER(name) AS lower_name FROM tags
+		WHERE namespace = @namespace AND (LOWER(name) LIKE @prefix ESCAPE '!' OR LOWER(name) %> @query)
+		UNION ALL
+		SELECT tag_id, name, LOWER(name) FROM tag_aliases
+		WHERE namespace = @namespace AND (LOWER(name) LIKE @prefix ESCAPE '!' OR LOWER(name) %> @query)
+	) names
+	ORDER BY tag_id, tier, score DESC, alias
+) matches
+JOIN tags ON tags.id = matches.tag_id
+WHERE tags.deleted_at IS NULL
+ORDER BY matches.tier, matches.score DESC, tags.name
+LIMIT @limit`
+
+// TagNode is a tag of a subtree, Depth levels below its root.
+type TagNode struct {
+	models.Tag
+	Depth int
+}
+
+// ancestorsQuery walks up from the tag @id, @limit levels at most so a cycle
+// cannot loop forever.
+const ancestorsQuery = `WITH RECURSIVE ancestors (id, parent_id, depth) AS (
+	SELECT id, parent_id, 0 FROM tags WHERE id = @id
+	UNION ALL
+	SELECT tags.id, tags.parent_id, ancestors.depth + 1
+	FROM tags JOIN ancestors ON tags.id = ancestors.parent_id
+	WHERE ancestors.depth < @limit
+)
+SELECT tags.* FROM tags JOIN ancestors ON tags.id = ancestors.id
+WHERE ancestors.depth > 0
+ORDER BY ancestors.depth DESC`
+
+// subtreeQuery walks down from the tag @id like ancestorsQuery, skipping the
+// soft deleted tags.
+const subtreeQuery = `WITH RECURSIVE subtree (id, depth) AS (
+	SELECT id, 0 FROM tags WHERE id = @id
+	UNION ALL
+	SELECT tags.id, subtree.depth + 1
+	FROM tags JOIN subtree ON tags.parent_id = subtree.id
+	WHERE tags.deleted_at IS NULL AND subtree.depth < @limit
+)
+SELECT tags.*, subtree.depth FROM tags JOIN subtree ON tags.id = subtree.id
+WHERE subtree.depth > 0
+ORDER BY subtree.depth, tags.name`
+
+type TagRepository struct {
+	db *database.Database
+}
+
+func NewTagRepository(db *database.Database) *TagRepository {
+	return &TagRepository{db: db}
+}
 
-func (r *TagRepository) Save(tag *models.Tag) error {
-	err := database.DB.Create(tag).Error
+func (r *TagRepository) Save(ctx context.Context, tag *models.Tag) error {
+	err := r.db.WithContext(ctx).Create(tag).Error
 	if err != nil {
-		logger.Errorf("failed to save data: %v", err)
+		logger.WithContext(ctx).Errorf("failed to save data: %v", err)
 	}
 	return err
 }
 
-func (r *TagRepository) GetTags(name string) ([]models.Tag, error) {
+func (r *TagRepository) GetTags(ctx context.Context, namespace string, name string, parentID string) ([]models.Tag, error) {
 	var tags []models.Tag
-	var err error
 
-	db := database.DB
+	db := r.db.WithContext(database.ExplainIfSlow(ctx)).Where("namespace = ?", namespace)
 	if name != "" {
-		nameQuery := "%" + strings.ToLower(name) + "%"
-		err = db.Find(&tags, "LOWER(name) LIKE ?", nameQuery).Error
+		db = db.Where("LOWER(name) LIKE ? ESCAPE '!'", database.ContainsPattern(name))
+	}
+	if parentID != "" {
+		db = db.Where("parent_id = ?", parentID)
+	}
+
+	err := db.Find(&tags).Error
+	if err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// GetChildren returns the tags right below the tags with ids, sorted by name.
+func (r *TagRepository) GetChildren(ctx context.Context, ids ...string) ([]models.Tag, error) {
+	var tags []models.Tag
+	err := r.db.WithContext(ctx).Order("name").Find(&tags, "parent_id IN ?", ids).Error
+	if err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// CountTags returns the number of live tags of a namespace.
+func (r *TagRepository) CountTags(ctx context.Context, namespace string) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.Tag{}).Where("namespace = ?", namespace).Count(&count).Error
+	return count, err
+}
+
+// CountChildren returns the number of tags right below a tag.
+func (r *TagRepository) CountChildren(ctx context.Context, id string) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.Tag{}).Where("parent_id = ?", id).Count(&count).Error
+	return count, err
+}
+
+// GetAncestors returns the ancestors of a tag from its root down to its
+// parent, up to limit of them.
+func (r *TagRepository) GetAncestors(ctx context.Context, id string, limit int) ([]models.Tag, error) {
+	var tags []models.Tag
+	err := r.db.WithContext(ctx).Raw(ancestorsQuery, map[string]interface{}{"id": id, "limit": limit}).Scan(&tags).Error
+	if err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// GetSubtree returns the descendants of a tag down to limit levels below it,
+// level by level.
+func (r *TagRepository) GetSubtree(ctx context.Context, id string, limit int) ([]TagNode, error) {
+	var nodes []TagNode
+	err := r.db.WithContext(ctx).Raw(subtreeQuery, map[string]interface{}{"id": id, "limit": limit}).Scan(&nodes).Error
+	if err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
+
+// MoveChildren moves the tags right below the tags with fromIDs under the
+// tag with toID.
+func (r *TagRepository) MoveChildren(ctx context.Context, fromIDs []string, toID string) error {
+	return r.db.WithContext(ctx).Model(&models.Tag{}).Where("parent_id IN ?", fromIDs).Update("parent_id", toID).Error
+}
+
+// AddUsage adds delta to the usage count of a tag, stopping at 0. It returns
+// gorm.ErrRecordNotFound when the namespace has no such tag.
+func (r *TagRepository) AddUsage(ctx context.Context, namespace string, id string, delta int64) error {
+	result := r.db.WithContext(ctx).Model(&models.Tag{}).
+		Where("namespace = ? AND id = ?", namespace, id).
+		UpdateColumn("usage_count", gorm.Expr("CASE WHEN usage_count + ? < 0 THEN 0 ELSE usage_count + ? END", delta, delta))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// SearchTags returns up to limit tags of a namespace whose name or an alias
+// equals query, starts with it, or has a trigram similarity to it of at
+// least threshold, best first.
+// Postgres ranks them with pg_trgm, the other drivers in Go.
+func (r *TagRepository) SearchTags(ctx context.Context, namespace string, query string, threshold float64, limit int) ([]TagMatch, error) {
+	if r.db.Driver() != "postgres" {
+		return r.scanTags(ctx, namespace, query, threshold, limit)
+	}
+
+	var matches []TagMatch
+	// a read-only transaction, on a replica when one serves the reads
+	err := r.db.WithReadTx(ctx, func(ctx context.Context) error {
+		db := r.db.WithContext(ctx)
+		// the threshold of %>, reset at the end of the transaction
+		var setting string
+		err := db.Raw("SELECT set_config('pg_trgm.word_similarity_threshold', ?, true)",
+			strconv.FormatFloat(threshold, 'f', -1, 64)).Scan(&setting).Error
+		if err != nil {
+			return err
+		}
+		return db.Raw(searchQuery, map[string]interface{}{
+			"namespace": namespace,
+			"query":     strings.ToLower(query),
+			"prefix":    database.PrefixPattern(query),
+			"limit":     limit,
+		}).Scan(&matches).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return matches, nil
+}
+
+// scanTags ranks the tags and aliases of a namespace like searchQuery, for
+// the drivers without pg_trgm. Only the names kept by candidateNames are
+// loaded, but the filter is a plain LIKE scan of the namespace, which still
+// suits small vocabularies only.
+func (r *TagRepository) scanTags(ctx context.Context, namespace string, query string, threshold float64, limit int) ([]TagMatch, error) {
+	filter, args := candidateNames(query, threshold)
+
+	var aliases []models.TagAlias
+	err := r.db.WithContext(ctx).Where("namespace = ?", namespace).Where(filter, args...).Find(&aliases).Error
+	if err != nil {
+		return nil, err
+	}
+	aliasedIDs := make([]uuid.UUID, 0, len(aliases))
+	for _, alias := range aliases {
+		aliasedIDs = append(aliasedIDs, alias.TagID)
+	}
+
+	// the tags of the matching aliases are ranked even when their own name
+	// is filtered out
+	db := r.db.WithContext(ctx).Where("namespace = ?", namespace)
+	if len(aliasedIDs) > 0 {
+		db = db.Where(r.db.WithContext(ctx).Where(filter, args...).Or("id IN ?", aliasedIDs))
 	} else {
-		err = db.Find(&tags).Error
+		db = db.Where(filter, args...)
+	}
+	var tags []models.Tag
+	if err := db.Find(&tags).Error; err != nil {
+		return nil, err
+	}
+	aliasNames := make(map[uuid.UUID][]string, len(aliases))
+	for _, alias := range aliases {
+		aliasNames[alias.TagID] = append(aliasNames[alias.TagID], alias.Name)
+	}
+
+	query = strings.ToLower(query)
+	var matches []TagMatch
+	for _, tag := range tags {
+		var best *TagMatch
+		// the name of the tag first, it is kept over an alias matching as well
+		for i, name := range append([]string{tag.Name}, aliasNames[tag.ID]...) {
+			match := rankName(query, name, threshold)
+			if match == nil || (best != nil && !betterMatch(match, best)) {
+				continue
+			}
+			match.Tag = tag
+			if i > 0 {
+				match.Alias = name
+			}
+			best = match
+		}
+		if best != nil {
+			matches = append(matches, *best)
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := &matches[i], &matches[j]
+		if a.Tier != b.Tier || a.Score != b.Score {
+			return betterMatch(a, b)
+		}
+		return a.Name < b.Name
+	})
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches, nil
+}
+
+// candidateNames returns a condition on name kept by every name which
+// rankName may match, so scanTags ranks only these. A name reaching the
+// threshold shares at least threshold of the trigrams of query, and contains
+// the letters of each trigram it shares. Letters outside ASCII are not
+// checked since LOWER folds only ASCII on SQLite.
+func candidateNames(query string, threshold float64) (string, []interface{}) {
+	if threshold <= 0 {
+		return "1 = 1", nil
 	}
+	args := []interface{}{database.PrefixPattern(query)}
+
+	trigrams := trigram.Trigrams(query)
+	required := int(math.Ceil(threshold*float64(len(trigrams)) - 1e-9))
+	var shared []string
+	for _, t := range trigrams {
+		letters := strings.TrimSpace(t)
+		if !isASCII(letters) {
+			required--
+			continue
+		}
+		shared = append(shared, "CASE WHEN LOWER(name) LIKE ? ESCAPE '!' THEN 1 ELSE 0 END")
+		args = append(args, database.ContainsPattern(letters))
+	}
+	switch {
+	case len(trigrams) == 0:
+		// nothing but the names starting with query can match
+		return "LOWER(name) LIKE ? ESCAPE '!'", args
+	case required <= 0:
+		return "1 = 1", nil
+	}
+	args = append(args, required)
+	return "LOWER(name) LIKE ? ESCAPE '!' OR " + strings.Join(shared, " + ") + " >= ?", args
+}
 
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// rankName returns the tier and score of name for the lower case query, nil
+// when it does not match.
+func rankName(query, name string, threshold float64) *TagMatch {
+	name = strings.ToLower(name)
+	match := &TagMatch{Tier: MatchFuzzy, Score: trigram.WordSimilarity(query, name)}
+	switch {
+	case name == query:
+		match.Tier = MatchExact
+	case strings.HasPrefix(name, query):
+		match.Tier = MatchPrefix
+	case match.Score < threshold:
+		return nil
+	}
+	return match
+}
+
+// betterMatch orders matches by tier then score.
+func betterMatch(a, b *TagMatch) bool {
+	if a.Tier != b.Tier {
+		return a.Tier < b.Tier
+	}
+	return a.Score > b.Score
+}
+
+// ListTagNames returns the ID, namespace, name and usage count of every tag.
+func (r *TagRepository) ListTagNames(ctx context.Context) ([]models.Tag, error) {
+	var tags []models.Tag
+	err := r.db.WithContext(ctx).Select("id", "namespace", "name", "usage_count").Find(&tags).Error
 	if err != nil {
 		return nil, err
 	}
 	return tags, nil
 }
 
-func (r *TagRepository) GetTagById(id string) (*models.Tag, error) {
+func (r *TagRepository) GetTagById(ctx context.Context, namespace string, id string) (*models.Tag, error) {
 	var tag models.Tag
-	err := database.DB.First(&tag, "id = ?", id).Error
+	err := r.db.WithContext(ctx).First(&tag, "id = ? AND namespace = ?", id, namespace).Error
 	if err != nil {
 		return nil, err
 	}
 	return &tag, nil
 }
 
-func (r *TagRepository) Update(tag *models.Tag) error {
-	err := database.DB.Save(tag).Error
+func (r *TagRepository) GetTagBySlug(ctx context.Context, namespace string, slug string) (*models.Tag, error) {
+	var tag models.Tag
+	err := r.db.WithContext(ctx).First(&tag, "namespace = ? AND slug = ?", namespace, slug).Error
+	if err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// FindSlugOwner returns the tag of a namespace holding slug, soft deleted
+// ones included since they keep it, or nil.
+func (r *TagRepository) FindSlugOwner(ctx context.Context, namespace string, slug string) (*models.Tag, error) {
+	var tags []models.Tag
+	err := r.db.WithContext(ctx).Unscoped().Where("namespace = ? AND slug = ?", namespace, slug).Limit(1).Find(&tags).Error
+	if err != nil || len(tags) == 0 {
+		return nil, err
+	}
+	return &tags[0], nil
+}
+
+// LockTagById reads a tag and locks it until the end of the transaction, see
+// database.Database.WithTx.
+func (r *TagRepository) LockTagById(ctx context.Context, namespace string, id string) (*models.Tag, error) {
+	var tag models.Tag
+	err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).First(&tag, "id = ? AND namespace = ?", id, namespace).Error
+	if err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// LockTagsByIds reads the tags with ids and locks them until the end of the
+// transaction, see LockTagById. Missing ids and the tags of other namespaces
+// are skipped.
+func (r *TagRepository) LockTagsByIds(ctx context.Context, namespace string, ids []string) ([]models.Tag, error) {
+	var tags []models.Tag
+	err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Order("id").Find(&tags, "id IN ? AND namespace = ?", ids, namespace).Error
+	if err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+func (r *TagRepository) Update(ctx context.Context, tag *models.Tag) error {
+	err := r.db.WithContext(ctx).Save(tag).Error
 	return err
 }
 
-func (r *TagRepository) Delete(tag *models.Tag, isHardDelete bool) error {
+func (r *TagRepository) Delete(ctx context.Context, tag *models.Tag, isHardDelete bool) error {
 	var err error
 	if isHardDelete {
 		// Delete tag permanently from db
-		err = database.DB.Unscoped().Delete(tag).Error
+		err = r.db.WithContext(ctx).Unscoped().Delete(tag).Error
 	} else {
 		// Soft delete tag
-		err = database.DB.Delete(tag).Error
+		err = r.db.WithContext(ctx).Delete(tag).Error
 	}
 	return err
 }
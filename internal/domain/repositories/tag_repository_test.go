@@ -0,0 +1,117 @@
+package repositories
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/adapters/database/dbtest"
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/domain/models"
+)
+
+// TestSearchTagsFilter checks that the SQL filter of scanTags keeps every
+// match, by ranking the whole namespace in Go.
+func TestSearchTagsFilter(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.Open(t)
+	repo := NewTagRepository(db)
+
+	names := []string{
+		"go", "golang", "google", "kubernetes", "kube-proxy", "cubernetes",
+		"postgres", "postgresql", "mysql", "sqlite", "Érable", "érable sucré",
+		"CAFÉ", "café au lait", "rust", "trust", "c++", "42",
+	}
+	var tags []models.Tag
+	for _, name := range names {
+		tag := models.Tag{Namespace: "test", Name: name}
+		if err := repo.Save(ctx, &tag); err != nil {
+			t.Fatalf("Save(%q) error: %v", name, err)
+		}
+		tags = append(tags, tag)
+		// the same names in another namespace, never returned
+		if err := repo.Save(ctx, &models.Tag{Namespace: "other", Name: name}); err != nil {
+			t.Fatalf("Save(%q) error: %v", name, err)
+		}
+	}
+	aliases := map[string]string{"k8s": "kubernetes", "golang-lang": "go", "pg": "postgres"}
+	aliasNames := map[string][]string{}
+	for alias, name := range aliases {
+		for _, tag := range tags {
+			if tag.Name != name {
+				continue
+			}
+			err := db.WithContext(ctx).Create(&models.TagAlias{TagID: tag.ID, Namespace: "test", Name: alias}).Error
+			if err != nil {
+				t.Fatalf("Create alias %q error: %v", alias, err)
+			}
+			aliasNames[name] = append(aliasNames[name], alias)
+		}
+	}
+
+	for _, query := range []string{"go", "g", "kubernets", "k8", "postgre", "sql", "erable", "érable", "CAFE", "café", "rsut", "c++", "4", "!!", "pg"} {
+		for _, threshold := range []float64{0, 0.3, 0.6, 1} {
+			matches, err := repo.SearchTags(ctx, "test", query, threshold, len(names))
+			if err != nil {
+				t.Fatalf("SearchTags(%q, %v) error: %v", query, threshold, err)
+			}
+			var got []string
+			for _, match := range matches {
+				if match.Namespace != "test" {
+					t.Errorf("SearchTags(%q, %v) returned a tag of namespace %q", query, threshold, match.Namespace)
+				}
+				got = append(got, match.Name)
+			}
+
+			var want []string
+			for _, tag := range tags {
+				for _, name := range append([]string{tag.Name}, aliasNames[tag.Name]...) {
+					if rankName(strings.ToLower(query), name, threshold) != nil {
+						want = append(want, tag.Name)
+						break
+					}
+				}
+			}
+			sort.Strings(got)
+			sort.Strings(want)
+			if strings.Join(got, ",") != strings.Join(want, ",") {
+				t.Errorf("SearchTags(%q, %v) = %q, want %q", query, threshold, got, want)
+			}
+		}
+	}
+}
+
+func TestCandidateNames(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.Open(t)
+	for _, name := range []string{"kubernetes", "cubernetes", "golang", "postgres"} {
+		if err := db.WithContext(ctx).Create(&models.Tag{Namespace: "test", Name: name}).Error; err != nil {
+			t.Fatalf("Create(%q) error: %v", name, err)
+		}
+	}
+
+	tests := []struct {
+		query     string
+		threshold float64
+		want      int64
+	}{
+		{"kubernets", 0.5, 2},
+		{"kubernets", 0.8, 1},
+		// the g of postgres may be the start of a word
+		{"go", 0.3, 2},
+		{"!!", 0.3, 0},
+		{"!!", 0, 4},
+		// LOWER does not fold É on SQLite, the letters are not checked
+		{"É", 0.3, 4},
+	}
+	for _, tt := range tests {
+		filter, args := candidateNames(tt.query, tt.threshold)
+		var got int64
+		if err := db.WithContext(ctx).Model(&models.Tag{}).Where(filter, args...).Count(&got).Error; err != nil {
+			t.Fatalf("candidateNames(%q, %v) error: %v", tt.query, tt.threshold, err)
+		}
+		if got != tt.want {
+			t.Errorf("candidateNames(%q, %v) kept %d names, want %d", tt.query, tt.threshold, got, tt.want)
+		}
+	}
+}
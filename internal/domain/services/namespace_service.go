@@ -0,0 +1,191 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/domain/models"
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/domain/repositories"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/logger"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/tagname"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/timezone"
+
+	// protobuf
+	pbTag "github.com/ponyjackal/go-microservice-boilerplate/proto/tag"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// NamespaceService manages the tag namespaces and their settings.
+type NamespaceService struct {
+	uow           UnitOfWork
+	namespaceRepo *repositories.NamespaceRepository
+	tagRepo       *repositories.TagRepository
+}
+
+func NewNamespaceService(uow UnitOfWork, namespaceRepo *repositories.NamespaceRepository, tagRepo *repositories.TagRepository) *NamespaceService {
+	return &NamespaceService{
+		uow:           uow,
+		namespaceRepo: namespaceRepo,
+		tagRepo:       tagRepo,
+	}
+}
+
+// GetNamespaces lists the namespaces sorted by name.
+func (c *NamespaceService) GetNamespaces(ctx context.Context) (*pbTag.GetNamespacesResponse, error) {
+	namespaces, err := c.namespaceRepo.GetNamespaces(ctx)
+	if err != nil {
+		logger.WithContext(ctx).Errorf("Failed to get namespaces: %s", err)
+		return nil, status.Errorf(codes.Internal, "Failed to get namespaces")
+	}
+
+	res := &pbTag.GetNamespacesResponse{Namespaces: make([]*pbTag.Namespace, len(namespaces))}
+	for i := range namespaces {
+		if res.Namespaces[i], err = c.namespaceData(ctx, &namespaces[i]); err != nil {
+			logger.WithContext(ctx).Errorf("Failed to count tags: %s", err)
+			return nil, status.Errorf(codes.Internal, "Failed to get namespaces")
+		}
+	}
+	return res, nil
+}
+
+func (c *NamespaceService) GetNamespace(ctx context.Context, query *pbTag.NamespaceName) (*pbTag.Namespace, error) {
+	namespace, err := c.namespaceRepo.GetNamespace(ctx, query.Name)
+	if err != nil {
+		return nil, statusError(ctx, namespaceLookupError(ctx, err, query.Name), "Failed to get namespace")
+	}
+	data, err := c.namespaceData(ctx, namespace)
+	if err != nil {
+		logger.WithContext(ctx).Errorf("Failed to count tags: %s", err)
+		return nil, status.Errorf(codes.Internal, "Failed to get namespace")
+	}
+	return data, nil
+}
+
+// CreateNamespace creates a namespace, its name cannot be changed.
+func (c *NamespaceService) CreateNamespace(ctx context.Context, request *pbTag.SaveNamespaceRequest) (*pbTag.Namespace, error) {
+	namespace := &models.Namespace{Name: request.Name}
+	if err := applySettings(namespace, request); err != nil {
+		return nil, err
+	}
+
+	err := c.uow.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := c.namespaceRepo.GetNamespace(ctx, request.Name); err == nil {
+			return status.Errorf(codes.AlreadyExists, "Namespace %q already exists", request.Name)
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		return c.namespaceRepo.Save(ctx, namespace)
+	})
+	if err != nil {
+		return nil, statusError(ctx, err, "Failed to create namespace")
+	}
+
+	data, err := c.namespaceData(ctx, namespace)
+	if err != nil {
+		logger.WithContext(ctx).Errorf("Failed to count tags: %s", err)
+		return nil, status.Errorf(codes.Internal, "Failed to create namespace")
+	}
+	return data, nil
+}
+
+// UpdateNamespace replaces the settings of a namespace. They apply to the
+// tags created or renamed afterwards, the existing ones are kept.
+func (c *NamespaceService) UpdateNamespace(ctx context.Context, request *pbTag.SaveNamespaceRequest) (*pbTag.Namespace, error) {
+	var namespace *models.Namespace
+	err := c.uow.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		namespace, err = c.namespaceRepo.LockNamespace(ctx, request.Name)
+		if err != nil {
+			return namespaceLookupError(ctx, err, request.Name)
+		}
+		if err := applySettings(namespace, request); err != nil {
+			return err
+		}
+		return c.namespaceRepo.Update(ctx, namespace)
+	})
+	if err != nil {
+		return nil, statusError(ctx, err, "Failed to update namespace")
+	}
+
+	data, err := c.namespaceData(ctx, namespace)
+	if err != nil {
+		logger.WithContext(ctx).Errorf("Failed to count tags: %s", err)
+		return nil, status.Errorf(codes.Internal, "Failed to update namespace")
+	}
+	return data, nil
+}
+
+// DeleteNamespace deletes a namespace without tags, along with its soft
+// deleted tags. The default namespace cannot be deleted.
+func (c *NamespaceService) DeleteNamespace(ctx context.Context, request *pbTag.NamespaceName) error {
+	if request.Name == models.DefaultNamespace {
+		return status.Errorf(codes.FailedPrecondition, "The default namespace cannot be deleted")
+	}
+
+	err := c.uow.WithTx(ctx, func(ctx context.Context) error {
+		// locked so no tag is created meanwhile, see TagService.SaveTag
+		namespace, err := c.namespaceRepo.LockNamespace(ctx, request.Name)
+		if err != nil {
+			return namespaceLookupError(ctx, err, request.Name)
+		}
+		count, err := c.tagRepo.CountTags(ctx, request.Name)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return status.Errorf(codes.FailedPrecondition, "Namespace %q has %d tags, delete them first", request.Name, count)
+		}
+		return c.namespaceRepo.Delete(ctx, namespace)
+	})
+	if err != nil {
+		return statusError(ctx, err, "Failed to delete namespace")
+	}
+	return nil
+}
+
+// namespaceData converts a namespace to its protobuf message, with the
+// number of its tags.
+func (c *NamespaceService) namespaceData(ctx context.Context, namespace *models.Namespace) (*pbTag.Namespace, error) {
+	count, err := c.tagRepo.CountTags(ctx, namespace.Name)
+	if err != nil {
+		return nil, err
+	}
+	return &pbTag.Namespace{
+		Name:              namespace.Name,
+		MaxTags:           namespace.MaxTags,
+		AllowedCharacters: string(namespace.AllowedCharacters),
+		TagCount:          count,
+		CreatedAt:         timezone.Format(namespace.CreatedAt),
+		UpdatedAt:         timezone.Format(namespace.UpdatedAt),
+	}, nil
+}
+
+// applySettings copies the settings of a request to namespace.
+func applySettings(namespace *models.Namespace, request *pbTag.SaveNamespaceRequest) error {
+	charset := tagname.Charset(request.AllowedCharacters)
+	if charset == "" {
+		charset = tagname.AnyCharacters
+	}
+	if !charset.Valid() {
+		return status.Errorf(codes.InvalidArgument, "Unknown allowed characters %q", request.AllowedCharacters)
+	}
+	if request.MaxTags < 0 {
+		return status.Errorf(codes.InvalidArgument, "The maximum number of tags cannot be negative")
+	}
+	namespace.MaxTags = request.MaxTags
+	namespace.AllowedCharacters = charset
+	return nil
+}
+
+// namespaceLookupError reports a missing namespace as NotFound, see
+// lookupError.
+func namespaceLookupError(ctx context.Context, err error, name string) error {
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	logger.WithContext(ctx).Errorf("Failed to get a namespace by name: %s", err)
+	return status.Errorf(codes.NotFound, "Namespace %q not found", name)
+}
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/adapters/database"
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/adapters/database/dbtest"
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/domain/events"
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/domain/repositories"
+
+	// protobuf
+	pbTag "github.com/ponyjackal/go-microservice-boilerplate/proto/tag"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// recorder is a publisher keeping the events. onPublish, when set, is
+// called before they are kept.
+type recorder struct {
+	mu        sync.Mutex
+	events    []events.Event
+	onPublish func(ctx context.Context, events []events.Event)
+}
+
+func (r *recorder) Publish(ctx context.Context, events ...events.Event) {
+	if r.onPublish != nil {
+		r.onPublish(ctx, events)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, events...)
+}
+
+func (r *recorder) published() []events.Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]events.Event{}, r.events...)
+}
+
+// testServices are the services over a migrated SQLite database.
+type testServices struct {
+	db         *database.Database
+	tagRepo    *repositories.TagRepository
+	tags       *TagService
+	namespaces *NamespaceService
+	events     *recorder
+}
+
+func newTestServices(t *testing.T) *testServices {
+	t.Helper()
+	db := dbtest.Open(t)
+	s := &testServices{
+		db:      db,
+		tagRepo: repositories.NewTagRepository(db),
+		events:  &recorder{},
+	}
+	namespaceRepo := repositories.NewNamespaceRepository(db)
+	s.tags = NewTagService(db, s.tagRepo, repositories.NewTagAliasRepository(db), namespaceRepo, s.events)
+	s.namespaces = NewNamespaceService(db, namespaceRepo, s.tagRepo)
+	return s
+}
+
+// saveTag creates a tag and fails the test on error.
+func (s *testServices) saveTag(t *testing.T, request *pbTag.SaveTagRequest) *pbTag.Tag {
+	t.Helper()
+	tag, err := s.tags.SaveTag(context.Background(), request)
+	if err != nil {
+		t.Fatalf("SaveTag(%q) error: %v", request.Name, err)
+	}
+	return tag
+}
+
+// wantCode fails the test unless err has the status code want.
+func wantCode(t *testing.T, err error, want codes.Code) {
+	t.Helper()
+	if got := status.Code(err); got != want {
+		t.Fatalf("got %v (%v), want %v", got, err, want)
+	}
+}
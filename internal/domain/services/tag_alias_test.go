@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	// protobuf
+	pbTag "github.com/ponyjackal/go-microservice-boilerplate/proto/tag"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+)
+
+func TestAddTagAlias(t *testing.T) {
+	ctx := context.Background()
+	s := newTestServices(t)
+	tag := s.saveTag(t, &pbTag.SaveTagRequest{Name: "go"})
+
+	alias, err := s.tags.AddTagAlias(ctx, &pbTag.AddTagAliasRequest{TagId: tag.Id, Name: "  GoLang "})
+	if err != nil {
+		t.Fatalf("AddTagAlias() error: %v", err)
+	}
+	// the name is normalized like the names of the tags
+	if alias.Name != "golang" || alias.Slug != "golang" || alias.TagId != tag.Id {
+		t.Errorf("alias = %+v, want golang of %s", alias, tag.Id)
+	}
+	if got := s.aliasNames(t, tag.Id); !reflect.DeepEqual(got, []string{"golang"}) {
+		t.Errorf("aliases = %q, want [golang]", got)
+	}
+
+	_, err = s.tags.AddTagAlias(ctx, &pbTag.AddTagAliasRequest{TagId: uuid.NewString(), Name: "gopher"})
+	wantCode(t, err, codes.NotFound)
+	_, err = s.tags.AddTagAlias(ctx, &pbTag.AddTagAliasRequest{TagId: tag.Id, Name: "!!!"})
+	wantCode(t, err, codes.InvalidArgument)
+}
+
+func TestTagAliasConflicts(t *testing.T) {
+	ctx := context.Background()
+	s := newTestServices(t)
+	tag := s.saveTag(t, &pbTag.SaveTagRequest{Name: "go"})
+	other := s.saveTag(t, &pbTag.SaveTagRequest{Name: "rust"})
+	if _, err := s.tags.AddTagAlias(ctx, &pbTag.AddTagAliasRequest{TagId: tag.Id, Name: "golang"}); err != nil {
+		t.Fatalf("AddTagAlias() error: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"tag named like an alias", func() error {
+			_, err := s.tags.SaveTag(ctx, &pbTag.SaveTagRequest{Name: "GoLang"})
+			return err
+		}},
+		{"tag renamed like an alias", func() error {
+			_, err := s.tags.UpdateTag(ctx, &pbTag.UpdateTagRequest{Id: other.Id, TagReq: &pbTag.SaveTagRequest{Name: "golang"}})
+			return err
+		}},
+		{"alias named like an alias", func() error {
+			_, err := s.tags.AddTagAlias(ctx, &pbTag.AddTagAliasRequest{TagId: other.Id, Name: "golang"})
+			return err
+		}},
+		{"alias named like a tag", func() error {
+			_, err := s.tags.AddTagAlias(ctx, &pbTag.AddTagAliasRequest{TagId: tag.Id, Name: "Rust"})
+			return err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wantCode(t, tt.call(), codes.AlreadyExists)
+		})
+	}
+}
+
+func TestGetTagRedirectsAliases(t *testing.T) {
+	ctx := context.Background()
+	s := newTestServices(t)
+	tag := s.saveTag(t, &pbTag.SaveTagRequest{Name: "go"})
+	alias, err := s.tags.AddTagAlias(ctx, &pbTag.AddTagAliasRequest{TagId: tag.Id, Name: "golang"})
+	if err != nil {
+		t.Fatalf("AddTagAlias() error: %v", err)
+	}
+
+	byID, err := s.tags.GetTagById(ctx, &pbTag.TagId{Id: alias.Id})
+	if err != nil {
+		t.Fatalf("GetTagById() of the alias error: %v", err)
+	}
+	bySlug, err := s.tags.GetTagBySlug(ctx, &pbTag.TagSlug{Slug: alias.Slug})
+	if err != nil {
+		t.Fatalf("GetTagBySlug() of the alias error: %v", err)
+	}
+	for _, got := range []*pbTag.Tag{byID, bySlug} {
+		if got.Id != tag.Id || got.RedirectedFrom == nil || got.RedirectedFrom.Id != alias.Id {
+			t.Errorf("got %s redirected from %+v, want %s redirected from %s", got.Id, got.RedirectedFrom, tag.Id, alias.Id)
+		}
+	}
+
+	// the tag itself is not redirected
+	got, err := s.tags.GetTagById(ctx, &pbTag.TagId{Id: tag.Id})
+	if err != nil {
+		t.Fatalf("GetTagById() error: %v", err)
+	}
+	if got.RedirectedFrom != nil {
+		t.Errorf("got %s redirected from %+v, want no redirection", got.Id, got.RedirectedFrom)
+	}
+
+	_, err = s.tags.GetTagById(ctx, &pbTag.TagId{Id: uuid.NewString()})
+	wantCode(t, err, codes.NotFound)
+	_, err = s.tags.GetTagById(ctx, &pbTag.TagId{Id: "not-a-uuid"})
+	wantCode(t, err, codes.NotFound)
+	_, err = s.tags.GetTagBySlug(ctx, &pbTag.TagSlug{Slug: "rust"})
+	wantCode(t, err, codes.NotFound)
+
+	// the failures of the database are not reported as missing tags
+	if err := s.db.WithContext(ctx).Exec("DROP TABLE tag_aliases").Error; err != nil {
+		t.Fatalf("DROP TABLE error: %v", err)
+	}
+	_, err = s.tags.GetTagById(ctx, &pbTag.TagId{Id: uuid.NewString()})
+	wantCode(t, err, codes.Internal)
+	_, err = s.tags.GetTagBySlug(ctx, &pbTag.TagSlug{Slug: "rust"})
+	wantCode(t, err, codes.Internal)
+}
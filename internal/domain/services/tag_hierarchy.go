@@ -0,0 +1,238 @@
+package services
+
+import (
+	"context"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/domain/events"
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/domain/models"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/config"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/logger"
+
+	// protobuf
+	pbTag "github.com/ponyjackal/go-microservice-boilerplate/proto/tag"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetTagChildren lists the tags right below a tag sorted by name.
+func (c *TagService) GetTagChildren(ctx context.Context, query *pbTag.TagId) (*pbTag.GetTagsResponse, error) {
+	ns, err := c.namespace(ctx, query.Namespace)
+	if err != nil {
+		return nil, statusError(ctx, err, "Failed to get tag children")
+	}
+	if _, err := c.tagRepo.GetTagById(ctx, ns.Name, query.Id); err != nil {
+		logger.WithContext(ctx).Errorf("Failed to get a tag by id: %s", err)
+		return nil, status.Errorf(codes.NotFound, "Tag not found")
+	}
+	tags, err := c.tagRepo.GetChildren(ctx, query.Id)
+	if err != nil {
+		logger.WithContext(ctx).Errorf("Failed to get tag children: %s", err)
+		return nil, status.Errorf(codes.Internal, "Failed to get tag children")
+	}
+	return tagsData(ctx, tags, "Failed to get tag children")
+}
+
+// GetTagAncestors lists the ancestors of a tag from its root down to its
+// parent.
+func (c *TagService) GetTagAncestors(ctx context.Context, query *pbTag.TagId) (*pbTag.GetTagsResponse, error) {
+	ns, err := c.namespace(ctx, query.Namespace)
+	if err != nil {
+		return nil, statusError(ctx, err, "Failed to get tag ancestors")
+	}
+	if _, err := c.tagRepo.GetTagById(ctx, ns.Name, query.Id); err != nil {
+		logger.WithContext(ctx).Errorf("Failed to get a tag by id: %s", err)
+		return nil, status.Errorf(codes.NotFound, "Tag not found")
+	}
+	tags, err := c.tagRepo.GetAncestors(ctx, query.Id, config.TagsConfig().MaxDepth)
+	if err != nil {
+		logger.WithContext(ctx).Errorf("Failed to get tag ancestors: %s", err)
+		return nil, status.Errorf(codes.Internal, "Failed to get tag ancestors")
+	}
+	return tagsData(ctx, tags, "Failed to get tag ancestors")
+}
+
+// GetTagSubtree lists the descendants of a tag level by level.
+func (c *TagService) GetTagSubtree(ctx context.Context, query *pbTag.TagId) (*pbTag.TagSubtree, error) {
+	ns, err := c.namespace(ctx, query.Namespace)
+	if err != nil {
+		return nil, statusError(ctx, err, "Failed to get tag subtree")
+	}
+	if _, err := c.tagRepo.GetTagById(ctx, ns.Name, query.Id); err != nil {
+		logger.WithContext(ctx).Errorf("Failed to get a tag by id: %s", err)
+		return nil, status.Errorf(codes.NotFound, "Tag not found")
+	}
+	nodes, err := c.tagRepo.GetSubtree(ctx, query.Id, config.TagsConfig().MaxDepth)
+	if err != nil {
+		logger.WithContext(ctx).Errorf("Failed to get tag subtree: %s", err)
+		return nil, status.Errorf(codes.Internal, "Failed to get tag subtree")
+	}
+
+	res := &pbTag.TagSubtree{Nodes: make([]*pbTag.TagNode, len(nodes))}
+	for i := range nodes {
+		data, err := tagData(ctx, &nodes[i].Tag)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Failed to get tag subtree")
+		}
+		res.Nodes[i] = &pbTag.TagNode{Tag: data, Depth: int32(nodes[i].Depth)}
+	}
+	return res, nil
+}
+
+// MoveTag moves a tag and its subtree under a new parent, or to the roots.
+// The parent cannot be in the subtree and the subtree cannot end up deeper
+// than tags.max_depth.
+func (c *TagService) MoveTag(ctx context.Context, request *pbTag.MoveTagRequest) (*pbTag.Tag, error) {
+	ids := []string{request.Id}
+	if request.ParentId != "" {
+		ids = append(ids, request.ParentId)
+	}
+	for _, id := range ids {
+		if _, err := uuid.Parse(id); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid tag id %q", id)
+		}
+	}
+	if request.ParentId == request.Id {
+		return nil, status.Errorf(codes.FailedPrecondition, "Tag cannot be its own parent")
+	}
+	ns, err := c.namespace(ctx, request.Namespace)
+	if err != nil {
+		return nil, statusError(ctx, err, "Failed to move tag")
+	}
+
+	var tag *models.Tag
+	err = c.uow.WithTx(ctx, func(ctx context.Context) error {
+		// both locked in ID order, so concurrent moves of the two see each
+		// other and cannot form a cycle
+		tags, err := c.tagRepo.LockTagsByIds(ctx, ns.Name, ids)
+		if err != nil {
+			return err
+		}
+		var parent *models.Tag
+		for i := range tags {
+			if tags[i].ID.String() == request.Id {
+				tag = &tags[i]
+			} else {
+				parent = &tags[i]
+			}
+		}
+		if tag == nil {
+			return status.Errorf(codes.NotFound, "Tag not found")
+		}
+		if request.ParentId != "" && parent == nil {
+			return status.Errorf(codes.NotFound, "Parent tag not found")
+		}
+
+		if parent == nil {
+			tag.ParentID = nil
+		} else {
+			tag.ParentID = &parent.ID
+		}
+		if err := c.checkPlacement(ctx, tag); err != nil {
+			return err
+		}
+		return c.tagRepo.Update(ctx, tag)
+	})
+	if err != nil {
+		return nil, statusError(ctx, err, "Failed to move tag")
+	}
+	c.publisher.Publish(ctx, tagEvent(events.TagUpdated, tag))
+
+	data, err := tagData(ctx, tag)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to move tag")
+	}
+	return data, nil
+}
+
+// checkPlacement returns FailedPrecondition when the parent of tag is in its
+// subtree or when the subtree would be deeper than tags.max_depth.
+func (c *TagService) checkPlacement(ctx context.Context, tag *models.Tag) error {
+	maxDepth := config.TagsConfig().MaxDepth
+	depth := 1
+	if tag.ParentID != nil {
+		ancestors, err := c.tagRepo.GetAncestors(ctx, tag.ParentID.String(), maxDepth)
+		if err != nil {
+			return err
+		}
+		for _, ancestor := range append(ancestors, models.Tag{ID: *tag.ParentID}) {
+			if ancestor.ID == tag.ID {
+				return status.Errorf(codes.FailedPrecondition, "Tag %s cannot be moved below its own descendant %s", tag.ID, *tag.ParentID)
+			}
+		}
+		depth = len(ancestors) + 2
+	}
+
+	// levels below the tag, a new tag has none
+	height := 0
+	if !tag.CreatedAt.IsZero() {
+		nodes, err := c.tagRepo.GetSubtree(ctx, tag.ID.String(), maxDepth)
+		if err != nil {
+			return err
+		}
+		for _, node := range nodes {
+			if node.Depth > height {
+				height = node.Depth
+			}
+		}
+	}
+	if depth+height > maxDepth {
+		return status.Errorf(codes.FailedPrecondition, "Tag %q would reach depth %d, over the maximum of %d", tag.Name, depth+height, maxDepth)
+	}
+	return nil
+}
+
+// tagsData converts tags to a GetTagsResponse, failing with msg.
+func tagsData(ctx context.Context, tags []models.Tag, msg string) (*pbTag.GetTagsResponse, error) {
+	res := &pbTag.GetTagsResponse{Tags: make([]*pbTag.Tag, len(tags))}
+	for i := range tags {
+		data, err := tagData(ctx, &tags[i])
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, msg)
+		}
+		res.Tags[i] = data
+	}
+	return res, nil
+}
+
+// movedChildren returns the children of the merged sources other than the
+// sources and the target, which are moved under the target.
+func movedChildren(children []models.Tag, sources []models.Tag, target *models.Tag) []models.Tag {
+	skipped := map[uuid.UUID]bool{target.ID: true}
+	for _, source := range sources {
+		skipped[source.ID] = true
+	}
+	var moved []models.Tag
+	for _, child := range children {
+		if !skipped[child.ID] {
+			moved = append(moved, child)
+		}
+	}
+	return moved
+}
+
+// reparentTarget moves the target of a merge in place of the highest source
+// among its ancestors, so that it does not end up below the children of that
+// source. It only changes target, which is saved by the merge.
+func (c *TagService) reparentTarget(ctx context.Context, target *models.Tag, sources []models.Tag) error {
+	if target.ParentID == nil {
+		return nil
+	}
+	ancestors, err := c.tagRepo.GetAncestors(ctx, target.ID.String(), config.TagsConfig().MaxDepth)
+	if err != nil {
+		return err
+	}
+	merged := make(map[uuid.UUID]bool, len(sources))
+	for _, source := range sources {
+		merged[source.ID] = true
+	}
+	// ancestors start at the root
+	for _, ancestor := range ancestors {
+		if merged[ancestor.ID] {
+			target.ParentID = ancestor.ParentID
+			return nil
+		}
+	}
+	return nil
+}
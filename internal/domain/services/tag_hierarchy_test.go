@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/config"
+
+	// protobuf
+	pbTag "github.com/ponyjackal/go-microservice-boilerplate/proto/tag"
+
+	"google.golang.org/grpc/codes"
+)
+
+// saveChain creates n tags, each one below the previous one.
+func (s *testServices) saveChain(t *testing.T, prefix string, n int) []*pbTag.Tag {
+	t.Helper()
+	var chain []*pbTag.Tag
+	parentID := ""
+	for i := 1; i <= n; i++ {
+		tag := s.saveTag(t, &pbTag.SaveTagRequest{Name: fmt.Sprintf("%s%d", prefix, i), ParentId: parentID})
+		chain = append(chain, tag)
+		parentID = tag.Id
+	}
+	return chain
+}
+
+// wantParent fails the test unless the tag with id is below parentID.
+func (s *testServices) wantParent(t *testing.T, id, parentID string) {
+	t.Helper()
+	tag, err := s.tags.GetTagById(context.Background(), &pbTag.TagId{Id: id})
+	if err != nil {
+		t.Fatalf("GetTagById() error: %v", err)
+	}
+	if tag.ParentId != parentID {
+		t.Errorf("parent of %s = %q, want %q", tag.Name, tag.ParentId, parentID)
+	}
+}
+
+func TestMoveTagRejectsCycles(t *testing.T) {
+	ctx := context.Background()
+	s := newTestServices(t)
+	chain := s.saveChain(t, "level", 3)
+	published := len(s.events.published())
+
+	tests := []struct {
+		name         string
+		id, parentID string
+	}{
+		{"itself", chain[0].Id, chain[0].Id},
+		{"its child", chain[0].Id, chain[1].Id},
+		{"its descendant", chain[0].Id, chain[2].Id},
+		{"the child of its child", chain[1].Id, chain[2].Id},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.tags.MoveTag(ctx, &pbTag.MoveTagRequest{Id: tt.id, ParentId: tt.parentID})
+			wantCode(t, err, codes.FailedPrecondition)
+		})
+	}
+
+	// the rejected moves changed nothing
+	s.wantParent(t, chain[0].Id, "")
+	s.wantParent(t, chain[1].Id, chain[0].Id)
+	s.wantParent(t, chain[2].Id, chain[1].Id)
+	if got := len(s.events.published()); got != published {
+		t.Errorf("the rejected moves published %d events", got-published)
+	}
+
+	// moving up is fine
+	if _, err := s.tags.MoveTag(ctx, &pbTag.MoveTagRequest{Id: chain[2].Id}); err != nil {
+		t.Fatalf("MoveTag() to the roots error: %v", err)
+	}
+	s.wantParent(t, chain[2].Id, "")
+	if _, err := s.tags.MoveTag(ctx, &pbTag.MoveTagRequest{Id: chain[0].Id, ParentId: chain[2].Id}); err != nil {
+		t.Fatalf("MoveTag() below a former descendant error: %v", err)
+	}
+	s.wantParent(t, chain[0].Id, chain[2].Id)
+}
+
+func TestMoveTagRejectsDepth(t *testing.T) {
+	ctx := context.Background()
+	s := newTestServices(t)
+	maxDepth := config.TagsConfig().MaxDepth
+	chain := s.saveChain(t, "level", maxDepth)
+
+	// a tag cannot be created below the deepest level
+	_, err := s.tags.SaveTag(ctx, &pbTag.SaveTagRequest{Name: "too deep", ParentId: chain[maxDepth-1].Id})
+	wantCode(t, err, codes.FailedPrecondition)
+
+	// a subtree two levels high fits below the level before the last one only
+	subtree := s.saveChain(t, "branch", 2)
+	_, err = s.tags.MoveTag(ctx, &pbTag.MoveTagRequest{Id: subtree[0].Id, ParentId: chain[maxDepth-2].Id})
+	wantCode(t, err, codes.FailedPrecondition)
+	s.wantParent(t, subtree[0].Id, "")
+
+	if _, err := s.tags.MoveTag(ctx, &pbTag.MoveTagRequest{Id: subtree[0].Id, ParentId: chain[maxDepth-3].Id}); err != nil {
+		t.Fatalf("MoveTag() error: %v", err)
+	}
+	s.wantParent(t, subtree[0].Id, chain[maxDepth-3].Id)
+
+	// moving a tag counts the levels below it
+	root := s.saveTag(t, &pbTag.SaveTagRequest{Name: "root"})
+	_, err = s.tags.MoveTag(ctx, &pbTag.MoveTagRequest{Id: chain[0].Id, ParentId: root.Id})
+	wantCode(t, err, codes.FailedPrecondition)
+	_, err = s.tags.MoveTag(ctx, &pbTag.MoveTagRequest{Id: chain[maxDepth-2].Id, ParentId: subtree[1].Id})
+	wantCode(t, err, codes.FailedPrecondition)
+}
@@ -1,41 +1,86 @@
 package services
 
 import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/domain/autocomplete"
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/domain/events"
 	"github.com/ponyjackal/go-microservice-boilerplate/internal/domain/models"
 	"github.com/ponyjackal/go-microservice-boilerplate/internal/domain/repositories"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/config"
 	"github.com/ponyjackal/go-microservice-boilerplate/pkg/logger"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/tagname"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/timezone"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/trigram"
 
 	// protobuf
 	pbTag "github.com/ponyjackal/go-microservice-boilerplate/proto/tag"
 
+	"github.com/google/uuid"
 	"github.com/jinzhu/copier"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
 )
 
+// UnitOfWork runs fn atomically. Repositories called with the context given
+// to fn share its transaction, nested calls are rolled back on their own.
+type UnitOfWork interface {
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
 type TagService struct {
-	tagRepo *repositories.TagRepository
+	uow           UnitOfWork
+	tagRepo       *repositories.TagRepository
+	aliasRepo     *repositories.TagAliasRepository
+	namespaceRepo *repositories.NamespaceRepository
+	// publisher receives the changes once committed
+	publisher events.Publisher
+	// completions is updated by every mutation of this instance and
+	// reloaded by SyncAutocomplete
+	completions *autocomplete.Index
 }
 
-func NewTagService() *TagService {
+func NewTagService(uow UnitOfWork, tagRepo *repositories.TagRepository, aliasRepo *repositories.TagAliasRepository, namespaceRepo *repositories.NamespaceRepository, publisher events.Publisher) *TagService {
 	return &TagService{
-		tagRepo: &repositories.TagRepository{},
+		uow:           uow,
+		tagRepo:       tagRepo,
+		aliasRepo:     aliasRepo,
+		namespaceRepo: namespaceRepo,
+		publisher:     publisher,
+		completions:   autocomplete.NewIndex(),
 	}
 }
 
-func (c *TagService) GetTags(name string) (*pbTag.GetTagsResponse, error) {
+func (c *TagService) GetTags(ctx context.Context, namespace string, name string, parentID string) (*pbTag.GetTagsResponse, error) {
+	if parentID != "" {
+		if _, err := uuid.Parse(parentID); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid tag id %q", parentID)
+		}
+	}
+	ns, err := c.namespace(ctx, namespace)
+	if err != nil {
+		return nil, statusError(ctx, err, "Failed to get tags")
+	}
+
 	var pbTags []*pbTag.Tag
-	tags, err := c.tagRepo.GetTags(name)
+	tags, err := c.tagRepo.GetTags(ctx, ns.Name, name, parentID)
 	if err != nil {
-		logger.Errorf("Failed to get tags: %s", err)
+		logger.WithContext(ctx).Errorf("Failed to get tags: %s", err)
 		return nil, status.Errorf(codes.Internal, "Failed to get tags")
 	}
 
 	err = copier.Copy(&pbTags, &tags)
 	if err != nil {
-		logger.Errorf("Failed to copy tags: %s", err)
+		logger.WithContext(ctx).Errorf("Failed to copy tags: %s", err)
 		return nil, status.Errorf(codes.Internal, "Failed to get tags")
 	}
+	for i := range pbTags {
+		setTimestamps(ctx, pbTags[i], &tags[i])
+	}
 	res := &pbTag.GetTagsResponse{
 		Tags: pbTags,
 	}
@@ -43,79 +88,728 @@ func (c *TagService) GetTags(name string) (*pbTag.GetTagsResponse, error) {
 	return res, nil
 }
 
-func (c *TagService) GetTagById(query *pbTag.TagId) (*pbTag.Tag, error) {
-	var tagData pbTag.Tag
-	tag, err := c.tagRepo.GetTagById(query.Id)
+// matchTiers names the tiers of repositories.TagMatch.
+var matchTiers = map[int]string{
+	repositories.MatchExact:  "exact",
+	repositories.MatchPrefix: "prefix",
+	repositories.MatchFuzzy:  "fuzzy",
+}
+
+// SearchTags ranks the tags matching the query, with the spans of their
+// names to highlight. The threshold and limit default to search.threshold
+// and search.default_limit, the limit is capped by search.max_limit.
+func (c *TagService) SearchTags(ctx context.Context, query *pbTag.SearchTagsQuery) (*pbTag.SearchTagsResponse, error) {
+	cfg := config.SearchConfig()
+	threshold := query.Threshold
+	if threshold == 0 {
+		threshold = cfg.Threshold
+	}
+	limit := int(query.Limit)
+	if limit == 0 {
+		limit = cfg.DefaultLimit
+	}
+	if limit > cfg.MaxLimit {
+		limit = cfg.MaxLimit
+	}
+
+	ns, err := c.namespace(ctx, query.Namespace)
+	if err != nil {
+		return nil, statusError(ctx, err, "Failed to search tags")
+	}
+	matches, err := c.tagRepo.SearchTags(ctx, ns.Name, query.Query, threshold, limit)
+	if err != nil {
+		logger.WithContext(ctx).Errorf("Failed to search tags: %s", err)
+		return nil, status.Errorf(codes.Internal, "Failed to search tags")
+	}
+
+	res := &pbTag.SearchTagsResponse{Matches: make([]*pbTag.TagMatch, 0, len(matches))}
+	for i := range matches {
+		tag := &matches[i].Tag
+		tagData := &pbTag.Tag{}
+		if err := copier.Copy(tagData, tag); err != nil {
+			logger.WithContext(ctx).Errorf("Failed to copy tag: %s", err)
+			return nil, status.Errorf(codes.Internal, "Failed to search tags")
+		}
+		setTimestamps(ctx, tagData, tag)
+
+		match := &pbTag.TagMatch{
+			Tag:   tagData,
+			Tier:  matchTiers[matches[i].Tier],
+			Score: matches[i].Score,
+			Alias: matches[i].Alias,
+		}
+		matched := tag.Name
+		if match.Alias != "" {
+			matched = match.Alias
+		}
+		for _, span := range trigram.Highlights(query.Query, matched) {
+			match.Highlights = append(match.Highlights, &pbTag.Highlight{Start: int32(span.Start), End: int32(span.End)})
+		}
+		res.Matches = append(res.Matches, match)
+	}
+	return res, nil
+}
+
+// AutocompleteTags suggests the tags starting with the prefix, the most used
+// first. The limit defaults to search.autocomplete.default_limit and is
+// capped by search.autocomplete.max_limit.
+func (c *TagService) AutocompleteTags(ctx context.Context, query *pbTag.AutocompleteTagsQuery) (*pbTag.AutocompleteTagsResponse, error) {
+	cfg := config.SearchConfig().Autocomplete
+	limit := int(query.Limit)
+	if limit == 0 {
+		limit = cfg.DefaultLimit
+	}
+	if limit > cfg.MaxLimit {
+		limit = cfg.MaxLimit
+	}
+
+	ns, err := c.namespace(ctx, query.Namespace)
+	if err != nil {
+		return nil, statusError(ctx, err, "Failed to autocomplete tags")
+	}
+	entries := c.completions.Complete(ns.Name, query.Prefix, limit)
+	res := &pbTag.AutocompleteTagsResponse{Suggestions: make([]*pbTag.TagSuggestion, len(entries))}
+	for i, entry := range entries {
+		res.Suggestions[i] = &pbTag.TagSuggestion{Id: entry.ID, Name: entry.Name, UsageCount: entry.UsageCount}
+	}
+	return res, nil
+}
+
+// RefreshAutocomplete reloads the autocompletion index from the database.
+func (c *TagService) RefreshAutocomplete(ctx context.Context) error {
+	tags, err := c.tagRepo.ListTagNames(ctx)
+	if err != nil {
+		return err
+	}
+	entries := make([]autocomplete.Entry, len(tags))
+	for i := range tags {
+		entries[i] = completion(&tags[i])
+	}
+	c.completions.Replace(entries)
+	return nil
+}
+
+// SyncAutocomplete reloads the autocompletion index every
+// search.autocomplete.refresh_interval until ctx is done, picking up the
+// changes made by other instances.
+func (c *TagService) SyncAutocomplete(ctx context.Context) {
+	ticker := time.NewTicker(config.SearchConfig().Autocomplete.RefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.RefreshAutocomplete(ctx); err != nil && ctx.Err() == nil {
+				logger.WithContext(ctx).Errorf("Failed to refresh the autocompletion index: %s", err)
+			}
+		}
+	}
+}
+
+// RecordTagUsage adds to the usage count of a tag, which ranks the
+// autocompletions. The services tagging items call it with 1, or -1 when
+// they untag one, and the count never goes below 0. No event is published,
+// the count is not part of the tag itself.
+func (c *TagService) RecordTagUsage(ctx context.Context, request *pbTag.RecordTagUsageRequest) (*pbTag.Tag, error) {
+	if _, err := uuid.Parse(request.Id); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid tag id %q", request.Id)
+	}
+	delta := request.Delta
+	if delta == 0 {
+		delta = 1
+	}
+	ns, err := c.namespace(ctx, request.Namespace)
+	if err != nil {
+		return nil, statusError(ctx, err, "Failed to record tag usage")
+	}
+
+	var tag *models.Tag
+	err = c.uow.WithTx(ctx, func(ctx context.Context) error {
+		if err := c.tagRepo.AddUsage(ctx, ns.Name, request.Id, delta); err != nil {
+			return lookupError(ctx, err)
+		}
+		var err error
+		tag, err = c.tagRepo.GetTagById(ctx, ns.Name, request.Id)
+		return err
+	})
 	if err != nil {
-		logger.Errorf("Failed to get a tag by id: %s", err)
+		return nil, statusError(ctx, err, "Failed to record tag usage")
+	}
+	c.completions.Put(completion(tag))
+
+	data, err := tagData(ctx, tag)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to record tag usage")
+	}
+	return data, nil
+}
+
+// GetTagById returns a tag by ID. The ID of an alias resolves to its tag,
+// with RedirectedFrom set.
+func (c *TagService) GetTagById(ctx context.Context, query *pbTag.TagId) (*pbTag.Tag, error) {
+	ns, err := c.namespace(ctx, query.Namespace)
+	if err != nil {
+		return nil, statusError(ctx, err, "Failed to get tag")
+	}
+
+	// postgres rejects the IDs which are not UUIDs
+	if _, err := uuid.Parse(query.Id); err != nil {
+		return nil, status.Errorf(codes.NotFound, "Tag not found")
+	}
+
+	var tagData pbTag.Tag
+	var alias *models.TagAlias
+	tag, err := c.tagRepo.GetTagById(ctx, ns.Name, query.Id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		if alias, err = c.aliasRepo.GetAliasById(ctx, ns.Name, query.Id); err == nil {
+			tag, err = c.tagRepo.GetTagById(ctx, ns.Name, alias.TagID.String())
+		}
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, status.Errorf(codes.NotFound, "Tag not found")
 	}
+	if err != nil {
+		logger.WithContext(ctx).Errorf("Failed to get a tag by id: %s", err)
+		return nil, status.Errorf(codes.Internal, "Failed to get tag")
+	}
 
 	err = copier.Copy(&tagData, tag)
 	if err != nil {
-		logger.Errorf("Failed to copy tag: %s", err)
+		logger.WithContext(ctx).Errorf("Failed to copy tag: %s", err)
 		return nil, status.Errorf(codes.Internal, "Failed to get tag")
 	}
+	setTimestamps(ctx, &tagData, tag)
+	if alias != nil {
+		tagData.RedirectedFrom = aliasData(alias)
+	}
 
 	return &tagData, nil
 }
 
-func (c *TagService) SaveTag(tagReq *pbTag.SaveTagRequest) (*pbTag.Tag, error) {
+// GetTagBySlug returns a tag by slug. The slug of an alias resolves to its
+// tag, with RedirectedFrom set.
+func (c *TagService) GetTagBySlug(ctx context.Context, query *pbTag.TagSlug) (*pbTag.Tag, error) {
+	ns, err := c.namespace(ctx, query.Namespace)
+	if err != nil {
+		return nil, statusError(ctx, err, "Failed to get tag")
+	}
+
+	var alias *models.TagAlias
+	tag, err := c.tagRepo.GetTagBySlug(ctx, ns.Name, query.Slug)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		if alias, err = c.aliasRepo.GetAliasBySlug(ctx, ns.Name, query.Slug); err == nil {
+			tag, err = c.tagRepo.GetTagById(ctx, ns.Name, alias.TagID.String())
+		}
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, status.Errorf(codes.NotFound, "Tag not found")
+	}
+	if err != nil {
+		logger.WithContext(ctx).Errorf("Failed to get a tag by slug: %s", err)
+		return nil, status.Errorf(codes.Internal, "Failed to get tag")
+	}
+
+	var tagData pbTag.Tag
+	if err = copier.Copy(&tagData, tag); err != nil {
+		logger.WithContext(ctx).Errorf("Failed to copy tag: %s", err)
+		return nil, status.Errorf(codes.Internal, "Failed to get tag")
+	}
+	setTimestamps(ctx, &tagData, tag)
+	if alias != nil {
+		tagData.RedirectedFrom = aliasData(alias)
+	}
+
+	return &tagData, nil
+}
+
+// SaveTag creates a tag in its namespace, within the limits of the settings
+// of the namespace.
+func (c *TagService) SaveTag(ctx context.Context, tagReq *pbTag.SaveTagRequest) (*pbTag.Tag, error) {
+	ns, err := c.namespace(ctx, tagReq.Namespace)
+	if err != nil {
+		return nil, statusError(ctx, err, "Failed to save tag")
+	}
+	name, slug, err := normalize(ns, tagReq.Name)
+	if err != nil {
+		return nil, err
+	}
 	tag := &models.Tag{
-		Name: tagReq.Name,
+		Namespace: ns.Name,
+		Name:      name,
+		Slug:      slug,
 	}
 
-	err := c.tagRepo.Save(tag)
+	if tagReq.ParentId != "" {
+		parentID, err := uuid.Parse(tagReq.ParentId)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid tag id %q", tagReq.ParentId)
+		}
+		tag.ParentID = &parentID
+	}
+
+	err = c.uow.WithTx(ctx, func(ctx context.Context) error {
+		if err := c.checkCapacity(ctx, ns.Name); err != nil {
+			return err
+		}
+		if tag.ParentID != nil {
+			// locked so it is not deleted or moved meanwhile
+			if _, err := c.tagRepo.LockTagById(ctx, ns.Name, tag.ParentID.String()); errors.Is(err, gorm.ErrRecordNotFound) {
+				return status.Errorf(codes.NotFound, "Parent tag not found")
+			} else if err != nil {
+				return err
+			}
+			if err := c.checkPlacement(ctx, tag); err != nil {
+				return err
+			}
+		}
+		if err := c.checkSlugFree(ctx, ns.Name, slug, ""); err != nil {
+			return err
+		}
+		return c.tagRepo.Save(ctx, tag)
+	})
 	if err != nil {
-		logger.Errorf("Failed to save tag: %s", err)
-		return nil, status.Errorf(codes.Internal, "Failed to save tag")
+		return nil, statusError(ctx, err, "Failed to save tag")
 	}
+	c.completions.Put(completion(tag))
+	c.publisher.Publish(ctx, tagEvent(events.TagCreated, tag))
 
 	// convert models.Tag to pbTag.Tag
 	tagData := &pbTag.Tag{}
 	if err := copier.Copy(tagData, tag); err != nil {
-		logger.Errorf("Failed to copy tag: %s", err)
+		logger.WithContext(ctx).Errorf("Failed to copy tag: %s", err)
 		return nil, status.Errorf(codes.Internal, "Failed to save tag")
 	}
+	setTimestamps(ctx, tagData, tag)
 
 	return tagData, nil
 }
 
-func (c *TagService) UpdateTag(request *pbTag.UpdateTagRequest) (*pbTag.Tag, error) {
-	tag, err := c.tagRepo.GetTagById(request.Id)
+// GetTagAliases lists the aliases of a tag sorted by name.
+func (c *TagService) GetTagAliases(ctx context.Context, query *pbTag.TagId) (*pbTag.TagAliases, error) {
+	ns, err := c.namespace(ctx, query.Namespace)
 	if err != nil {
-		logger.Errorf("Failed to get a tag by id: %s", err)
+		return nil, statusError(ctx, err, "Failed to get tag aliases")
+	}
+	if _, err := c.tagRepo.GetTagById(ctx, ns.Name, query.Id); err != nil {
+		logger.WithContext(ctx).Errorf("Failed to get a tag by id: %s", err)
 		return nil, status.Errorf(codes.NotFound, "Tag not found")
 	}
+	aliases, err := c.aliasRepo.GetAliases(ctx, query.Id)
+	if err != nil {
+		logger.WithContext(ctx).Errorf("Failed to get tag aliases: %s", err)
+		return nil, status.Errorf(codes.Internal, "Failed to get tag aliases")
+	}
 
-	tag.Name = request.TagReq.Name
+	res := &pbTag.TagAliases{Aliases: make([]*pbTag.TagAlias, len(aliases))}
+	for i := range aliases {
+		res.Aliases[i] = aliasData(&aliases[i])
+	}
+	return res, nil
+}
 
-	err = c.tagRepo.Update(tag)
+// AddTagAlias adds an alternative name to a tag. The name is normalized like
+// the names of the tags and its slug must be free.
+func (c *TagService) AddTagAlias(ctx context.Context, request *pbTag.AddTagAliasRequest) (*pbTag.TagAlias, error) {
+	ns, err := c.namespace(ctx, request.Namespace)
 	if err != nil {
-		logger.Errorf("Failed to update tag: %s", err)
-		return nil, status.Errorf(codes.Internal, "Failed to update tag")
+		return nil, statusError(ctx, err, "Failed to add tag alias")
+	}
+	name, slug, err := normalize(ns, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	alias := &models.TagAlias{
+		Namespace: ns.Name,
+		Name:      name,
+		Slug:      slug,
+	}
+
+	err = c.uow.WithTx(ctx, func(ctx context.Context) error {
+		tag, err := c.tagRepo.LockTagById(ctx, ns.Name, request.TagId)
+		if err != nil {
+			return lookupError(ctx, err)
+		}
+		if err := c.checkSlugFree(ctx, ns.Name, slug, ""); err != nil {
+			return err
+		}
+		alias.TagID = tag.ID
+		return c.aliasRepo.Save(ctx, alias)
+	})
+	if err != nil {
+		return nil, statusError(ctx, err, "Failed to add tag alias")
+	}
+	return aliasData(alias), nil
+}
+
+// RemoveTagAlias removes an alias of a tag.
+func (c *TagService) RemoveTagAlias(ctx context.Context, request *pbTag.TagAliasId) error {
+	ns, err := c.namespace(ctx, request.Namespace)
+	if err != nil {
+		return statusError(ctx, err, "Failed to remove tag alias")
+	}
+	removed, err := c.aliasRepo.Delete(ctx, ns.Name, request.TagId, request.Id)
+	if err != nil {
+		logger.WithContext(ctx).Errorf("Failed to remove tag alias: %s", err)
+		return status.Errorf(codes.Internal, "Failed to remove tag alias")
+	}
+	if !removed {
+		return status.Errorf(codes.NotFound, "Tag alias not found")
+	}
+	return nil
+}
+
+// UpdateTag renames a tag of the namespace of the request, tags cannot
+// change namespace.
+func (c *TagService) UpdateTag(ctx context.Context, request *pbTag.UpdateTagRequest) (*pbTag.Tag, error) {
+	ns, err := c.namespace(ctx, request.TagReq.Namespace)
+	if err != nil {
+		return nil, statusError(ctx, err, "Failed to update tag")
+	}
+	name, slug, err := normalize(ns, request.TagReq.Name)
+	if err != nil {
+		return nil, err
 	}
 
+	var tag *models.Tag
+	err = c.uow.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		tag, err = c.tagRepo.LockTagById(ctx, ns.Name, request.Id)
+		if err != nil {
+			return lookupError(ctx, err)
+		}
+
+		if tag.Slug != slug {
+			if err := c.checkSlugFree(ctx, ns.Name, slug, tag.ID.String()); err != nil {
+				return err
+			}
+			tag.Slug = slug
+		}
+		tag.Name = name
+		return c.tagRepo.Update(ctx, tag)
+	})
+	if err != nil {
+		return nil, statusError(ctx, err, "Failed to update tag")
+	}
+	c.completions.Put(completion(tag))
+	c.publisher.Publish(ctx, tagEvent(events.TagUpdated, tag))
+
 	var tagData pbTag.Tag
 	if err = copier.Copy(&tagData, tag); err != nil {
-		logger.Errorf("Failed to copy tag: %s", err)
+		logger.WithContext(ctx).Errorf("Failed to copy tag: %s", err)
 		return nil, status.Errorf(codes.Internal, "Failed to update tag")
 	}
+	setTimestamps(ctx, &tagData, tag)
 
 	return &tagData, nil
 }
 
-func (c *TagService) DeleteTag(request *pbTag.TagId) error {
-	tag, err := c.tagRepo.GetTagById(request.Id)
+func (c *TagService) DeleteTag(ctx context.Context, request *pbTag.TagId) error {
+	ns, err := c.namespace(ctx, request.Namespace)
+	if err != nil {
+		return statusError(ctx, err, "Failed to delete tag")
+	}
+
+	var tag *models.Tag
+	err = c.uow.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		tag, err = c.tagRepo.LockTagById(ctx, ns.Name, request.Id)
+		if err != nil {
+			return lookupError(ctx, err)
+		}
+		children, err := c.tagRepo.CountChildren(ctx, request.Id)
+		if err != nil {
+			return err
+		}
+		if children > 0 {
+			return status.Errorf(codes.FailedPrecondition, "Tag has %d children, move or delete them first", children)
+		}
+		return c.tagRepo.Delete(ctx, tag, false)
+	})
+	if err != nil {
+		return statusError(ctx, err, "Failed to delete tag")
+	}
+	c.completions.Remove(request.Id)
+	c.publisher.Publish(ctx, tagEvent(events.TagDeleted, tag))
+	return nil
+}
+
+// lookupError reports a missing tag as NotFound. Other errors are returned
+// as is so the transaction can be retried.
+func lookupError(ctx context.Context, err error) error {
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	logger.WithContext(ctx).Errorf("Failed to get a tag by id: %s", err)
+	return status.Errorf(codes.NotFound, "Tag not found")
+}
+
+// errDryRun rolls back the transaction of a dry run.
+var errDryRun = errors.New("dry run")
+
+// MergeTags merges the source tags into the target in one transaction: the
+// sources are soft deleted, their names become aliases of the target sharing
+// their IDs, their aliases move to the target and their usage counts are
+// added to its own. Their children move under the target, which takes the
+// place of the highest source above it, if any. A dry run applies the merge then rolls it back, so it
+// reports the same changes and errors.
+func (c *TagService) MergeTags(ctx context.Context, request *pbTag.MergeTagsRequest) (*pbTag.MergeTagsResponse, error) {
+	for _, id := range append([]string{request.TargetId}, request.SourceIds...) {
+		if _, err := uuid.Parse(id); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid tag id %q", id)
+		}
+	}
+	for _, id := range request.SourceIds {
+		if id == request.TargetId {
+			return nil, status.Errorf(codes.InvalidArgument, "Tag %s cannot be merged into itself", id)
+		}
+	}
+	ns, err := c.namespace(ctx, request.Namespace)
+	if err != nil {
+		return nil, statusError(ctx, err, "Failed to merge tags")
+	}
+
+	var target *models.Tag
+	var sources []models.Tag
+	var created, moved []models.TagAlias
+	var children []models.Tag
+	err = c.uow.WithTx(ctx, func(ctx context.Context) error {
+		// reset when the transaction is retried
+		created = nil
+		var err error
+		if target, err = c.tagRepo.LockTagById(ctx, ns.Name, request.TargetId); err != nil {
+			return lookupError(ctx, err)
+		}
+		if sources, err = c.tagRepo.LockTagsByIds(ctx, ns.Name, request.SourceIds); err != nil {
+			return err
+		}
+		if len(sources) != len(request.SourceIds) {
+			return status.Errorf(codes.NotFound, "Tags not found: %s", strings.Join(missingIds(request.SourceIds, sources), ", "))
+		}
+		if moved, err = c.aliasRepo.GetAliasesOfTags(ctx, request.SourceIds); err != nil {
+			return err
+		}
+		if err = c.aliasRepo.MoveAliases(ctx, request.SourceIds, request.TargetId); err != nil {
+			return err
+		}
+		if children, err = c.tagRepo.GetChildren(ctx, request.SourceIds...); err != nil {
+			return err
+		}
+		children = movedChildren(children, sources, target)
+		if err = c.reparentTarget(ctx, target, sources); err != nil {
+			return err
+		}
+		if err = c.tagRepo.MoveChildren(ctx, request.SourceIds, request.TargetId); err != nil {
+			return err
+		}
+
+		for i := range sources {
+			source := &sources[i]
+			alias := models.TagAlias{ID: source.ID, TagID: target.ID, Namespace: target.Namespace, Name: source.Name, Slug: source.Slug}
+			if err := c.aliasRepo.Save(ctx, &alias); err != nil {
+				return err
+			}
+			created = append(created, alias)
+			target.UsageCount += source.UsageCount
+			if err := c.tagRepo.Delete(ctx, source, false); err != nil {
+				return err
+			}
+		}
+		if err := c.tagRepo.Update(ctx, target); err != nil {
+			return err
+		}
+		if err := c.checkPlacement(ctx, target); err != nil {
+			return err
+		}
+
+		if request.DryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		return nil, statusError(ctx, err, "Failed to merge tags")
+	}
+
+	res := &pbTag.MergeTagsResponse{DryRun: request.DryRun}
+	if res.Target, err = tagData(ctx, target); err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to merge tags")
+	}
+	for i := range sources {
+		source, err := tagData(ctx, &sources[i])
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Failed to merge tags")
+		}
+		res.Sources = append(res.Sources, source)
+	}
+	for i := range created {
+		res.CreatedAliases = append(res.CreatedAliases, aliasData(&created[i]))
+	}
+	for i := range moved {
+		moved[i].TagID = target.ID
+		res.MovedAliases = append(res.MovedAliases, aliasData(&moved[i]))
+	}
+	for i := range children {
+		children[i].ParentID = &target.ID
+		child, err := tagData(ctx, &children[i])
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Failed to merge tags")
+		}
+		res.MovedChildren = append(res.MovedChildren, child)
+	}
+	if request.DryRun {
+		return res, nil
+	}
+
+	changes := make([]events.Event, 0, len(sources)+1)
+	for i := range sources {
+		c.completions.Remove(sources[i].ID.String())
+		event := tagEvent(events.TagMerged, &sources[i])
+		event.TargetID = target.ID.String()
+		changes = append(changes, event)
+	}
+	c.completions.Put(completion(target))
+	c.publisher.Publish(ctx, append(changes, tagEvent(events.TagUpdated, target))...)
+	return res, nil
+}
+
+// missingIds returns the ids without a tag.
+func missingIds(ids []string, tags []models.Tag) []string {
+	found := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		found[tag.ID.String()] = true
+	}
+	var missing []string
+	for _, id := range ids {
+		if !found[id] {
+			missing = append(missing, id)
+		}
+	}
+	return missing
+}
+
+// namespace returns the namespace with name, the default one when empty.
+func (c *TagService) namespace(ctx context.Context, name string) (*models.Namespace, error) {
+	if name == "" {
+		name = models.DefaultNamespace
+	}
+	ns, err := c.namespaceRepo.GetNamespace(ctx, name)
 	if err != nil {
-		logger.Errorf("Failed to get a tag by id: %s", err)
-		return status.Errorf(codes.NotFound, "Tag not found")
+		return nil, namespaceLookupError(ctx, err, name)
 	}
+	return ns, nil
+}
 
-	err = c.tagRepo.Delete(tag, false)
+// checkCapacity locks a namespace, so its tags are created one at a time
+// and it is not deleted meanwhile, and returns FailedPrecondition when it
+// holds its maximum number of tags.
+func (c *TagService) checkCapacity(ctx context.Context, name string) error {
+	ns, err := c.namespaceRepo.LockNamespace(ctx, name)
+	if err != nil {
+		return namespaceLookupError(ctx, err, name)
+	}
+	if ns.MaxTags == 0 {
+		return nil
+	}
+	count, err := c.tagRepo.CountTags(ctx, name)
 	if err != nil {
-		logger.Errorf("Failed to delete tag: %s", err)
-		return status.Errorf(codes.Internal, "Failed to delete tag")
+		return err
+	}
+	if count >= ns.MaxTags {
+		return status.Errorf(codes.FailedPrecondition, "Namespace %q is limited to %d tags", name, ns.MaxTags)
 	}
 	return nil
 }
+
+// normalize returns the name to store, following the configured policy, and
+// its slug. The name must only contain the characters allowed by ns.
+func normalize(ns *models.Namespace, name string) (string, string, error) {
+	name = tagname.Normalize(name, config.NormalizationPolicy())
+	if r, ok := ns.AllowedCharacters.Disallowed(name); ok {
+		return "", "", status.Errorf(codes.InvalidArgument, "Character %q is not allowed in the namespace %q", r, ns.Name)
+	}
+	slug := tagname.Slug(name)
+	if slug == "" {
+		return "", "", status.Errorf(codes.InvalidArgument, "Tag name must contain a letter or a digit")
+	}
+	return name, slug, nil
+}
+
+// checkSlugFree returns AlreadyExists when slug belongs to an alias of the
+// namespace, pointing to its canonical tag, or to a tag of the namespace
+// other than the one with id.
+func (c *TagService) checkSlugFree(ctx context.Context, namespace string, slug string, id string) error {
+	// checked first, the slug of a merged tag is held by its alias too
+	alias, err := c.aliasRepo.GetAliasBySlug(ctx, namespace, slug)
+	switch {
+	case err == nil:
+		return status.Errorf(codes.AlreadyExists, "Tag %q is an alias of the tag %s", alias.Name, alias.TagID)
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return err
+	}
+
+	owner, err := c.tagRepo.FindSlugOwner(ctx, namespace, slug)
+	switch {
+	case err != nil:
+		return err
+	case owner == nil:
+		return nil
+	case owner.ID.String() == id:
+		return nil
+	case owner.DeletedAt.Valid:
+		return status.Errorf(codes.AlreadyExists, "Tag %q conflicts with the deleted tag %s", owner.Name, owner.ID)
+	default:
+		return status.Errorf(codes.AlreadyExists, "Tag %q already exists with id %s", owner.Name, owner.ID)
+	}
+}
+
+// statusError returns status errors unchanged and logs any other error,
+// reported as Internal with msg.
+func statusError(ctx context.Context, err error, msg string) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	logger.WithContext(ctx).Errorf("%s: %s", msg, err)
+	return status.Errorf(codes.Internal, msg)
+}
+
+// completion returns the autocompletion entry of a tag.
+func completion(tag *models.Tag) autocomplete.Entry {
+	return autocomplete.Entry{ID: tag.ID.String(), Namespace: tag.Namespace, Name: tag.Name, UsageCount: tag.UsageCount}
+}
+
+// tagData converts a tag to its protobuf message.
+func tagData(ctx context.Context, tag *models.Tag) (*pbTag.Tag, error) {
+	data := &pbTag.Tag{}
+	if err := copier.Copy(data, tag); err != nil {
+		logger.WithContext(ctx).Errorf("Failed to copy tag: %s", err)
+		return nil, err
+	}
+	setTimestamps(ctx, data, tag)
+	return data, nil
+}
+
+// tagEvent returns the event of a change of tag.
+func tagEvent(eventType events.Type, tag *models.Tag) events.Event {
+	return events.Event{Type: eventType, TagID: tag.ID.String(), Namespace: tag.Namespace, Name: tag.Name, Time: timezone.Now()}
+}
+
+// aliasData converts an alias to its protobuf message.
+func aliasData(alias *models.TagAlias) *pbTag.TagAlias {
+	return &pbTag.TagAlias{
+		Id:        alias.ID.String(),
+		TagId:     alias.TagID.String(),
+		Name:      alias.Name,
+		Slug:      alias.Slug,
+		CreatedAt: timezone.Format(alias.CreatedAt),
+	}
+}
+
+// setTimestamps fills the UTC timestamps of a tag and their display values in
+// the timezone requested by the caller.
+func setTimestamps(ctx context.Context, tagData *pbTag.Tag, tag *models.Tag) {
+	tagData.CreatedAt = timezone.Format(tag.CreatedAt)
+	tagData.UpdatedAt = timezone.Format(tag.UpdatedAt)
+	tagData.CreatedAtLocal = timezone.FormatInContext(ctx, tag.CreatedAt)
+	tagData.UpdatedAtLocal = timezone.FormatInContext(ctx, tag.UpdatedAt)
+}
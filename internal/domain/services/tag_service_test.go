@@ -0,0 +1,348 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/domain/events"
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/domain/models"
+
+	// protobuf
+	pbTag "github.com/ponyjackal/go-microservice-boilerplate/proto/tag"
+
+	"google.golang.org/grpc/codes"
+	"gorm.io/gorm"
+)
+
+// mergeFixture is a target with two sources to merge into it, the first one
+// having an alias and a child.
+type mergeFixture struct {
+	target, golang, gopher, goroutines *pbTag.Tag
+	alias                              *pbTag.TagAlias
+}
+
+func newMergeFixture(t *testing.T, s *testServices) *mergeFixture {
+	t.Helper()
+	ctx := context.Background()
+	f := &mergeFixture{
+		target: s.saveTag(t, &pbTag.SaveTagRequest{Name: "go"}),
+		golang: s.saveTag(t, &pbTag.SaveTagRequest{Name: "golang"}),
+		gopher: s.saveTag(t, &pbTag.SaveTagRequest{Name: "gopher"}),
+	}
+	f.goroutines = s.saveTag(t, &pbTag.SaveTagRequest{Name: "goroutines", ParentId: f.golang.Id})
+
+	var err error
+	if f.alias, err = s.tags.AddTagAlias(ctx, &pbTag.AddTagAliasRequest{TagId: f.golang.Id, Name: "go-lang"}); err != nil {
+		t.Fatalf("AddTagAlias() error: %v", err)
+	}
+	for id, delta := range map[string]int64{f.target.Id: 1, f.golang.Id: 3, f.gopher.Id: 2} {
+		if _, err := s.tags.RecordTagUsage(ctx, &pbTag.RecordTagUsageRequest{Id: id, Delta: delta}); err != nil {
+			t.Fatalf("RecordTagUsage() error: %v", err)
+		}
+	}
+	return f
+}
+
+func (f *mergeFixture) request(dryRun bool) *pbTag.MergeTagsRequest {
+	return &pbTag.MergeTagsRequest{SourceIds: []string{f.golang.Id, f.gopher.Id}, TargetId: f.target.Id, DryRun: dryRun}
+}
+
+// aliasNames returns the sorted names of the aliases of a tag.
+func (s *testServices) aliasNames(t *testing.T, id string) []string {
+	t.Helper()
+	aliases, err := s.tags.GetTagAliases(context.Background(), &pbTag.TagId{Id: id})
+	if err != nil {
+		t.Fatalf("GetTagAliases() error: %v", err)
+	}
+	var names []string
+	for _, alias := range aliases.Aliases {
+		names = append(names, alias.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// checkMergeResponse checks the changes reported by MergeTags, applied or not.
+func checkMergeResponse(t *testing.T, f *mergeFixture, res *pbTag.MergeTagsResponse) {
+	t.Helper()
+	if res.Target.UsageCount != 6 {
+		t.Errorf("target usage count = %d, want 6", res.Target.UsageCount)
+	}
+	if len(res.Sources) != 2 {
+		t.Errorf("sources = %v, want golang and gopher", res.Sources)
+	}
+	created := map[string]string{}
+	for _, alias := range res.CreatedAliases {
+		created[alias.Name] = alias.Id
+		if alias.TagId != f.target.Id {
+			t.Errorf("created alias %q of tag %s, want %s", alias.Name, alias.TagId, f.target.Id)
+		}
+	}
+	// the aliases take the ids of the sources
+	if created["golang"] != f.golang.Id || created["gopher"] != f.gopher.Id {
+		t.Errorf("created aliases = %v, want golang and gopher with their ids", created)
+	}
+	if len(res.MovedAliases) != 1 || res.MovedAliases[0].Id != f.alias.Id || res.MovedAliases[0].TagId != f.target.Id {
+		t.Errorf("moved aliases = %v, want go-lang moved to the target", res.MovedAliases)
+	}
+	if len(res.MovedChildren) != 1 || res.MovedChildren[0].Id != f.goroutines.Id || res.MovedChildren[0].ParentId != f.target.Id {
+		t.Errorf("moved children = %v, want goroutines under the target", res.MovedChildren)
+	}
+}
+
+func TestMergeTagsDryRun(t *testing.T) {
+	ctx := context.Background()
+	s := newTestServices(t)
+	f := newMergeFixture(t, s)
+	published := len(s.events.published())
+
+	res, err := s.tags.MergeTags(ctx, f.request(true))
+	if err != nil {
+		t.Fatalf("MergeTags() error: %v", err)
+	}
+	if !res.DryRun {
+		t.Error("DryRun = false, want true")
+	}
+	checkMergeResponse(t, f, res)
+
+	// nothing was applied
+	if got := len(s.events.published()); got != published {
+		t.Errorf("a dry run published %d events", got-published)
+	}
+	for _, source := range []*pbTag.Tag{f.golang, f.gopher} {
+		tag, err := s.tags.GetTagById(ctx, &pbTag.TagId{Id: source.Id})
+		if err != nil {
+			t.Fatalf("GetTagById(%s) error: %v", source.Name, err)
+		}
+		if tag.RedirectedFrom != nil {
+			t.Errorf("%s redirects to %s after a dry run", source.Name, tag.Name)
+		}
+	}
+	if names := s.aliasNames(t, f.target.Id); len(names) != 0 {
+		t.Errorf("target aliases = %q after a dry run, want none", names)
+	}
+	child, err := s.tags.GetTagById(ctx, &pbTag.TagId{Id: f.goroutines.Id})
+	if err != nil {
+		t.Fatalf("GetTagById() error: %v", err)
+	}
+	if child.ParentId != f.golang.Id {
+		t.Errorf("child parent = %s after a dry run, want %s", child.ParentId, f.golang.Id)
+	}
+	target, err := s.tags.GetTagById(ctx, &pbTag.TagId{Id: f.target.Id})
+	if err != nil {
+		t.Fatalf("GetTagById() error: %v", err)
+	}
+	if target.UsageCount != 1 {
+		t.Errorf("target usage count = %d after a dry run, want 1", target.UsageCount)
+	}
+}
+
+func TestMergeTags(t *testing.T) {
+	ctx := context.Background()
+	s := newTestServices(t)
+	f := newMergeFixture(t, s)
+
+	// the events are published once the merge is visible outside of its
+	// transaction
+	var visible error
+	s.events.onPublish = func(context.Context, []events.Event) {
+		_, err := s.tagRepo.GetTagById(context.Background(), models.DefaultNamespace, f.golang.Id)
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			visible = errors.New("the merged source is still visible")
+		}
+	}
+
+	res, err := s.tags.MergeTags(ctx, f.request(false))
+	if err != nil {
+		t.Fatalf("MergeTags() error: %v", err)
+	}
+	checkMergeResponse(t, f, res)
+	if visible != nil {
+		t.Errorf("events published before the commit: %v", visible)
+	}
+
+	published := s.events.published()
+	var merged []string
+	for _, event := range published[len(published)-3:] {
+		switch event.Type {
+		case events.TagMerged:
+			if event.TargetID != f.target.Id {
+				t.Errorf("merged event target = %s, want %s", event.TargetID, f.target.Id)
+			}
+			merged = append(merged, event.Name)
+		case events.TagUpdated:
+			if event.TagID != f.target.Id {
+				t.Errorf("updated event of %s, want the target", event.TagID)
+			}
+		default:
+			t.Errorf("unexpected %s event", event.Type)
+		}
+	}
+	sort.Strings(merged)
+	if len(merged) != 2 || merged[0] != "golang" || merged[1] != "gopher" {
+		t.Errorf("merged events = %q, want golang and gopher", merged)
+	}
+
+	// the ids of the sources resolve to the target
+	tag, err := s.tags.GetTagById(ctx, &pbTag.TagId{Id: f.golang.Id})
+	if err != nil {
+		t.Fatalf("GetTagById() error: %v", err)
+	}
+	if tag.Id != f.target.Id || tag.RedirectedFrom.GetName() != "golang" {
+		t.Errorf("GetTagById(golang) = %s redirected from %v, want the target", tag.Name, tag.RedirectedFrom)
+	}
+	if tag.UsageCount != 6 {
+		t.Errorf("target usage count = %d, want 6", tag.UsageCount)
+	}
+	if names := s.aliasNames(t, f.target.Id); strings.Join(names, ",") != "go-lang,golang,gopher" {
+		t.Errorf("target aliases = %q, want go-lang, golang and gopher", names)
+	}
+	children, err := s.tags.GetTagChildren(ctx, &pbTag.TagId{Id: f.target.Id})
+	if err != nil {
+		t.Fatalf("GetTagChildren() error: %v", err)
+	}
+	if len(children.Tags) != 1 || children.Tags[0].Id != f.goroutines.Id {
+		t.Errorf("target children = %v, want goroutines", children.Tags)
+	}
+}
+
+func TestMergeTagsErrors(t *testing.T) {
+	ctx := context.Background()
+	s := newTestServices(t)
+	f := newMergeFixture(t, s)
+	published := len(s.events.published())
+
+	_, err := s.tags.MergeTags(ctx, &pbTag.MergeTagsRequest{SourceIds: []string{f.target.Id}, TargetId: f.target.Id})
+	wantCode(t, err, codes.InvalidArgument)
+
+	// a missing source rolls the whole merge back
+	missing := "00000000-0000-4000-8000-000000000000"
+	_, err = s.tags.MergeTags(ctx, &pbTag.MergeTagsRequest{SourceIds: []string{f.golang.Id, missing}, TargetId: f.target.Id})
+	wantCode(t, err, codes.NotFound)
+	if names := s.aliasNames(t, f.target.Id); len(names) != 0 {
+		t.Errorf("target aliases = %q after a failed merge, want none", names)
+	}
+	if got := len(s.events.published()); got != published {
+		t.Errorf("a failed merge published %d events", got-published)
+	}
+}
+
+// createNamespace creates a namespace and fails the test on error.
+func (s *testServices) createNamespace(t *testing.T, request *pbTag.SaveNamespaceRequest) {
+	t.Helper()
+	if _, err := s.namespaces.CreateNamespace(context.Background(), request); err != nil {
+		t.Fatalf("CreateNamespace(%q) error: %v", request.Name, err)
+	}
+}
+
+func TestNamespaceUniqueness(t *testing.T) {
+	ctx := context.Background()
+	s := newTestServices(t)
+	s.createNamespace(t, &pbTag.SaveNamespaceRequest{Name: "docs"})
+	s.createNamespace(t, &pbTag.SaveNamespaceRequest{Name: "blog"})
+
+	// the same name in every namespace
+	s.saveTag(t, &pbTag.SaveTagRequest{Name: "go"})
+	docs := s.saveTag(t, &pbTag.SaveTagRequest{Name: "go", Namespace: "docs"})
+	blog := s.saveTag(t, &pbTag.SaveTagRequest{Name: "go", Namespace: "blog"})
+	if docs.Slug != "go" || blog.Slug != "go" {
+		t.Errorf("slugs = %q and %q, want go in both namespaces", docs.Slug, blog.Slug)
+	}
+
+	// but once per namespace, aliases included
+	_, err := s.tags.SaveTag(ctx, &pbTag.SaveTagRequest{Name: "Go!", Namespace: "docs"})
+	wantCode(t, err, codes.AlreadyExists)
+	if _, err := s.tags.AddTagAlias(ctx, &pbTag.AddTagAliasRequest{TagId: docs.Id, Name: "golang", Namespace: "docs"}); err != nil {
+		t.Fatalf("AddTagAlias() error: %v", err)
+	}
+	_, err = s.tags.SaveTag(ctx, &pbTag.SaveTagRequest{Name: "golang", Namespace: "docs"})
+	wantCode(t, err, codes.AlreadyExists)
+	s.saveTag(t, &pbTag.SaveTagRequest{Name: "golang", Namespace: "blog"})
+
+	// the tags are found in their own namespace only
+	_, err = s.tags.GetTagById(ctx, &pbTag.TagId{Id: docs.Id, Namespace: "blog"})
+	wantCode(t, err, codes.NotFound)
+	tag, err := s.tags.GetTagBySlug(ctx, &pbTag.TagSlug{Slug: "go", Namespace: "blog"})
+	if err != nil {
+		t.Fatalf("GetTagBySlug() error: %v", err)
+	}
+	if tag.Id != blog.Id {
+		t.Errorf("GetTagBySlug(go) in blog = %s, want %s", tag.Id, blog.Id)
+	}
+	_, err = s.tags.MergeTags(ctx, &pbTag.MergeTagsRequest{SourceIds: []string{blog.Id}, TargetId: docs.Id, Namespace: "docs"})
+	wantCode(t, err, codes.NotFound)
+
+	_, err = s.tags.SaveTag(ctx, &pbTag.SaveTagRequest{Name: "go", Namespace: "missing"})
+	wantCode(t, err, codes.NotFound)
+}
+
+func TestNamespaceMaxTags(t *testing.T) {
+	ctx := context.Background()
+	s := newTestServices(t)
+	s.createNamespace(t, &pbTag.SaveNamespaceRequest{Name: "small", MaxTags: 2})
+
+	first := s.saveTag(t, &pbTag.SaveTagRequest{Name: "first", Namespace: "small"})
+	s.saveTag(t, &pbTag.SaveTagRequest{Name: "second", Namespace: "small"})
+	_, err := s.tags.SaveTag(ctx, &pbTag.SaveTagRequest{Name: "third", Namespace: "small"})
+	wantCode(t, err, codes.FailedPrecondition)
+	// the other namespaces are not limited
+	s.saveTag(t, &pbTag.SaveTagRequest{Name: "third"})
+
+	// only the live tags count
+	if err := s.tags.DeleteTag(ctx, &pbTag.TagId{Id: first.Id, Namespace: "small"}); err != nil {
+		t.Fatalf("DeleteTag() error: %v", err)
+	}
+	s.saveTag(t, &pbTag.SaveTagRequest{Name: "third", Namespace: "small"})
+
+	// lowering the maximum below the count only prevents new tags
+	if _, err := s.namespaces.UpdateNamespace(ctx, &pbTag.SaveNamespaceRequest{Name: "small", MaxTags: 1}); err != nil {
+		t.Fatalf("UpdateNamespace() error: %v", err)
+	}
+	ns, err := s.namespaces.GetNamespace(ctx, &pbTag.NamespaceName{Name: "small"})
+	if err != nil {
+		t.Fatalf("GetNamespace() error: %v", err)
+	}
+	if ns.TagCount != 2 {
+		t.Errorf("tag count = %d, want 2", ns.TagCount)
+	}
+	_, err = s.tags.SaveTag(ctx, &pbTag.SaveTagRequest{Name: "fourth", Namespace: "small"})
+	wantCode(t, err, codes.FailedPrecondition)
+}
+
+func TestNamespaceAllowedCharacters(t *testing.T) {
+	tests := []struct {
+		charset  string
+		allowed  []string
+		rejected []string
+	}{
+		{"", []string{"café", "c++", "日本"}, nil},
+		{"ascii", []string{"c++", "node.js", "go 1.22"}, []string{"café", "日本", "emoji 🙂"}},
+		{"alphanumeric", []string{"café au lait", "日本", "kube-proxy"}, []string{"c++", "node.js", "emoji 🙂"}},
+	}
+	for _, tt := range tests {
+		t.Run("charset "+tt.charset, func(t *testing.T) {
+			ctx := context.Background()
+			s := newTestServices(t)
+			s.createNamespace(t, &pbTag.SaveNamespaceRequest{Name: "strict", AllowedCharacters: tt.charset})
+			tag := s.saveTag(t, &pbTag.SaveTagRequest{Name: "tag", Namespace: "strict"})
+
+			for _, name := range tt.allowed {
+				s.saveTag(t, &pbTag.SaveTagRequest{Name: name, Namespace: "strict"})
+			}
+			for _, name := range tt.rejected {
+				_, err := s.tags.SaveTag(ctx, &pbTag.SaveTagRequest{Name: name, Namespace: "strict"})
+				wantCode(t, err, codes.InvalidArgument)
+				// renames and aliases follow the same policy
+				_, err = s.tags.UpdateTag(ctx, &pbTag.UpdateTagRequest{Id: tag.Id, TagReq: &pbTag.SaveTagRequest{Name: name, Namespace: "strict"}})
+				wantCode(t, err, codes.InvalidArgument)
+				_, err = s.tags.AddTagAlias(ctx, &pbTag.AddTagAliasRequest{TagId: tag.Id, Name: name, Namespace: "strict"})
+				wantCode(t, err, codes.InvalidArgument)
+				// the default namespace allows any character
+				s.saveTag(t, &pbTag.SaveTagRequest{Name: name})
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/timezone"
+
+	// protobuf
+	pbTag "github.com/ponyjackal/go-microservice-boilerplate/proto/tag"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// TestTimestampsRoundTrip stores a tag while the server and the process are
+// in other zones than UTC, and reads its timestamps back through GORM, the
+// API and a protobuf Timestamp.
+func TestTimestampsRoundTrip(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Fatal(err)
+	}
+	previousDefault, previousLocal := timezone.Default(), time.Local
+	timezone.SetDefault(tokyo)
+	time.Local = paris
+	t.Cleanup(func() {
+		timezone.SetDefault(previousDefault)
+		time.Local = previousLocal
+	})
+
+	s := newTestServices(t)
+	before := time.Now()
+	created := s.saveTag(t, &pbTag.SaveTagRequest{Name: "timezones"})
+	after := time.Now()
+
+	// the API returns UTC, display fields follow the server timezone
+	createdAt, err := timezone.Parse(created.CreatedAt)
+	if err != nil {
+		t.Fatalf("created_at %q: %v", created.CreatedAt, err)
+	}
+	if created.CreatedAt[len(created.CreatedAt)-1] != 'Z' {
+		t.Errorf("created_at %q is not in UTC", created.CreatedAt)
+	}
+	if createdAt.Before(before) || createdAt.After(after) {
+		t.Errorf("created_at %v is not between %v and %v", createdAt, before, after)
+	}
+	if want := createdAt.In(tokyo).Format(time.RFC3339); created.CreatedAtLocal != want {
+		t.Errorf("created_at_local = %q, want %q", created.CreatedAtLocal, want)
+	}
+
+	// GORM stores and reads back the same instant, in UTC
+	stored, err := s.tagRepo.GetTagById(context.Background(), "default", created.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !stored.CreatedAt.Equal(createdAt) || !stored.UpdatedAt.Equal(createdAt) {
+		t.Errorf("stored %v and %v, want %v", stored.CreatedAt, stored.UpdatedAt, createdAt)
+	}
+	if name, _ := stored.CreatedAt.Zone(); name != "UTC" {
+		t.Errorf("stored created_at is read back in %s, want UTC", name)
+	}
+
+	// through a protobuf Timestamp and back
+	ts := timestamppb.New(stored.CreatedAt)
+	if !ts.AsTime().Equal(createdAt) || timezone.Format(ts.AsTime()) != created.CreatedAt {
+		t.Errorf("got %v through a Timestamp, want %v", ts.AsTime(), createdAt)
+	}
+
+	// a caller preferring another zone sees the same instant
+	ctx := timezone.ContextWithLocation(context.Background(), paris)
+	got, err := s.tags.GetTagById(ctx, &pbTag.TagId{Id: created.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.CreatedAt != created.CreatedAt {
+		t.Errorf("created_at = %q, want %q", got.CreatedAt, created.CreatedAt)
+	}
+	if want := createdAt.In(paris).Format(time.RFC3339); got.CreatedAtLocal != want {
+		t.Errorf("created_at_local = %q, want %q", got.CreatedAtLocal, want)
+	}
+}
@@ -0,0 +1,162 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/config"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/constants"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/logger"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/metrics"
+
+	// protobuf
+	ServiceServer "github.com/ponyjackal/go-microservice-boilerplate/proto/service"
+
+	"github.com/google/uuid"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+type gatewayRouteKey struct{}
+
+// gatewayRoute receives the route template matched by the gateway mux.
+type gatewayRoute struct {
+	pattern string
+}
+
+// newGatewayHandler creates the gRPC-Gateway handler proxying REST requests
+// to the local gRPC server, along with its client connection.
+func newGatewayHandler(ctx context.Context, accessLogger *logger.AccessLogger) (http.Handler, *grpc.ClientConn, error) {
+	cfg := config.Get().Server
+	// Create a client connection to the gRPC server
+	// This is where the gRPC-Gateway proxies the requests
+	conn, err := grpc.DialContext(
+		ctx,
+		fmt.Sprintf("%s:%s", cfg.Host, cfg.GRPCPort),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to dial server: %w", err)
+	}
+
+	gwmux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(gatewayHeaderMatcher),
+		runtime.WithMetadata(recordGatewayRoute),
+	)
+	// Register Service handler
+	if err := ServiceServer.RegisterServiceHandler(ctx, gwmux, conn); err != nil {
+		conn.Close()
+		return nil, nil, fmt.Errorf("failed to register gateway: %w", err)
+	}
+
+	return otelhttp.NewHandler(instrumentGateway(gwmux, accessLogger), "grpc-gateway"), conn, nil
+}
+
+// gatewayHeaderMatcher forwards the request ID, the timezone preference, the
+// feature flag targeting headers and the session in addition to the default
+// headers.
+func gatewayHeaderMatcher(key string) (string, bool) {
+	switch http.CanonicalHeaderKey(key) {
+	case constants.RequestIDHeader:
+		return constants.RequestIDMetadataKey, true
+	case constants.TimezoneHeader:
+		return constants.TimezoneMetadataKey, true
+	case constants.TenantIDHeader:
+		return constants.TenantIDMetadataKey, true
+	case constants.UserIDHeader:
+		return constants.UserIDMetadataKey, true
+	case constants.SessionIDHeader:
+		return constants.SessionIDMetadataKey, true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// recordGatewayRoute stores the matched route template for the access log.
+func recordGatewayRoute(ctx context.Context, r *http.Request) metadata.MD {
+	if route, ok := ctx.Value(gatewayRouteKey{}).(*gatewayRoute); ok {
+		route.pattern, _ = runtime.HTTPPathPattern(ctx)
+	}
+	return nil
+}
+
+// instrumentGateway writes one access log entry and records RED metrics per
+// gateway request.
+func instrumentGateway(h http.Handler, accessLogger *logger.AccessLogger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		done := metrics.HTTPStarted(logger.TransportGateway)
+		defer done()
+
+		requestID := r.Header.Get(constants.RequestIDHeader)
+		if requestID == "" {
+			requestID = uuid.NewString()
+			r.Header.Set(constants.RequestIDHeader, requestID)
+		}
+		w.Header().Set(constants.RequestIDHeader, requestID)
+
+		route := &gatewayRoute{}
+		ctx := logger.ContextWithRequestID(r.Context(), requestID)
+		ctx = context.WithValue(ctx, gatewayRouteKey{}, route)
+		rw := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		h.ServeHTTP(rw, r.WithContext(ctx))
+
+		latency := time.Since(start)
+		// metrics only use route templates, the access log falls back to the raw path
+		template, path := route.pattern, route.pattern
+		if template == "" {
+			template, path = metrics.UnmatchedRoute, r.URL.Path
+		}
+		metrics.ObserveHTTP(logger.TransportGateway, r.Method, template, rw.status, latency)
+		if accessLogger.Skip(path) {
+			return
+		}
+
+		bytesIn := r.ContentLength
+		if bytesIn < 0 {
+			bytesIn = 0
+		}
+		accessLogger.Log(ctx, logger.AccessEntry{
+			Transport: logger.TransportGateway,
+			Method:    r.Method,
+			Route:     path,
+			Status:    rw.status,
+			Latency:   latency,
+			BytesIn:   bytesIn,
+			BytesOut:  rw.bytes,
+			Peer:      r.RemoteAddr,
+			UserAgent: r.UserAgent(),
+		})
+	})
+}
+
+// statusRecorder captures the status code and body size of a response.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	n, err := r.ResponseWriter.Write(b)
+	r.bytes += int64(n)
+	return n, err
+}
+
+func (r *statusRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
@@ -0,0 +1,168 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/constants"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/flags"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/logger"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/metrics"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/session"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/timezone"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/utils"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// newAccessLogInterceptor propagates the request ID and writes one access log
+// entry per unary call.
+func newAccessLogInterceptor(accessLogger *logger.AccessLogger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		requestID := firstValue(md, constants.RequestIDMetadataKey)
+		if requestID == "" {
+			requestID = uuid.NewString()
+		}
+		ctx = logger.ContextWithRequestID(ctx, requestID)
+		if err := grpc.SetHeader(ctx, metadata.Pairs(constants.RequestIDMetadataKey, requestID)); err != nil {
+			logger.Warnf("failed to set request id header: %v", err)
+		}
+
+		logPayload(ctx, "request", info.FullMethod, req)
+		resp, err := handler(ctx, req)
+		logPayload(ctx, "response", info.FullMethod, resp)
+		if accessLogger.Skip(info.FullMethod) {
+			return resp, err
+		}
+
+		entry := logger.AccessEntry{
+			Transport: logger.TransportGRPC,
+			Method:    "UNARY",
+			Route:     info.FullMethod,
+			Code:      status.Code(err).String(),
+			Latency:   time.Since(start),
+			BytesIn:   messageSize(req),
+			BytesOut:  messageSize(resp),
+			UserAgent: firstValue(md, "user-agent"),
+		}
+		if p, ok := peer.FromContext(ctx); ok {
+			entry.Peer = p.Addr.String()
+		}
+		if err != nil {
+			entry.Error = status.Convert(err).Message()
+		}
+		accessLogger.Log(ctx, entry)
+
+		return resp, err
+	}
+}
+
+// newMetricsInterceptor records RED metrics per gRPC method.
+func newMetricsInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		done := metrics.GRPCStarted()
+		defer done()
+
+		resp, err := handler(ctx, req)
+		metrics.ObserveGRPC(info.FullMethod, status.Code(err), time.Since(start))
+		return resp, err
+	}
+}
+
+// newTimezoneInterceptor stores the timezone requested with the x-timezone
+// metadata in the context. Unknown timezones are rejected as invalid.
+func newTimezoneInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		name := firstValue(md, constants.TimezoneMetadataKey)
+		if name == "" {
+			return handler(ctx, req)
+		}
+
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown timezone %q", name)
+		}
+		return handler(timezone.ContextWithLocation(ctx, loc), req)
+	}
+}
+
+// newFlagsInterceptor stores the tenant, user and metadata of the call in
+// the context so feature flags can target them.
+func newFlagsInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		headers := make(map[string]string, len(md))
+		for name, values := range md {
+			if len(values) > 0 {
+				headers[name] = values[0]
+			}
+		}
+
+		attrs := flags.Attributes{
+			Tenant:  firstValue(md, constants.TenantIDMetadataKey),
+			User:    firstValue(md, constants.UserIDMetadataKey),
+			Headers: headers,
+		}
+		return handler(flags.ContextWithAttributes(ctx, attrs), req)
+	}
+}
+
+// newSessionInterceptor stores the session of the call in the context,
+// falling back to the user ID.
+func newSessionInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		id := firstValue(md, constants.SessionIDMetadataKey)
+		if id == "" {
+			id = firstValue(md, constants.UserIDMetadataKey)
+		}
+		if id == "" {
+			return handler(ctx, req)
+		}
+		return handler(session.ContextWithID(ctx, id), req)
+	}
+}
+
+// logPayload logs a redacted copy of a protobuf message at debug level.
+func logPayload(ctx context.Context, kind string, method string, msg interface{}) {
+	if !logger.IsLevelEnabled(logrus.DebugLevel) {
+		return
+	}
+	m, ok := msg.(proto.Message)
+	if !ok {
+		return
+	}
+	payload, err := utils.ConvertProtoToByte(logger.RedactProto(m))
+	if err != nil {
+		return
+	}
+	logger.WithContext(ctx).Debugf("gRPC %s for method %s: %s", kind, method, payload)
+}
+
+// firstValue returns the first value of key in md, or an empty string.
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// messageSize returns the encoded size of a protobuf message.
+func messageSize(msg interface{}) int64 {
+	if m, ok := msg.(proto.Message); ok {
+		return int64(proto.Size(m))
+	}
+	return 0
+}
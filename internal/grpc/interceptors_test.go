@@ -0,0 +1,100 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/constants"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/flags"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/logger"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/metrics"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestFlagsInterceptorTargetsCalls(t *testing.T) {
+	previous := flags.All()
+	err := flags.Set(map[string]flags.Flag{
+		"beta": {
+			Type:  flags.TypeBoolean,
+			Rules: []flags.Rule{{Attribute: flags.AttributeUser, Values: []string{"alice"}, Serve: flags.On}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { flags.Set(previous) })
+
+	interceptor := newFlagsInterceptor()
+	evaluate := func(md metadata.MD) string {
+		ctx := metadata.NewIncomingContext(context.Background(), md)
+		variant, _ := interceptor(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return flags.Variant(ctx, "beta"), nil
+		})
+		return variant.(string)
+	}
+
+	if got := evaluate(metadata.Pairs(constants.UserIDMetadataKey, "alice")); got != flags.On {
+		t.Errorf("got %q for the targeted user, want on", got)
+	}
+	if got := evaluate(metadata.Pairs(constants.UserIDMetadataKey, "bob")); got != flags.Off {
+		t.Errorf("got %q for another user, want off", got)
+	}
+	if got := evaluate(nil); got != flags.Off {
+		t.Errorf("got %q without metadata, want off", got)
+	}
+}
+
+func TestMetricsInterceptorCodes(t *testing.T) {
+	interceptor := newMetricsInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/service.Service/GetTagById"}
+	for _, err := range []error{nil, status.Error(codes.NotFound, "Tag not found"), status.Error(codes.NotFound, "Tag not found"), errors.New("plain")} {
+		interceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, err
+		})
+	}
+
+	exposition := scrape(t)
+	for _, series := range []string{
+		`service_grpc_requests_total{code="OK",method="/service.Service/GetTagById"} 1`,
+		`service_grpc_requests_total{code="NotFound",method="/service.Service/GetTagById"} 2`,
+		`service_grpc_request_errors_total{code="NotFound",method="/service.Service/GetTagById"} 2`,
+		// errors without a status are Unknown
+		`service_grpc_requests_total{code="Unknown",method="/service.Service/GetTagById"} 1`,
+	} {
+		if !strings.Contains(exposition, series+"\n") {
+			t.Errorf("missing %s", series)
+		}
+	}
+}
+
+func TestGatewayMetricsUnmatched(t *testing.T) {
+	notFound := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { http.NotFound(w, r) })
+	h := instrumentGateway(notFound, logger.NewAccessLogger(logger.AccessLogOptions{}))
+	for _, path := range []string{"/api/v1/nothing", "/api/v1/nothing/else"} {
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	exposition := scrape(t)
+	if series := `service_http_requests_total{method="GET",route="unmatched",status="404",transport="gateway"} 2`; !strings.Contains(exposition, series+"\n") {
+		t.Errorf("missing %s", series)
+	}
+	if strings.Contains(exposition, `route="/api/v1/nothing"`) {
+		t.Error("the raw path is a label")
+	}
+}
+
+// scrape returns the exposition of the metrics registry.
+func scrape(t *testing.T) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	return rec.Body.String()
+}
@@ -3,12 +3,14 @@ package server
 import (
 	"context"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
-	"os"
 
 	_ "github.com/ponyjackal/go-microservice-boilerplate/docs"
 	"github.com/ponyjackal/go-microservice-boilerplate/internal/domain/services"
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/health"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/config"
 	"github.com/ponyjackal/go-microservice-boilerplate/pkg/logger"
 
 	// protobuf
@@ -17,8 +19,10 @@ import (
 
 	"github.com/bufbuild/protovalidate-go"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 
 	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
@@ -26,15 +30,36 @@ import (
 // server is used to implement service.ServiceServer.
 type server struct {
 	ServiceServer.UnimplementedServiceServer
-	tagService *services.TagService
-	validator  *protovalidate.Validator
+	tagService       *services.TagService
+	namespaceService *services.NamespaceService
+	validator        *protovalidate.Validator
 }
 
 // GetTags implements service.ServiceServer
 func (s *server) GetTags(ctx context.Context, query *pbTag.GetTagsQuery) (*pbTag.GetTagsResponse, error) {
-	response, err := s.tagService.GetTags(query.Name)
+	response, err := s.tagService.GetTags(ctx, query.Namespace, query.Name, query.ParentId)
 	if err != nil {
-		logger.Errorf("failed to get tags: %s", err)
+		logger.WithContext(ctx).Errorf("failed to get tags: %s", err)
+		return nil, err
+	}
+	return response, nil
+}
+
+// SearchTags implements service.ServiceServer
+func (s *server) SearchTags(ctx context.Context, query *pbTag.SearchTagsQuery) (*pbTag.SearchTagsResponse, error) {
+	response, err := s.tagService.SearchTags(ctx, query)
+	if err != nil {
+		logger.WithContext(ctx).Errorf("failed to search tags: %s", err)
+		return nil, err
+	}
+	return response, nil
+}
+
+// AutocompleteTags implements service.ServiceServer
+func (s *server) AutocompleteTags(ctx context.Context, query *pbTag.AutocompleteTagsQuery) (*pbTag.AutocompleteTagsResponse, error) {
+	response, err := s.tagService.AutocompleteTags(ctx, query)
+	if err != nil {
+		logger.WithContext(ctx).Errorf("failed to autocomplete tags: %s", err)
 		return nil, err
 	}
 	return response, nil
@@ -42,9 +67,20 @@ func (s *server) GetTags(ctx context.Context, query *pbTag.GetTagsQuery) (*pbTag
 
 // GetTagById implements service.ServiceServer
 func (s *server) GetTagById(ctx context.Context, request *pbTag.TagId) (*pbTag.Tag, error) {
-	tag, err := s.tagService.GetTagById(request)
+	tag, err := s.tagService.GetTagById(ctx, request)
+	if err != nil {
+		logger.WithContext(ctx).Errorf("failed to get a tag by id: %s", err)
+		return nil, err
+	}
+
+	return tag, err
+}
+
+// GetTagBySlug implements service.ServiceServer
+func (s *server) GetTagBySlug(ctx context.Context, request *pbTag.TagSlug) (*pbTag.Tag, error) {
+	tag, err := s.tagService.GetTagBySlug(ctx, request)
 	if err != nil {
-		logger.Errorf("failed to get a tag by id: %s", err)
+		logger.WithContext(ctx).Errorf("failed to get a tag by slug: %s", err)
 		return nil, err
 	}
 
@@ -53,9 +89,9 @@ func (s *server) GetTagById(ctx context.Context, request *pbTag.TagId) (*pbTag.T
 
 // SaveTag implements service.ServiceServer
 func (s *server) SaveTag(ctx context.Context, request *pbTag.SaveTagRequest) (*pbTag.Tag, error) {
-	response, err := s.tagService.SaveTag(request)
+	response, err := s.tagService.SaveTag(ctx, request)
 	if err != nil {
-		logger.Errorf("failed to save tag: %s", err)
+		logger.WithContext(ctx).Errorf("failed to save tag: %s", err)
 		return nil, err
 	}
 
@@ -64,20 +100,174 @@ func (s *server) SaveTag(ctx context.Context, request *pbTag.SaveTagRequest) (*p
 
 // UpdateTag implements service.ServiceServer
 func (s *server) UpdateTag(ctx context.Context, request *pbTag.UpdateTagRequest) (*pbTag.Tag, error) {
-	response, err := s.tagService.UpdateTag(request)
+	response, err := s.tagService.UpdateTag(ctx, request)
 	if err != nil {
-		logger.Errorf("failed to update tag: %s", err)
+		logger.WithContext(ctx).Errorf("failed to update tag: %s", err)
 		return nil, err
 	}
 
 	return response, nil
 }
 
+// GetTagAliases implements service.ServiceServer
+func (s *server) GetTagAliases(ctx context.Context, request *pbTag.TagId) (*pbTag.TagAliases, error) {
+	aliases, err := s.tagService.GetTagAliases(ctx, request)
+	if err != nil {
+		logger.WithContext(ctx).Errorf("failed to get tag aliases: %s", err)
+		return nil, err
+	}
+
+	return aliases, err
+}
+
+// AddTagAlias implements service.ServiceServer
+func (s *server) AddTagAlias(ctx context.Context, request *pbTag.AddTagAliasRequest) (*pbTag.TagAlias, error) {
+	alias, err := s.tagService.AddTagAlias(ctx, request)
+	if err != nil {
+		logger.WithContext(ctx).Errorf("failed to add a tag alias: %s", err)
+		return nil, err
+	}
+
+	return alias, err
+}
+
+// RemoveTagAlias implements service.ServiceServer
+func (s *server) RemoveTagAlias(ctx context.Context, request *pbTag.TagAliasId) (*emptypb.Empty, error) {
+	err := s.tagService.RemoveTagAlias(ctx, request)
+	if err != nil {
+		logger.WithContext(ctx).Errorf("failed to remove a tag alias: %s", err)
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, err
+}
+
+// GetTagChildren implements service.ServiceServer
+func (s *server) GetTagChildren(ctx context.Context, request *pbTag.TagId) (*pbTag.GetTagsResponse, error) {
+	response, err := s.tagService.GetTagChildren(ctx, request)
+	if err != nil {
+		logger.WithContext(ctx).Errorf("failed to get tag children: %s", err)
+		return nil, err
+	}
+
+	return response, err
+}
+
+// GetTagAncestors implements service.ServiceServer
+func (s *server) GetTagAncestors(ctx context.Context, request *pbTag.TagId) (*pbTag.GetTagsResponse, error) {
+	response, err := s.tagService.GetTagAncestors(ctx, request)
+	if err != nil {
+		logger.WithContext(ctx).Errorf("failed to get tag ancestors: %s", err)
+		return nil, err
+	}
+
+	return response, err
+}
+
+// GetTagSubtree implements service.ServiceServer
+func (s *server) GetTagSubtree(ctx context.Context, request *pbTag.TagId) (*pbTag.TagSubtree, error) {
+	response, err := s.tagService.GetTagSubtree(ctx, request)
+	if err != nil {
+		logger.WithContext(ctx).Errorf("failed to get tag subtree: %s", err)
+		return nil, err
+	}
+
+	return response, err
+}
+
+// RecordTagUsage implements service.ServiceServer
+func (s *server) RecordTagUsage(ctx context.Context, request *pbTag.RecordTagUsageRequest) (*pbTag.Tag, error) {
+	tag, err := s.tagService.RecordTagUsage(ctx, request)
+	if err != nil {
+		logger.WithContext(ctx).Errorf("failed to record a tag usage: %s", err)
+		return nil, err
+	}
+
+	return tag, err
+}
+
+// MoveTag implements service.ServiceServer
+func (s *server) MoveTag(ctx context.Context, request *pbTag.MoveTagRequest) (*pbTag.Tag, error) {
+	tag, err := s.tagService.MoveTag(ctx, request)
+	if err != nil {
+		logger.WithContext(ctx).Errorf("failed to move a tag: %s", err)
+		return nil, err
+	}
+
+	return tag, err
+}
+
+// MergeTags implements service.ServiceServer
+func (s *server) MergeTags(ctx context.Context, request *pbTag.MergeTagsRequest) (*pbTag.MergeTagsResponse, error) {
+	response, err := s.tagService.MergeTags(ctx, request)
+	if err != nil {
+		logger.WithContext(ctx).Errorf("failed to merge tags: %s", err)
+		return nil, err
+	}
+
+	return response, err
+}
+
 // DeleteTag implements service.ServiceServer
 func (s *server) DeleteTag(ctx context.Context, request *pbTag.TagId) (*emptypb.Empty, error) {
-	err := s.tagService.DeleteTag(request)
+	err := s.tagService.DeleteTag(ctx, request)
 	if err != nil {
-		logger.Errorf("failed to delete a tag: %s", err)
+		logger.WithContext(ctx).Errorf("failed to delete a tag: %s", err)
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, err
+}
+
+// GetNamespaces implements service.ServiceServer
+func (s *server) GetNamespaces(ctx context.Context, _ *emptypb.Empty) (*pbTag.GetNamespacesResponse, error) {
+	response, err := s.namespaceService.GetNamespaces(ctx)
+	if err != nil {
+		logger.WithContext(ctx).Errorf("failed to get namespaces: %s", err)
+		return nil, err
+	}
+
+	return response, err
+}
+
+// GetNamespace implements service.ServiceServer
+func (s *server) GetNamespace(ctx context.Context, request *pbTag.NamespaceName) (*pbTag.Namespace, error) {
+	namespace, err := s.namespaceService.GetNamespace(ctx, request)
+	if err != nil {
+		logger.WithContext(ctx).Errorf("failed to get a namespace: %s", err)
+		return nil, err
+	}
+
+	return namespace, err
+}
+
+// CreateNamespace implements service.ServiceServer
+func (s *server) CreateNamespace(ctx context.Context, request *pbTag.SaveNamespaceRequest) (*pbTag.Namespace, error) {
+	namespace, err := s.namespaceService.CreateNamespace(ctx, request)
+	if err != nil {
+		logger.WithContext(ctx).Errorf("failed to create a namespace: %s", err)
+		return nil, err
+	}
+
+	return namespace, err
+}
+
+// UpdateNamespace implements service.ServiceServer
+func (s *server) UpdateNamespace(ctx context.Context, request *pbTag.SaveNamespaceRequest) (*pbTag.Namespace, error) {
+	namespace, err := s.namespaceService.UpdateNamespace(ctx, request)
+	if err != nil {
+		logger.WithContext(ctx).Errorf("failed to update a namespace: %s", err)
+		return nil, err
+	}
+
+	return namespace, err
+}
+
+// DeleteNamespace implements service.ServiceServer
+func (s *server) DeleteNamespace(ctx context.Context, request *pbTag.NamespaceName) (*emptypb.Empty, error) {
+	err := s.namespaceService.DeleteNamespace(ctx, request)
+	if err != nil {
+		logger.WithContext(ctx).Errorf("failed to delete a namespace: %s", err)
 		return nil, err
 	}
 
@@ -86,6 +276,7 @@ func (s *server) DeleteTag(ctx context.Context, request *pbTag.TagId) (*emptypb.
 
 func newServer(
 	tagService *services.TagService,
+	namespaceService *services.NamespaceService,
 ) *server {
 	validator, err := protovalidate.New()
 	if err != nil {
@@ -93,8 +284,9 @@ func newServer(
 	}
 
 	s := &server{
-		tagService: tagService,
-		validator:  validator,
+		tagService:       tagService,
+		namespaceService: namespaceService,
+		validator:        validator,
 	}
 	return s
 }
@@ -107,8 +299,6 @@ func newUnaryInterceptor() (grpc.UnaryServerInterceptor, error) {
 	}
 
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-		logger.Infof("Handling gRPC request for method %s", info.FullMethod)
-
 		// Type assert req to a protoreflect.ProtoMessage
 		if p, ok := req.(protoreflect.ProtoMessage); ok {
 			// Validate the request
@@ -117,37 +307,10 @@ func newUnaryInterceptor() (grpc.UnaryServerInterceptor, error) {
 			}
 		}
 
-		resp, err := handler(ctx, req)
-		logger.Infof("Completed gRPC request for method %s", info.FullMethod)
-		return resp, err
+		return handler(ctx, req)
 	}, nil
 }
 
-func loggingHandler(h http.Handler, logMessage string) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Log before handling the request
-		logger.Infof("Handling request for: %s with log message: %s", r.URL.Path, logMessage)
-
-		// Pass the request to the original handler
-		h.ServeHTTP(w, r)
-
-		// Log after handling the request if you want
-	})
-}
-
-// func serveSwaggerUI(gwmux *runtime.ServeMux, httpMux *http.ServeMux) {
-// 	swaggerDir := "../docs"
-
-// 	logger.Infof("grpc gateway swagger doc is ready")
-// 	// Serving swagger UI
-// 	httpMux.Handle("/doc", loggingHandler(http.StripPrefix("/doc", http.FileServer(http.Dir(swaggerDir))), "Serving Swagger UI"))
-// 	// Serving swagger doc
-// 	httpMux.HandleFunc("doc.json", func(w http.ResponseWriter, r *http.Request) {
-// 		w.Header().Set("Content-Type", "application/json")
-// 		w.Write(docs.SwaggerJSON)
-// 	})
-// }
-
 func mergeHandlers(gwmux *runtime.ServeMux, httpMux *http.ServeMux) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path == "/doc" || r.URL.Path == "/doc.json" {
@@ -158,14 +321,17 @@ func mergeHandlers(gwmux *runtime.ServeMux, httpMux *http.ServeMux) http.Handler
 	})
 }
 
-func StartServer(
+// NewServer creates the gRPC server and the listener it serves on. The
+// server is started and stopped by the lifecycle manager.
+func NewServer(
 	tagService *services.TagService,
-) {
-	lis, err := net.Listen("tcp", fmt.Sprintf(":%s", os.Getenv("GRPC_SERVER_PORT")))
-	if err != nil {
-		logger.Fatalf("failed to listen: %v", err)
+	namespaceService *services.NamespaceService,
+	accessLogger *logger.AccessLogger,
+	checker *health.Checker,
+) (*grpc.Server, net.Listener, error) {
+	opts := []grpc.ServerOption{
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
 	}
-	opts := []grpc.ServerOption{}
 	// creds, err := credentials.NewServerTLSFromFile(Path(constants.CERT_FILE), Path(constants.KEY_FILE))
 	// if err != nil {
 	// 	logger.Fatalf("Failed to generate credentials: %v", err)
@@ -174,54 +340,51 @@ func StartServer(
 
 	unaryInterceptor, err := newUnaryInterceptor()
 	if err != nil {
-		logger.Fatalf("failed to create interceptor: %v", err)
+		return nil, nil, fmt.Errorf("failed to create interceptor: %w", err)
 	}
-	opts = append(opts, grpc.UnaryInterceptor(unaryInterceptor))
+	opts = append(opts, grpc.ChainUnaryInterceptor(
+		newAccessLogInterceptor(accessLogger),
+		newMetricsInterceptor(),
+		newTimezoneInterceptor(),
+		newFlagsInterceptor(),
+		newSessionInterceptor(),
+		unaryInterceptor,
+	))
 	s := grpc.NewServer(opts...)
 
-	serverInstance := newServer(tagService)
+	serverInstance := newServer(tagService, namespaceService)
 	ServiceServer.RegisterServiceServer(s, serverInstance)
-	logger.Infof("grpc server listening at %v", lis.Addr())
-	go func() {
-		if err := s.Serve(lis); err != nil {
-			logger.Fatalf("failed to serve: %v", err)
-		}
-	}()
-
-	// grpc gateway
-	// Create a client connection to the gRPC server we just started
-	// This is where the gRPC-Gateway proxies the requests
-	// conn, err := grpc.DialContext(
-	// 	context.Background(),
-	// 	fmt.Sprintf("%s:%s", os.Getenv("SERVER_HOST"), os.Getenv("GRPC_SERVER_PORT")),
-	// 	grpc.WithBlock(),
-	// 	grpc.WithTransportCredentials(insecure.NewCredentials()),
-	// )
-	// if err != nil {
-	// 	logger.Errorf("Failed to dial server:", err)
-	// }
-
-	// gwmux := runtime.NewServeMux()
-	// Register Service handler
-	// err = ServiceServer.RegisterServiceHandler(context.Background(), gwmux, conn)
-	// if err != nil {
-	// 	logger.Errorf("Failed to register gateway:", err)
-	// }
+	healthpb.RegisterHealthServer(s, checker.GRPCServer())
 
-	// Serve Swagger UI and doc
-	// httpMux := http.NewServeMux()
-	// httpMux.Handle("/", gwmux)
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%s", config.Get().Server.GRPCPort))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to listen: %w", err)
+	}
+	logger.Infof("grpc server listening at %v", lis.Addr())
+	return s, lis, nil
+}
 
-	// swaggerFiles.ReadFile("../docs/service.swagger.json")
-	// httpMux.Handle("/docs/", loggingHandler(swaggerFiles.Handler, "swagger doc"))
+// NewGatewayServer creates the gRPC-Gateway HTTP server listening on
+// server.gateway_port, along with its connection to the gRPC server to close
+// once it has drained. It returns nil when the gateway is disabled.
+func NewGatewayServer(
+	ctx context.Context,
+	accessLogger *logger.AccessLogger,
+) (*http.Server, io.Closer, error) {
+	cfg := config.Get().Server
+	if cfg.GatewayPort == "" {
+		return nil, nil, nil
+	}
 
-	// gwServer := &http.Server{
-	// 	Addr:    fmt.Sprintf(":%s", os.Getenv("SERVER_PORT")),
-	// 	Handler: httpMux,
-	// }
+	gwHandler, conn, err := newGatewayHandler(ctx, accessLogger)
+	if err != nil {
+		return nil, nil, err
+	}
+	gwServer := &http.Server{
+		Addr:    fmt.Sprintf(":%s", cfg.GatewayPort),
+		Handler: gwHandler,
+	}
 
-	// logger.Infof("Serving gRPC-Gateway on %s:%s", os.Getenv("SERVER_HOST"), os.Getenv("SERVER_PORT"))
-	// if err := gwServer.ListenAndServe(); err != nil {
-	// 	logger.Fatalf("failed to serve grpc gateway: %v", err)
-	// }
+	logger.Infof("Serving gRPC-Gateway on %s:%s", cfg.Host, cfg.GatewayPort)
+	return gwServer, conn, nil
 }
@@ -0,0 +1,131 @@
+package server
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/adapters/database/dbtest"
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/domain/events"
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/domain/repositories"
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/domain/services"
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/health"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/config"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/logger"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/tracing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// recordSpans installs a global tracer provider sampling every span into the
+// returned recorder until the end of the test.
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(tracing.NewProvider(recorder, resource.Empty(), 1))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+	return recorder
+}
+
+// freePort returns a TCP port nothing listens on.
+func freePort(t *testing.T) string {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lis.Close()
+	_, port, _ := net.SplitHostPort(lis.Addr().String())
+	return port
+}
+
+func TestGatewayTracing(t *testing.T) {
+	recorder := recordSpans(t)
+	if err := config.SetupConfig([]string{"--database.driver=sqlite", "--database.master.name=unused.db", "--server.host=127.0.0.1", "--server.grpc_port=" + freePort(t)}); err != nil {
+		t.Fatal(err)
+	}
+
+	db := dbtest.Open(t)
+	tagRepo := repositories.NewTagRepository(db)
+	namespaceRepo := repositories.NewNamespaceRepository(db)
+	tagService := services.NewTagService(db, tagRepo, repositories.NewTagAliasRepository(db), namespaceRepo, events.LogPublisher{})
+	namespaceService := services.NewNamespaceService(db, namespaceRepo, tagRepo)
+	accessLogger := logger.NewAccessLogger(config.AccessLogConfig())
+
+	grpcServer, lis, err := NewServer(tagService, namespaceService, accessLogger, health.NewChecker(time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	go grpcServer.Serve(lis)
+	defer grpcServer.Stop()
+
+	handler, conn, err := newGatewayHandler(context.Background(), accessLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/tags", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("got status %d: %s", rec.Code, rec.Body)
+	}
+
+	// the server span may end after the response is written
+	var gateway, client, server sdktrace.ReadOnlySpan
+	var queries []sdktrace.ReadOnlySpan
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		gateway, client, server, queries = nil, nil, nil, nil
+		for _, span := range recorder.Ended() {
+			switch {
+			case span.Name() == "grpc-gateway":
+				gateway = span
+			case span.Name() == "service.Service/GetTags" && span.SpanKind() == trace.SpanKindClient:
+				client = span
+			case span.Name() == "service.Service/GetTags" && span.SpanKind() == trace.SpanKindServer:
+				server = span
+			case span.SpanKind() == trace.SpanKindClient && strings.HasPrefix(span.Name(), "gorm."):
+				queries = append(queries, span)
+			}
+		}
+		if gateway != nil && client != nil && server != nil {
+			break
+		}
+	}
+	if gateway == nil || client == nil || server == nil {
+		t.Fatalf("missing spans: gateway %v, client %v, server %v", gateway != nil, client != nil, server != nil)
+	}
+
+	if client.Parent().SpanID() != gateway.SpanContext().SpanID() {
+		t.Error("the gRPC client span is not a child of the gateway span")
+	}
+	if server.Parent().SpanID() != client.SpanContext().SpanID() {
+		t.Error("the gRPC server span is not a child of the client span, the trace context was not propagated")
+	}
+	// the migrations ran in traces of their own
+	traced := 0
+	for _, query := range queries {
+		if query.SpanContext().TraceID() != gateway.SpanContext().TraceID() {
+			continue
+		}
+		traced++
+		if query.Parent().SpanID() != server.SpanContext().SpanID() {
+			t.Errorf("the query span %q is not a child of the gRPC server span", query.Name())
+		}
+	}
+	if traced == 0 {
+		t.Error("no query span in the trace of the request")
+	}
+}
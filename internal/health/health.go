@@ -0,0 +1,179 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/logger"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Statuses reported by the readiness checks.
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// ServiceName is the gRPC service whose serving status mirrors readiness.
+const ServiceName = "service.Service"
+
+// Check reports the health of a single dependency.
+type Check func(ctx context.Context) error
+
+// CheckResult is the outcome of a single dependency check.
+type CheckResult struct {
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+	// Optional checks are reported without affecting readiness.
+	Optional bool `json:"optional,omitempty"`
+}
+
+// Report is the outcome of a readiness evaluation.
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+type namedCheck struct {
+	name     string
+	check    Check
+	optional bool
+}
+
+// Checker evaluates readiness from a set of dependency checks and mirrors it
+// in the standard gRPC health service.
+type Checker struct {
+	mu           sync.RWMutex
+	checks       []namedCheck
+	timeout      time.Duration
+	shuttingDown int32
+	grpcHealth   *health.Server
+}
+
+// NewChecker creates a checker whose dependency checks time out after timeout.
+func NewChecker(timeout time.Duration) *Checker {
+	grpcHealth := health.NewServer()
+	grpcHealth.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	grpcHealth.SetServingStatus(ServiceName, healthpb.HealthCheckResponse_NOT_SERVING)
+
+	return &Checker{
+		timeout:    timeout,
+		grpcHealth: grpcHealth,
+	}
+}
+
+// AddCheck registers a dependency check under name.
+func (c *Checker) AddCheck(name string, check Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks = append(c.checks, namedCheck{name: name, check: check})
+}
+
+// AddOptionalCheck registers a dependency check under name whose failure is
+// reported but leaves the service ready, for dependencies with a fallback.
+func (c *Checker) AddOptionalCheck(name string, check Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks = append(c.checks, namedCheck{name: name, check: check, optional: true})
+}
+
+// GRPCServer returns the grpc.health.v1 implementation to register on the
+// gRPC server.
+func (c *Checker) GRPCServer() healthpb.HealthServer {
+	return c.grpcHealth
+}
+
+// Readiness runs every check concurrently and reports their status. The
+// report is down while the service is shutting down.
+func (c *Checker) Readiness(ctx context.Context) Report {
+	c.mu.RLock()
+	checks := append([]namedCheck{}, c.checks...)
+	c.mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	results := make([]CheckResult, len(checks))
+	var wg sync.WaitGroup
+	for i, nc := range checks {
+		wg.Add(1)
+		go func(i int, nc namedCheck) {
+			defer wg.Done()
+			start := time.Now()
+			err := nc.check(ctx)
+			results[i] = CheckResult{
+				Status:    StatusUp,
+				LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+				Optional:  nc.optional,
+			}
+			if err != nil {
+				results[i].Status = StatusDown
+				results[i].Error = logger.Redact(err.Error())
+			}
+		}(i, nc)
+	}
+	wg.Wait()
+
+	report := Report{
+		Status: StatusUp,
+		Checks: make(map[string]CheckResult, len(checks)),
+	}
+	for i, nc := range checks {
+		report.Checks[nc.name] = results[i]
+		if results[i].Status != StatusUp && !nc.optional {
+			report.Status = StatusDown
+		}
+	}
+	if c.IsShuttingDown() {
+		report.Status = StatusDown
+	}
+	return report
+}
+
+// Refresh evaluates readiness and updates the gRPC serving status.
+func (c *Checker) Refresh(ctx context.Context) Report {
+	report := c.Readiness(ctx)
+	servingStatus := healthpb.HealthCheckResponse_SERVING
+	if report.Status != StatusUp {
+		servingStatus = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	// ignored by the health server once Shutdown has been called
+	c.grpcHealth.SetServingStatus("", servingStatus)
+	c.grpcHealth.SetServingStatus(ServiceName, servingStatus)
+	return report
+}
+
+// Run refreshes the gRPC serving status every interval until ctx is done.
+func (c *Checker) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	c.Refresh(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if report := c.Refresh(ctx); report.Status != StatusUp {
+				logger.Warnf("service is not ready: %+v", report.Checks)
+			}
+		}
+	}
+}
+
+// Shutdown permanently marks the service as not ready so load balancers
+// drain traffic before the servers stop.
+func (c *Checker) Shutdown() {
+	atomic.StoreInt32(&c.shuttingDown, 1)
+	c.grpcHealth.Shutdown()
+}
+
+// IsShuttingDown reports whether Shutdown has been called.
+func (c *Checker) IsShuttingDown() bool {
+	return atomic.LoadInt32(&c.shuttingDown) == 1
+}
@@ -0,0 +1,64 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestReadinessOptionalCheck(t *testing.T) {
+	checker := NewChecker(time.Second)
+	checker.AddCheck("primary", func(ctx context.Context) error { return nil })
+	checker.AddOptionalCheck("replica-0", func(ctx context.Context) error { return errors.New("ejected") })
+
+	report := checker.Readiness(context.Background())
+	if report.Status != StatusUp {
+		t.Fatalf("got status %s with an optional check down, want %s", report.Status, StatusUp)
+	}
+	replica := report.Checks["replica-0"]
+	if replica.Status != StatusDown || !replica.Optional || replica.Error != "ejected" {
+		t.Fatalf("got replica-0 %+v, want an optional check down", replica)
+	}
+
+	checker.AddCheck("migrations", func(ctx context.Context) error { return errors.New("pending") })
+	if report := checker.Readiness(context.Background()); report.Status != StatusDown {
+		t.Fatalf("got status %s with a required check down, want %s", report.Status, StatusDown)
+	}
+}
+
+func TestShutdown(t *testing.T) {
+	ctx := context.Background()
+	checker := NewChecker(time.Second)
+	checker.AddCheck("primary", func(ctx context.Context) error { return nil })
+
+	status := func(service string) healthpb.HealthCheckResponse_ServingStatus {
+		t.Helper()
+		res, err := checker.GRPCServer().Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		if err != nil {
+			t.Fatalf("Check(%q) error: %v", service, err)
+		}
+		return res.Status
+	}
+
+	checker.Refresh(ctx)
+	for _, service := range []string{"", ServiceName} {
+		if got := status(service); got != healthpb.HealthCheckResponse_SERVING {
+			t.Fatalf("got %q %s before the shutdown, want SERVING", service, got)
+		}
+	}
+
+	checker.Shutdown()
+	if report := checker.Readiness(ctx); report.Status != StatusDown {
+		t.Errorf("got status %s while shutting down, want %s", report.Status, StatusDown)
+	}
+	// later refreshes do not serve again
+	checker.Refresh(ctx)
+	for _, service := range []string{"", ServiceName} {
+		if got := status(service); got != healthpb.HealthCheckResponse_NOT_SERVING {
+			t.Errorf("got %q %s after the shutdown, want NOT_SERVING", service, got)
+		}
+	}
+}
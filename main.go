@@ -1,85 +1,230 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
 	"os"
-	"os/signal"
-	"sync"
-	"syscall"
-	"time"
 
 	"github.com/ponyjackal/go-microservice-boilerplate/internal/adapters/database"
 	"github.com/ponyjackal/go-microservice-boilerplate/internal/adapters/database/migrations"
 	"github.com/ponyjackal/go-microservice-boilerplate/internal/adapters/database/seeds"
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/admin"
 	"github.com/ponyjackal/go-microservice-boilerplate/internal/app/routers"
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/domain/events"
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/domain/repositories"
 	"github.com/ponyjackal/go-microservice-boilerplate/internal/domain/services"
 	server "github.com/ponyjackal/go-microservice-boilerplate/internal/grpc"
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/health"
 	"github.com/ponyjackal/go-microservice-boilerplate/pkg/config"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/flags"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/lifecycle"
 	"github.com/ponyjackal/go-microservice-boilerplate/pkg/logger"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/timezone"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/tracing"
 	// product
 )
 
 // @BasePath /api/v1
 func main() {
-	// init timezone and db
-	initDB()
-	defer cleanUp()
-	// Set up shutdownCh and wg
-	shutdownCh := make(chan struct{})
-	var wg sync.WaitGroup
+	os.Exit(run(os.Args[1:]))
+}
+
+// run dispatches the subcommand in args, serving by default, and returns
+// the process exit code.
+func run(args []string) int {
+	if len(args) > 0 {
+		switch args[0] {
+		case "migrate":
+			return runMigrate(args[1:])
+		case "seed":
+			return runSeed(args[1:])
+		}
+	}
+	return serve(args)
+}
+
+// setup loads the configuration from args and configures logging. It
+// returns false along with the exit code when the process must stop.
+func setup(args []string) (int, bool) {
+	// load configuration, reporting every problem at once
+	if err := config.SetupConfig(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return lifecycle.ExitOK, false
+		}
+		logger.Errorf("config SetupConfig() error: %s", err)
+		return lifecycle.ExitServerError, false
+	}
+	cfg := config.Get()
+	logger.SetEnvironment(cfg.App.Env)
+	logger.SetLogLevel(cfg.Logging.LogLevel())
+	// timestamps are stored in UTC, the server timezone only affects display
+	timezone.SetDefault(config.TimezoneConfig())
+	logger.SetLocation(config.TimezoneConfig())
+	// mask secrets before anything is logged
+	if err := logger.SetRedaction(config.RedactionConfig()); err != nil {
+		logger.Errorf("logger SetRedaction() error: %s", err)
+		return lifecycle.ExitServerError, false
+	}
+	if err := flags.Set(cfg.Flags); err != nil {
+		logger.Errorf("flags Set() error: %s", err)
+		return lifecycle.ExitServerError, false
+	}
+	return lifecycle.ExitOK, true
+}
+
+// serve starts every server and background worker and blocks until the
+// service has shut down, returning the process exit code.
+func serve(args []string) int {
+	if code, ok := setup(args); !ok {
+		return code
+	}
+
+	shutdownTimeout, drainDelay := config.ShutdownConfig()
+	manager := lifecycle.New(lifecycle.Options{
+		ShutdownTimeout: shutdownTimeout,
+		DrainDelay:      drainDelay,
+	})
+	if err := start(manager); err != nil {
+		// release what was opened before the failure
+		logger.Errorf("%s", err)
+		manager.Close()
+		return lifecycle.ExitServerError
+	}
+	return manager.Run()
+}
+
+// start opens the dependencies and registers every server and worker with
+// manager, along with the closers of what it opened.
+func start(manager *lifecycle.Manager) error {
+	// init tracing
+	shutdownTracing, err := tracing.Setup(context.Background(), config.TracingConfig())
+	if err != nil {
+		return fmt.Errorf("tracing Setup() error: %w", err)
+	}
+	// closers run in reverse order: the database is closed before traces are flushed
+	manager.AddCloser("tracing", shutdownTracing)
+
+	// init db
+	db, err := initDB(manager)
+	if err != nil {
+		return err
+	}
+
+	/* repository */
+	tagRepo := repositories.NewTagRepository(db)
+	tagAliasRepo := repositories.NewTagAliasRepository(db)
+	namespaceRepo := repositories.NewNamespaceRepository(db)
 
 	/* service */
-	tagService := services.NewTagService()
+	tagService := services.NewTagService(db, tagRepo, tagAliasRepo, namespaceRepo, events.LogPublisher{})
+	namespaceService := services.NewNamespaceService(db, namespaceRepo, tagRepo)
+	if err := tagService.RefreshAutocomplete(context.Background()); err != nil {
+		return fmt.Errorf("tagService RefreshAutocomplete() error: %w", err)
+	}
+
+	// access log shared by gin, grpc and the gateway
+	accessLogger := logger.NewAccessLogger(config.AccessLogConfig())
+
+	// apply the fields that support hot reload, see config.Reload
+	config.OnReload(func(cfg *config.Configuration) {
+		logger.SetLogLevel(cfg.Logging.LogLevel())
+		accessLogger.SetOptions(config.AccessLogConfig())
+		if err := logger.SetRedaction(config.RedactionConfig()); err != nil {
+			logger.Errorf("logger SetRedaction() error: %s", err)
+		}
+		if err := flags.Set(cfg.Flags); err != nil {
+			logger.Errorf("flags Set() error: %s", err)
+		}
+	})
+	// reload on SIGHUP and config file changes
+	manager.AddWorker("config watcher", config.Watch)
+
+	// eject unreachable or lagging replicas, reads fall back to the primary
+	manager.AddWorker("replica monitor", db.MonitorReplicas)
+
+	// pick up the tags changed by other instances
+	manager.AddWorker("autocomplete sync", tagService.SyncAutocomplete)
+
+	// readiness checks, mirrored in the grpc health service
+	checkTimeout, checkInterval := config.HealthCheckConfig()
+	checker := health.NewChecker(checkTimeout)
+	checker.AddCheck("primary", db.PingPrimary)
+	// reads fall back to the primary, a replica down does not make the service unready
+	for name, check := range db.ReplicaChecks() {
+		checker.AddOptionalCheck(name, check)
+	}
+	checker.AddCheck("migrations", func(ctx context.Context) error {
+		return migrations.CheckState(ctx, db)
+	})
+	manager.AddWorker("health checker", func(ctx context.Context) {
+		checker.Run(ctx, checkInterval)
+	})
+	// stop reporting ready so load balancers drain traffic first
+	manager.OnShutdown(checker.Shutdown)
 
 	// setup router
-	router := routers.SetupRoute(tagService)
-
-	// Start the Gin server concurrently in a Goroutine
-	serverErrCh := make(chan error)
-	go func() {
-		serverErrCh <- router.Run(config.ServerConfig())
-	}()
-	// start grpc server
-	server.StartServer(tagService)
-
-	// Graceful shutdown
-	sigCh := make(chan os.Signal, 1)
-	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
-	<-sigCh
-
-	// Wait for a signal or a server error
-	select {
-	case sig := <-sigCh:
-		logger.Infof("Received signal: %v. Shutting down...", sig)
-	case err := <-serverErrCh:
-		logger.Infof("Server error: %v. Shutting down...", err)
-	}
-
-	close(shutdownCh) // signal all goroutines to stop
-	wg.Wait()         // wait for all goroutines to stop
-}
+	router := routers.SetupRoute(tagService, namespaceService, accessLogger, checker)
+	manager.AddHTTPServer("http server", &http.Server{
+		Addr:    config.ServerConfig(),
+		Handler: router,
+	})
 
-func initDB() {
-	//set timezone
-	os.Setenv("SERVER_TIMEZONE", "Asia/Tokyo")
-	loc, _ := time.LoadLocation(os.Getenv("SERVER_TIMEZONE"))
-	time.Local = loc
-	// setup db
-	if err := config.SetupConfig(); err != nil {
-		logger.Fatalf("config SetupConfig() error: %s", err)
+	// setup grpc server
+	grpcServer, lis, err := server.NewServer(tagService, namespaceService, accessLogger, checker)
+	if err != nil {
+		return fmt.Errorf("grpc NewServer() error: %w", err)
 	}
-	masterDSN, replicaDSN := config.DbConfiguration()
+	manager.AddGRPCServer("grpc server", grpcServer, lis)
 
-	if err := database.DbConnection(masterDSN, replicaDSN); err != nil {
-		logger.Fatalf("database DbConnection error: %s", err)
+	// setup grpc gateway
+	gwServer, gwConn, err := server.NewGatewayServer(context.Background(), accessLogger)
+	if err != nil {
+		// the grpc server is not serving yet, only its listener is open
+		lis.Close()
+		return fmt.Errorf("grpc NewGatewayServer() error: %w", err)
 	}
-	// run db migration
-	migrations.Migrate()
-	// Check if seed data exists
-	if !seeds.IsSeedDataExists() {
-		// Run db seed
-		seeds.SeedData()
+	if gwServer != nil {
+		// the gateway drains before the grpc server it proxies to, then closes its connection
+		manager.AddHTTPServer("grpc gateway", gwServer,
+			lifecycle.DependsOn("grpc server"),
+			lifecycle.OnStopped(gwConn.Close),
+		)
+	}
+
+	// setup admin server exposing metrics
+	if adminServer := admin.NewServer(); adminServer != nil {
+		manager.AddHTTPServer("admin server", adminServer)
 	}
+	return nil
 }
 
-func cleanUp() {
+// initDB opens the database, closed by manager, then applies the migrations
+// and seeds the fixtures as configured.
+func initDB(manager *lifecycle.Manager) (*database.Database, error) {
+	// setup db
+	db, err := database.New(context.Background(), config.Get().Database, database.Options{
+		Production: config.Get().App.Env == "production",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("database New() error: %w", err)
+	}
+	manager.AddCloser("database", func(ctx context.Context) error {
+		return db.Close()
+	})
+	// run db migration, or leave it to the migrate command
+	if config.Get().Database.AutoMigrate {
+		if err := migrations.Up(context.Background(), db); err != nil {
+			return nil, fmt.Errorf("migrations Up() error: %w", err)
+		}
+	}
+	// upsert the fixtures of the configured environment
+	if env := config.Get().Database.SeedEnv; env != "" {
+		if err := seeds.Seed(context.Background(), db, env, seeds.Options{}); err != nil {
+			return nil, fmt.Errorf("seeds Seed() error: %w", err)
+		}
+	}
+	return db, nil
 }
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/adapters/database"
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/adapters/database/migrations"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/config"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/lifecycle"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/logger"
+)
+
+const migrateUsage = `usage: migrate [flags] <command>
+
+commands:
+  up             apply every pending migration
+  down N         roll back the last N migrations
+  status         list the migrations and whether they are applied
+  to ID          apply or roll back migrations until ID is the last applied
+  create NAME    write empty up and down SQL files in ` + migrations.SQLDir + `
+
+flags are the configuration flags of the service, see --help`
+
+// runMigrate runs the migrate subcommand and returns the process exit code.
+func runMigrate(args []string) int {
+	// create only writes files, it needs no configuration
+	if len(args) > 0 && args[0] == "create" {
+		if len(args) != 2 {
+			fmt.Fprintln(os.Stderr, migrateUsage)
+			return lifecycle.ExitServerError
+		}
+		paths, err := migrations.Create(migrations.SQLDir, args[1])
+		if err != nil {
+			logger.Errorf("migrations Create() error: %s", err)
+			return lifecycle.ExitServerError
+		}
+		for _, path := range paths {
+			fmt.Println(path)
+		}
+		return lifecycle.ExitOK
+	}
+
+	if code, ok := setup(args); !ok {
+		return code
+	}
+	command := config.Args()
+	if len(command) == 0 {
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		return lifecycle.ExitServerError
+	}
+
+	ctx := context.Background()
+	db, err := database.New(ctx, config.Get().Database, database.Options{})
+	if err != nil {
+		logger.Errorf("database New() error: %s", err)
+		return lifecycle.ExitServerError
+	}
+	defer db.Close()
+
+	if err := migrate(ctx, db, command, os.Stdout); err != nil {
+		logger.Errorf("migrate %s error: %s", command[0], err)
+		return lifecycle.ExitServerError
+	}
+	return lifecycle.ExitOK
+}
+
+// migrate runs one migrate command, writing the status to out.
+func migrate(ctx context.Context, db *database.Database, command []string, out io.Writer) error {
+	switch {
+	case command[0] == "up" && len(command) == 1:
+		return migrations.Up(ctx, db)
+	case command[0] == "down" && len(command) == 2:
+		n, err := strconv.Atoi(command[1])
+		if err != nil || n < 1 {
+			return fmt.Errorf("invalid number of migrations %q", command[1])
+		}
+		return migrations.Down(ctx, db, n)
+	case command[0] == "to" && len(command) == 2:
+		return migrations.To(ctx, db, command[1])
+	case command[0] == "status" && len(command) == 1:
+		statuses, err := migrations.Statuses(ctx, db)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tSTATUS")
+		for _, status := range statuses {
+			state := "pending"
+			switch {
+			case status.Unknown:
+				state = "applied, unknown"
+			case status.Applied:
+				state = "applied"
+			}
+			fmt.Fprintf(w, "%s\t%s\n", status.ID, state)
+		}
+		return w.Flush()
+	}
+	return fmt.Errorf("unknown command\n%s", migrateUsage)
+}
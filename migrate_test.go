@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/adapters/database/dbtest"
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/adapters/database/migrations"
+)
+
+func TestMigrate(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.Empty(t)
+	statuses, err := migrations.Statuses(ctx, db)
+	if err != nil {
+		t.Fatalf("Statuses() error: %v", err)
+	}
+	first := statuses[0].ID
+
+	status := func() string {
+		t.Helper()
+		var out bytes.Buffer
+		if err := migrate(ctx, db, []string{"status"}, &out); err != nil {
+			t.Fatalf("migrate status error: %v", err)
+		}
+		return out.String()
+	}
+
+	if err := migrate(ctx, db, []string{"to", first}, nil); err != nil {
+		t.Fatalf("migrate to error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(status()), "\n")
+	if len(lines) != len(statuses)+1 || strings.Fields(lines[0])[0] != "ID" {
+		t.Fatalf("status =\n%s\nwant a header and a line per migration", strings.Join(lines, "\n"))
+	}
+	if got := strings.Fields(lines[1]); got[0] != first || got[1] != "applied" {
+		t.Errorf("status of %s = %q, want applied", first, lines[1])
+	}
+	if got := strings.Fields(lines[2]); got[1] != "pending" {
+		t.Errorf("status of %s = %q, want pending", got[0], lines[2])
+	}
+
+	if err := migrate(ctx, db, []string{"up"}, nil); err != nil {
+		t.Fatalf("migrate up error: %v", err)
+	}
+	if out := status(); strings.Contains(out, "pending") {
+		t.Errorf("status after up =\n%s", out)
+	}
+	if err := db.WithContext(ctx).Exec("INSERT INTO migrations (id) VALUES (?)", "29991231000000_from_the_future").Error; err != nil {
+		t.Fatalf("INSERT error: %v", err)
+	}
+	lines = strings.Split(strings.TrimSpace(status()), "\n")
+	if got := strings.Join(strings.Fields(lines[len(lines)-1]), " "); got != "29991231000000_from_the_future applied, unknown" {
+		t.Errorf("status of the unknown migration = %q", got)
+	}
+
+	if err := migrate(ctx, db, []string{"down", "1"}, nil); err != nil {
+		t.Fatalf("migrate down error: %v", err)
+	}
+
+	for _, command := range [][]string{{"down", "0"}, {"down", "one"}, {"up", "now"}, {"sideways"}} {
+		if err := migrate(ctx, db, command, nil); err == nil {
+			t.Errorf("migrate %q returned no error", command)
+		}
+	}
+}
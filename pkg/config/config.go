@@ -1,12 +1,81 @@
 package config
 
+import (
+	"strings"
+	"sync/atomic"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/flags"
+)
+
+// Configuration is the effective configuration of the service. Every leaf is
+// read, in increasing order of precedence, from its `default` tag, the
+// configuration file, its `env` variable and the command line flag named
+// after its dotted `yaml` path, e.g. --server.port. Leaves tagged
+// `reload:"true"` are applied by Reload, the others require a restart.
 type Configuration struct {
-	Server   ServerConfiguration
-	Database DatabaseConfiguration
+	App      AppConfiguration      `yaml:"app"`
+	Server   ServerConfiguration   `yaml:"server"`
+	Database DatabaseConfiguration `yaml:"database"`
+	Logging  LoggingConfiguration  `yaml:"logging"`
+	Tracing  TracingConfiguration  `yaml:"tracing"`
+	Health   HealthConfiguration   `yaml:"health"`
+	Search   SearchConfiguration   `yaml:"search"`
+	Tags     TagsConfiguration     `yaml:"tags"`
+	// Flags are the feature flags keyed by name, written as JSON in the
+	// FEATURE_FLAGS variable.
+	Flags map[string]flags.Flag `yaml:"flags" env:"FEATURE_FLAGS" reload:"true"`
+}
+
+// AppConfiguration describes the deployment the service runs in.
+type AppConfiguration struct {
+	Env string `yaml:"env" env:"APP_ENV" default:"development" validate:"required"`
+}
+
+// ValidationError lists every problem found while loading the configuration.
+type ValidationError struct {
+	Problems []string
 }
 
-// SetupConfig configuration
-func SetupConfig() error {
+func (e *ValidationError) Error() string {
+	return "invalid configuration:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+var (
+	current atomic.Value // *Configuration
+	// loadArgs and loadedFile are kept to reload the same sources
+	loadArgs   []string
+	loadedFile string
+	// positional holds the arguments following the flags
+	positional []string
+)
 
+// SetupConfig loads the configuration from the file given by --config or
+// CONFIG_FILE, the environment and args, and makes it available through Get.
+func SetupConfig(args []string) error {
+	cfg, src, err := load(args)
+	if err != nil {
+		return err
+	}
+	reloadMu.Lock()
+	loadArgs, loadedFile, positional = args, src.file, src.args
+	reloadMu.Unlock()
+	current.Store(cfg)
 	return nil
 }
+
+// Args returns the arguments following the flags given to SetupConfig, e.g.
+// the command of a subcommand.
+func Args() []string {
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+	return positional
+}
+
+// Get returns the configuration loaded by SetupConfig, or the defaults when
+// it has not been called.
+func Get() *Configuration {
+	if cfg, ok := current.Load().(*Configuration); ok {
+		return cfg
+	}
+	return Default()
+}
@@ -2,42 +2,171 @@ package config
 
 import (
 	"fmt"
-	"os"
+	"net"
+	"net/url"
+	"time"
 )
 
 type DatabaseConfiguration struct {
-	Driver   string
-	Dbname   string
-	Username string
-	Password string
-	Host     string
-	Port     string
-	LogMode  bool
-}
-
-func DbConfiguration() (string, string) {
-	masterDBName := os.Getenv("MASTER_DB_NAME")
-	masterDBUser := os.Getenv("MASTER_DB_USER")
-	masterDBPassword := os.Getenv("MASTER_DB_PASSWORD")
-	masterDBHost := os.Getenv("MASTER_DB_HOST")
-	masterDBPort := os.Getenv("MASTER_DB_PORT")
-	masterDBSslMode := os.Getenv("MASTER_SSL_MODE")
-
-	replicaDBName := os.Getenv("REPLICA_DB_NAME")
-	replicaDBUser := os.Getenv("REPLICA_DB_USER")
-	replicaDBPassword := os.Getenv("REPLICA_DB_PASSWORD")
-	replicaDBHost := os.Getenv("REPLICA_DB_HOST")
-	replicaDBPort := os.Getenv("REPLICA_DB_PORT")
-	replicaDBSslMode := os.Getenv("REPLICA_SSL_MODE")
-
-	masterDBDSN := fmt.Sprintf(
-		"host=%s user=%s password=%s dbname=%s port=%s sslmode=%s",
-		masterDBHost, masterDBUser, masterDBPassword, masterDBName, masterDBPort, masterDBSslMode,
-	)
+	// Driver selects the database server. With sqlite master.name is the
+	// path of the database file and the other connection settings are unused.
+	Driver string `yaml:"driver" env:"DB_DRIVER" default:"postgres" validate:"oneof=postgres mysql sqlite"`
+	// LogMode logs every query, slow queries are logged regardless.
+	LogMode bool `yaml:"log_mode" env:"DB_LOG_MODE"`
+	// AutoMigrate applies the pending migrations at startup, otherwise they
+	// are applied with the migrate command.
+	AutoMigrate bool `yaml:"auto_migrate" env:"DB_AUTO_MIGRATE" default:"true"`
+	// SeedEnv names the fixtures upserted at startup, e.g. dev, none when
+	// empty. See the seed command.
+	SeedEnv string                  `yaml:"seed_env" env:"DB_SEED_ENV"`
+	Master  ConnectionConfiguration `yaml:"master" env:"MASTER_"`
+	// Replica holds the settings shared by every replica.
+	Replica ConnectionConfiguration `yaml:"replica" env:"REPLICA_"`
+	// ReplicaHosts lists the replicas as host or host:port, overriding the
+	// host and port of Replica. Replica.Host alone declares a single replica.
+	ReplicaHosts []string                 `yaml:"replica_hosts" env:"REPLICA_DB_HOSTS"`
+	Replication  ReplicationConfiguration `yaml:"replication" env:"DB_REPLICA_"`
+	Transaction  TransactionConfiguration `yaml:"transaction" env:"DB_TX_"`
+	SlowQuery    SlowQueryConfiguration   `yaml:"slow_query" env:"DB_SLOW_QUERY_"`
+}
+
+// SlowQueryConfiguration controls the logging of slow queries.
+type SlowQueryConfiguration struct {
+	// Threshold logs and counts the queries running longer, zero disables it.
+	Threshold time.Duration `yaml:"threshold" env:"THRESHOLD" default:"200ms" validate:"gte=0"`
+	// Explain logs the plan of the slow queries asking for it, such as
+	// GetTags. Plans show the parameters, they are never captured in
+	// production.
+	Explain bool `yaml:"explain" env:"EXPLAIN"`
+}
+
+// TransactionConfiguration controls the transactions started by WithTx.
+type TransactionConfiguration struct {
+	Isolation string `yaml:"isolation" env:"ISOLATION" default:"read_committed" validate:"oneof=read_committed repeatable_read serializable"`
+	// MaxAttempts bounds the runs of a transaction failing with a
+	// serialization failure or a deadlock.
+	MaxAttempts int `yaml:"max_attempts" env:"MAX_ATTEMPTS" default:"3" validate:"gte=1"`
+	// RetryBackoff is the delay before the first retry, doubled after each
+	// one up to MaxRetryBackoff. The delays are jittered so the transactions
+	// conflicting together do not retry together.
+	RetryBackoff    time.Duration `yaml:"retry_backoff" env:"RETRY_BACKOFF" default:"20ms" validate:"gte=0"`
+	MaxRetryBackoff time.Duration `yaml:"max_retry_backoff" env:"MAX_RETRY_BACKOFF" default:"1s" validate:"gte=0"`
+}
+
+// ReplicationConfiguration controls how reads are routed to the replicas.
+type ReplicationConfiguration struct {
+	// MaxLag ejects a replica replaying the primary further behind.
+	MaxLag time.Duration `yaml:"max_lag" env:"MAX_LAG" default:"10s" validate:"gt=0"`
+	// CheckInterval is the delay between two replica health checks.
+	CheckInterval time.Duration `yaml:"check_interval" env:"CHECK_INTERVAL" default:"5s" validate:"gt=0"`
+	// ReadYourWritesWindow is the time a session reads from the primary after
+	// a write, zero disables it.
+	ReadYourWritesWindow time.Duration `yaml:"read_your_writes_window" env:"READ_YOUR_WRITES_WINDOW" default:"5s" validate:"gte=0"`
+}
+
+// ConnectionConfiguration describes how to reach one database server.
+type ConnectionConfiguration struct {
+	Dbname   string `yaml:"name" env:"DB_NAME"`
+	Username string `yaml:"user" env:"DB_USER"`
+	Password string `yaml:"password" env:"DB_PASSWORD" secret:"true"`
+	Host     string `yaml:"host" env:"DB_HOST"`
+	Port     string `yaml:"port" env:"DB_PORT" default:"5432" validate:"numeric"`
+	SSLMode  string `yaml:"ssl_mode" env:"SSL_MODE" default:"disable" validate:"oneof=disable allow prefer require verify-ca verify-full"`
+	// StatementTimeout aborts any statement running longer, zero disables it.
+	StatementTimeout time.Duration             `yaml:"statement_timeout" env:"DB_STATEMENT_TIMEOUT" default:"30s" validate:"gte=0"`
+	Pool             PoolConfiguration         `yaml:"pool" env:"DB_"`
+	ConnectRetry     ConnectRetryConfiguration `yaml:"connect_retry" env:"DB_CONNECT_"`
+}
+
+// PoolConfiguration sizes the connection pool, zero values mean unlimited.
+type PoolConfiguration struct {
+	MaxOpenConns    int           `yaml:"max_open_conns" env:"MAX_OPEN_CONNS" default:"25" validate:"gte=0"`
+	MaxIdleConns    int           `yaml:"max_idle_conns" env:"MAX_IDLE_CONNS" default:"10" validate:"gte=0"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime" env:"CONN_MAX_LIFETIME" default:"30m" validate:"gte=0"`
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time" env:"CONN_MAX_IDLE_TIME" default:"5m" validate:"gte=0"`
+}
+
+// ConnectRetryConfiguration controls the attempts to reach the database at
+// startup. The delay doubles after each failure up to MaxBackoff.
+type ConnectRetryConfiguration struct {
+	Attempts   int           `yaml:"attempts" env:"ATTEMPTS" default:"5" validate:"gte=1"`
+	Backoff    time.Duration `yaml:"backoff" env:"BACKOFF" default:"1s" validate:"gt=0"`
+	MaxBackoff time.Duration `yaml:"max_backoff" env:"MAX_BACKOFF" default:"30s" validate:"gt=0"`
+}
 
-	replicaDBDSN := fmt.Sprintf(
-		"host=%s user=%s password=%s dbname=%s port=%s sslmode=%s",
-		replicaDBHost, replicaDBUser, replicaDBPassword, replicaDBName, replicaDBPort, replicaDBSslMode,
+// DSN returns the connection string of the database for driver. Sessions
+// use UTC so timestamps are read back in UTC whatever the server timezone.
+func (c ConnectionConfiguration) DSN(driver string) string {
+	switch driver {
+	case "mysql":
+		return c.mysqlDSN()
+	case "sqlite":
+		return c.sqliteDSN()
+	}
+
+	dsn := fmt.Sprintf(
+		"host=%s user=%s password=%s dbname=%s port=%s sslmode=%s TimeZone=UTC",
+		c.Host, c.Username, c.Password, c.Dbname, c.Port, c.SSLMode,
 	)
-	return masterDBDSN, replicaDBDSN
+	if c.StatementTimeout > 0 {
+		dsn += fmt.Sprintf(" statement_timeout=%d", c.StatementTimeout.Milliseconds())
+	}
+	return dsn
+}
+
+// mysqlDSN returns the DSN of go-sql-driver/mysql. Multiple statements are
+// allowed for the SQL migrations.
+func (c ConnectionConfiguration) mysqlDSN() string {
+	params := url.Values{}
+	params.Set("parseTime", "true")
+	params.Set("loc", "UTC")
+	params.Set("time_zone", "'+00:00'")
+	params.Set("multiStatements", "true")
+	switch c.SSLMode {
+	case "allow", "prefer":
+		params.Set("tls", "preferred")
+	case "require", "verify-ca":
+		params.Set("tls", "skip-verify")
+	case "verify-full":
+		params.Set("tls", "true")
+	}
+	if c.StatementTimeout > 0 {
+		// only bounds the SELECT statements
+		params.Set("max_execution_time", fmt.Sprint(c.StatementTimeout.Milliseconds()))
+	}
+	return fmt.Sprintf("%s:%s@tcp(%s)/%s?%s",
+		c.Username, c.Password, net.JoinHostPort(c.Host, c.Port), c.Dbname, params.Encode())
+}
+
+// sqliteDSN returns the DSN of the database file. Writers wait for the lock
+// instead of failing, and transactions take the write lock when they begin
+// so they never deadlock upgrading it.
+func (c ConnectionConfiguration) sqliteDSN() string {
+	params := url.Values{}
+	params.Add("_pragma", "busy_timeout(5000)")
+	params.Add("_pragma", "foreign_keys(1)")
+	params.Add("_pragma", "journal_mode(WAL)")
+	params.Set("_txlock", "immediate")
+	return c.Dbname + "?" + params.Encode()
+}
+
+// Replicas returns the connection of every configured replica.
+func (c DatabaseConfiguration) Replicas() []ConnectionConfiguration {
+	if len(c.ReplicaHosts) == 0 {
+		if c.Replica.Host == "" {
+			return nil
+		}
+		return []ConnectionConfiguration{c.Replica}
+	}
+
+	replicas := make([]ConnectionConfiguration, 0, len(c.ReplicaHosts))
+	for _, address := range c.ReplicaHosts {
+		replica := c.Replica
+		replica.Host = address
+		if host, port, err := net.SplitHostPort(address); err == nil {
+			replica.Host, replica.Port = host, port
+		}
+		replicas = append(replicas, replica)
+	}
+	return replicas
 }
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDSN(t *testing.T) {
+	conn := ConnectionConfiguration{
+		Host: "db", Port: "5432", Username: "app", Password: "s3cr3t", Dbname: "tags",
+		SSLMode: "disable", StatementTimeout: 1500 * time.Millisecond,
+	}
+
+	t.Run("postgres", func(t *testing.T) {
+		dsn := conn.DSN("postgres")
+		for _, want := range []string{"host=db", "port=5432", "TimeZone=UTC", "statement_timeout=1500"} {
+			if !strings.Contains(dsn, want) {
+				t.Errorf("DSN %q, missing %q", dsn, want)
+			}
+		}
+
+		noTimeout := conn
+		noTimeout.StatementTimeout = 0
+		if dsn := noTimeout.DSN("postgres"); strings.Contains(dsn, "statement_timeout") {
+			t.Errorf("DSN %q without a timeout sets statement_timeout", dsn)
+		}
+	})
+
+	t.Run("mysql", func(t *testing.T) {
+		dsn := conn.DSN("mysql")
+		prefix := "app:s3cr3t@tcp(db:5432)/tags?"
+		if !strings.HasPrefix(dsn, prefix) {
+			t.Fatalf("DSN %q, want the prefix %q", dsn, prefix)
+		}
+		params, err := url.ParseQuery(strings.TrimPrefix(dsn, prefix))
+		if err != nil {
+			t.Fatalf("ParseQuery() error: %v", err)
+		}
+		if got := params.Get("max_execution_time"); got != "1500" {
+			t.Errorf("max_execution_time = %q, want 1500", got)
+		}
+		if params.Get("loc") != "UTC" || params.Get("parseTime") != "true" {
+			t.Errorf("params = %v, want UTC times", params)
+		}
+
+		noTimeout := conn
+		noTimeout.StatementTimeout = 0
+		if dsn := noTimeout.DSN("mysql"); strings.Contains(dsn, "max_execution_time") {
+			t.Errorf("DSN %q without a timeout sets max_execution_time", dsn)
+		}
+	})
+
+	t.Run("sqlite", func(t *testing.T) {
+		// statements of an embedded database are not bounded
+		if dsn := conn.DSN("sqlite"); !strings.HasPrefix(dsn, "tags?") || strings.Contains(dsn, "1500") {
+			t.Errorf("DSN %q", dsn)
+		}
+	})
+}
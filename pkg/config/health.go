@@ -0,0 +1,19 @@
+package config
+
+import (
+	"time"
+)
+
+type HealthConfiguration struct {
+	// CheckTimeout bounds a readiness evaluation.
+	CheckTimeout time.Duration `yaml:"check_timeout" env:"HEALTH_CHECK_TIMEOUT" default:"3s" validate:"gt=0"`
+	// CheckInterval is the refresh interval of the gRPC health status.
+	CheckInterval time.Duration `yaml:"check_interval" env:"HEALTH_CHECK_INTERVAL" default:"10s" validate:"gt=0"`
+}
+
+// HealthCheckConfig returns the timeout of a readiness evaluation and the
+// interval at which the gRPC health status is refreshed.
+func HealthCheckConfig() (time.Duration, time.Duration) {
+	health := Get().Health
+	return health.CheckTimeout, health.CheckInterval
+}
@@ -0,0 +1,438 @@
+package config
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/constants"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/flags"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/logger"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFileEnv names the environment variable holding the configuration
+// file path when --config is not given.
+const ConfigFileEnv = "CONFIG_FILE"
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// field describes a configuration leaf and where it is read from.
+type field struct {
+	key    string // dotted path used in files and flags, e.g. server.port
+	env    string
+	def    string
+	sep    string // separator of list values in env variables and flags
+	secret bool
+	reload bool // applied by Reload without a restart
+	index  []int
+}
+
+func (f field) usage() string {
+	if f.env == "" {
+		return f.key
+	}
+	return fmt.Sprintf("%s (env %s)", f.key, f.env)
+}
+
+// fields lists the leaves of Configuration in declaration order.
+func fields() []field {
+	var out []field
+	collectFields(reflect.TypeOf(Configuration{}), "", "", nil, &out)
+	return out
+}
+
+func collectFields(t reflect.Type, keyPrefix, envPrefix string, index []int, out *[]field) {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		name := sf.Tag.Get("yaml")
+		if name == "" || name == "-" {
+			continue
+		}
+		key := name
+		if keyPrefix != "" {
+			key = keyPrefix + "." + name
+		}
+		idx := append(append([]int{}, index...), i)
+		env := sf.Tag.Get("env")
+
+		// nested sections prefix the env names of their leaves
+		if sf.Type.Kind() == reflect.Struct && sf.Type != durationType {
+			collectFields(sf.Type, key, envPrefix+env, idx, out)
+			continue
+		}
+
+		f := field{
+			key:    key,
+			def:    sf.Tag.Get("default"),
+			sep:    ",",
+			secret: sf.Tag.Get("secret") == "true",
+			reload: sf.Tag.Get("reload") == "true",
+			index:  idx,
+		}
+		if env != "" {
+			f.env = envPrefix + env
+		}
+		if sep, ok := sf.Tag.Lookup("sep"); ok {
+			f.sep = sep
+		}
+		*out = append(*out, f)
+	}
+}
+
+// Default returns the configuration made of the `default` tags only.
+func Default() *Configuration {
+	cfg := &Configuration{}
+	v := reflect.ValueOf(cfg).Elem()
+	for _, f := range fields() {
+		if f.def == "" {
+			continue
+		}
+		if err := setString(v.FieldByIndex(f.index), f, f.def); err != nil {
+			panic(fmt.Sprintf("config: invalid default for %s: %v", f.key, err))
+		}
+	}
+	return cfg
+}
+
+// Load builds the configuration from the defaults, the configuration file,
+// the environment and the command line flags in args, in that order of
+// precedence. Empty environment variables are ignored. Every problem is
+// reported at once in a *ValidationError.
+func Load(args []string) (*Configuration, error) {
+	cfg, _, err := load(args)
+	return cfg, err
+}
+
+// source describes where a configuration was loaded from.
+type source struct {
+	// file is the path of the configuration file, empty when none is used.
+	file string
+	// args are the arguments left after the flags.
+	args []string
+}
+
+// load is Load also returning the sources of the configuration.
+func load(args []string) (*Configuration, source, error) {
+	leaves := fields()
+	byKey := make(map[string]field, len(leaves))
+
+	fs := flag.NewFlagSet(constants.ServiceName, flag.ContinueOnError)
+	configFile := fs.String("config", os.Getenv(ConfigFileEnv), "path to a YAML or TOML configuration file (env "+ConfigFileEnv+")")
+	for _, f := range leaves {
+		byKey[f.key] = f
+		fs.String(f.key, f.def, f.usage())
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, source{}, err
+	}
+	src := source{file: *configFile, args: fs.Args()}
+
+	cfg := Default()
+	v := reflect.ValueOf(cfg).Elem()
+	var problems []string
+
+	if *configFile != "" {
+		problems = append(problems, loadFile(v, byKey, *configFile)...)
+	}
+
+	for _, f := range leaves {
+		if f.env == "" {
+			continue
+		}
+		if value := os.Getenv(f.env); value != "" {
+			if err := setString(v.FieldByIndex(f.index), f, value); err != nil {
+				problems = append(problems, fmt.Sprintf("%s: invalid value in %s: %v", f.key, f.env, err))
+			}
+		}
+	}
+
+	fs.Visit(func(fl *flag.Flag) {
+		f, ok := byKey[fl.Name]
+		if !ok {
+			return
+		}
+		if err := setString(v.FieldByIndex(f.index), f, fl.Value.String()); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: invalid value in --%s: %v", f.key, f.key, err))
+		}
+	})
+
+	problems = append(problems, cfg.validate()...)
+	if len(problems) > 0 {
+		return nil, src, &ValidationError{Problems: problems}
+	}
+	return cfg, src, nil
+}
+
+// loadFile applies the values of a YAML or TOML file, chosen by extension.
+func loadFile(v reflect.Value, byKey map[string]field, path string) []string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return []string{fmt.Sprintf("failed to read config file: %v", err)}
+	}
+
+	raw := map[string]interface{}{}
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &raw)
+	case ".toml":
+		err = toml.Unmarshal(data, &raw)
+	default:
+		return []string{fmt.Sprintf("unsupported config file extension %q, expected .yaml, .yml or .toml", ext)}
+	}
+	if err != nil {
+		return []string{fmt.Sprintf("failed to parse config file %s: %v", path, err)}
+	}
+
+	values := map[string]interface{}{}
+	flatten("", raw, byKey, values)
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var problems []string
+	for _, key := range keys {
+		f, ok := byKey[key]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s: unknown key in %s", key, path))
+			continue
+		}
+		if err := setFileValue(v.FieldByIndex(f.index), f, values[key]); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: invalid value in %s: %v", key, path, err))
+		}
+	}
+	return problems
+}
+
+// flatten turns nested sections into dotted keys. Values of map leaves are
+// kept whole.
+func flatten(prefix string, m map[string]interface{}, byKey map[string]field, out map[string]interface{}) {
+	for k, value := range m {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		if _, leaf := byKey[key]; !leaf {
+			if nested, ok := value.(map[string]interface{}); ok {
+				flatten(key, nested, byKey, out)
+				continue
+			}
+		}
+		out[key] = value
+	}
+}
+
+// setFileValue sets a leaf from a decoded file value, keeping list items intact.
+func setFileValue(v reflect.Value, f field, raw interface{}) error {
+	switch value := raw.(type) {
+	case nil:
+		return setString(v, f, "")
+	case map[string]interface{}:
+		// structured leaves are decoded through their JSON tags
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		return setString(v, f, string(data))
+	case []interface{}:
+		if v.Kind() != reflect.Slice {
+			return fmt.Errorf("unexpected list")
+		}
+		items := make([]string, len(value))
+		for i, item := range value {
+			items[i] = fmt.Sprint(item)
+		}
+		v.Set(reflect.ValueOf(items))
+		return nil
+	default:
+		return setString(v, f, fmt.Sprint(value))
+	}
+}
+
+// setString parses s into the leaf according to its type.
+func setString(v reflect.Value, f field, s string) error {
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	case reflect.Float64:
+		x, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return err
+		}
+		v.SetFloat(x)
+	case reflect.Map:
+		// maps are written as JSON in env variables and flags
+		m := reflect.New(v.Type())
+		if s != "" {
+			if err := json.Unmarshal([]byte(s), m.Interface()); err != nil {
+				return err
+			}
+		}
+		v.Set(m.Elem())
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported list type %s", v.Type())
+		}
+		var items []string
+		if s != "" {
+			items = strings.Split(s, f.sep)
+		}
+		v.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+// validate checks the `validate` tags and the rules spanning several fields.
+func (c *Configuration) validate() []string {
+	var problems []string
+
+	validate := validator.New()
+	validate.RegisterTagNameFunc(func(sf reflect.StructField) string {
+		return sf.Tag.Get("yaml")
+	})
+	if err := validate.Struct(c); err != nil {
+		if errs, ok := err.(validator.ValidationErrors); ok {
+			for _, fe := range errs {
+				problems = append(problems, describe(fe))
+			}
+		} else {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	if _, err := logrus.ParseLevel(c.Logging.Level); err != nil {
+		problems = append(problems, fmt.Sprintf("logging.level: unknown level %q", c.Logging.Level))
+	}
+	for _, pattern := range c.Logging.Redact.Patterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			problems = append(problems, fmt.Sprintf("logging.redact.patterns: %v", err))
+		}
+	}
+	for _, problem := range flags.Validate(c.Flags) {
+		problems = append(problems, "flags."+problem)
+	}
+	if _, err := time.LoadLocation(c.Server.Timezone); err != nil {
+		problems = append(problems, fmt.Sprintf("server.timezone: unknown timezone %q", c.Server.Timezone))
+	}
+	if pool := c.Database.Master.Pool; pool.MaxOpenConns > 0 && pool.MaxIdleConns > pool.MaxOpenConns {
+		problems = append(problems, "database.master.pool.max_idle_conns: must be at most max_open_conns")
+	}
+	if pool := c.Database.Replica.Pool; pool.MaxOpenConns > 0 && pool.MaxIdleConns > pool.MaxOpenConns {
+		problems = append(problems, "database.replica.pool.max_idle_conns: must be at most max_open_conns")
+	}
+	master := c.Database.Master
+	if master.Dbname == "" {
+		problems = append(problems, "database.master.name: is required")
+	}
+	if c.Database.Driver == "sqlite" {
+		// a file database has no server to replicate from
+		if len(c.Database.Replicas()) > 0 {
+			problems = append(problems, "database.replica: is not supported by the sqlite driver")
+		}
+	} else {
+		if master.Host == "" {
+			problems = append(problems, "database.master.host: is required")
+		}
+		if master.Username == "" {
+			problems = append(problems, "database.master.user: is required")
+		}
+	}
+	for i, host := range c.Database.ReplicaHosts {
+		if strings.TrimSpace(host) == "" {
+			problems = append(problems, fmt.Sprintf("database.replica_hosts[%d]: must not be empty", i))
+		}
+	}
+	return problems
+}
+
+// describe formats a validator error using the configuration key.
+func describe(fe validator.FieldError) string {
+	key := fe.Namespace()
+	if i := strings.Index(key, "."); i >= 0 {
+		key = key[i+1:]
+	}
+
+	switch fe.Tag() {
+	case "required":
+		return key + ": is required"
+	case "numeric":
+		return key + ": must be numeric"
+	case "oneof":
+		return fmt.Sprintf("%s: must be one of %s", key, strings.ReplaceAll(fe.Param(), " ", ", "))
+	case "min", "gte":
+		return fmt.Sprintf("%s: must be at least %s", key, fe.Param())
+	case "max", "lte":
+		return fmt.Sprintf("%s: must be at most %s", key, fe.Param())
+	case "gt":
+		return fmt.Sprintf("%s: must be greater than %s", key, fe.Param())
+	default:
+		return fmt.Sprintf("%s: failed %q validation", key, fe.Tag())
+	}
+}
+
+// Redacted returns the configuration keyed like the configuration file with
+// secrets masked, for the /debug/config endpoint.
+func (c *Configuration) Redacted() map[string]interface{} {
+	out := map[string]interface{}{}
+	v := reflect.ValueOf(c).Elem()
+	for _, f := range fields() {
+		value := v.FieldByIndex(f.index)
+		dumped := value.Interface()
+		switch {
+		case f.secret && !value.IsZero():
+			dumped = logger.RedactedValue
+		case value.Type() == durationType:
+			dumped = time.Duration(value.Int()).String()
+		}
+
+		section := out
+		parts := strings.Split(f.key, ".")
+		for _, part := range parts[:len(parts)-1] {
+			next, ok := section[part].(map[string]interface{})
+			if !ok {
+				next = map[string]interface{}{}
+				section[part] = next
+			}
+			section = next
+		}
+		section[parts[len(parts)-1]] = dumped
+	}
+	return out
+}
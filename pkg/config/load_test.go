@@ -0,0 +1,308 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/logger"
+)
+
+// sqliteArgs are the flags passing validation without a database server.
+var sqliteArgs = []string{"--database.driver=sqlite", "--database.master.name=test.db"}
+
+// clearEnv unsets the variables of every leaf for the test, so the
+// environment running the tests does not leak into them.
+func clearEnv(t *testing.T) {
+	t.Helper()
+	t.Setenv(ConfigFileEnv, "")
+	for _, f := range fields() {
+		if f.env != "" {
+			t.Setenv(f.env, "")
+		}
+	}
+}
+
+// writeFile writes a configuration file in a temporary directory and
+// returns its path.
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("WriteFile() error: %v", err)
+	}
+	return path
+}
+
+func mustLoad(t *testing.T, args ...string) *Configuration {
+	t.Helper()
+	cfg, err := Load(append(append([]string{}, sqliteArgs...), args...))
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	return cfg
+}
+
+func fieldByKey(t *testing.T, key string) field {
+	t.Helper()
+	for _, f := range fields() {
+		if f.key == key {
+			return f
+		}
+	}
+	t.Fatalf("no field %s", key)
+	return field{}
+}
+
+func TestFieldTags(t *testing.T) {
+	tests := []struct {
+		key  string
+		want field
+	}{
+		{"server.port", field{env: "SERVER_PORT", def: "8000", sep: ","}},
+		// the env names of nested sections are prefixed
+		{"database.master.password", field{env: "MASTER_DB_PASSWORD", sep: ",", secret: true}},
+		{"database.replica.pool.max_open_conns", field{env: "REPLICA_DB_MAX_OPEN_CONNS", def: "25", sep: ","}},
+		{"logging.level", field{env: "LOG_LEVEL", def: "info", sep: ",", reload: true}},
+		{"logging.redact.patterns", field{env: "LOG_REDACT_PATTERNS", sep: "\n", reload: true}},
+		{"server.limit_count_per_request", field{sep: ","}},
+	}
+	for _, tt := range tests {
+		got := fieldByKey(t, tt.key)
+		tt.want.key, tt.want.index = tt.key, got.index
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("field %s = %+v, want %+v", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestDefault(t *testing.T) {
+	cfg := Default()
+	if cfg.Server.Port != "8000" {
+		t.Errorf("server.port = %q, want 8000", cfg.Server.Port)
+	}
+	if cfg.Database.Transaction.RetryBackoff != 20*time.Millisecond {
+		t.Errorf("database.transaction.retry_backoff = %s, want 20ms", cfg.Database.Transaction.RetryBackoff)
+	}
+	if cfg.Logging.AccessLog.SampleRate != 1 {
+		t.Errorf("logging.access_log.sample_rate = %v, want 1", cfg.Logging.AccessLog.SampleRate)
+	}
+	if want := []string{"/api/v1/health", "/livez", "/readyz", "/grpc.health.v1.Health/Check"}; !reflect.DeepEqual(cfg.Logging.AccessLog.SkipPaths, want) {
+		t.Errorf("logging.access_log.skip_paths = %q, want %q", cfg.Logging.AccessLog.SkipPaths, want)
+	}
+	if cfg.Database.Master.Password != "" {
+		t.Errorf("database.master.password = %q, want none", cfg.Database.Master.Password)
+	}
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	clearEnv(t)
+	file := writeFile(t, "config.yaml", `
+server:
+  port: "8001"
+  grpc_port: "50052"
+  host: file.example.com
+logging:
+  level: warn
+`)
+
+	t.Run("file", func(t *testing.T) {
+		cfg := mustLoad(t, "--config="+file)
+		if cfg.Server.Port != "8001" || cfg.Server.GRPCPort != "50052" || cfg.Server.Host != "file.example.com" {
+			t.Errorf("server = %+v, want the values of the file", cfg.Server)
+		}
+		if cfg.Server.Timezone != "UTC" {
+			t.Errorf("server.timezone = %q, want the default UTC", cfg.Server.Timezone)
+		}
+	})
+
+	t.Run("env over file", func(t *testing.T) {
+		t.Setenv("SERVER_PORT", "8002")
+		t.Setenv("SERVER_HOST", "")
+		cfg := mustLoad(t, "--config="+file)
+		if cfg.Server.Port != "8002" {
+			t.Errorf("server.port = %q, want 8002 from the env", cfg.Server.Port)
+		}
+		// empty variables are ignored
+		if cfg.Server.Host != "file.example.com" {
+			t.Errorf("server.host = %q, want file.example.com", cfg.Server.Host)
+		}
+	})
+
+	t.Run("flag over env", func(t *testing.T) {
+		t.Setenv("SERVER_PORT", "8002")
+		t.Setenv(ConfigFileEnv, file)
+		cfg := mustLoad(t, "--server.port=8003")
+		if cfg.Server.Port != "8003" {
+			t.Errorf("server.port = %q, want 8003 from the flag", cfg.Server.Port)
+		}
+		if cfg.Logging.Level != "warn" {
+			t.Errorf("logging.level = %q, want warn from %s", cfg.Logging.Level, ConfigFileEnv)
+		}
+	})
+}
+
+func TestLoadValues(t *testing.T) {
+	clearEnv(t)
+	file := writeFile(t, "config.toml", `
+[server]
+allowed_hosts = ["a.example.com", "b.example.com"]
+
+[logging.redact]
+patterns = ["card-\\d+"]
+`)
+	t.Setenv("LOG_REDACT_FIELDS", "session,cookie")
+	t.Setenv("FEATURE_FLAGS", `{"search": {"type": "boolean", "enabled": true}}`)
+
+	cfg := mustLoad(t, "--config="+file, "--database.transaction.retry_backoff=50ms", "migrate", "up")
+	if want := []string{"a.example.com", "b.example.com"}; !reflect.DeepEqual(cfg.Server.AllowedHosts, want) {
+		t.Errorf("server.allowed_hosts = %q, want %q", cfg.Server.AllowedHosts, want)
+	}
+	if want := []string{`card-\d+`}; !reflect.DeepEqual(cfg.Logging.Redact.Patterns, want) {
+		t.Errorf("logging.redact.patterns = %q, want %q", cfg.Logging.Redact.Patterns, want)
+	}
+	if want := []string{"session", "cookie"}; !reflect.DeepEqual(cfg.Logging.Redact.Fields, want) {
+		t.Errorf("logging.redact.fields = %q, want %q", cfg.Logging.Redact.Fields, want)
+	}
+	if !cfg.Flags["search"].Enabled {
+		t.Errorf("flags = %+v, want search enabled", cfg.Flags)
+	}
+	if cfg.Database.Transaction.RetryBackoff != 50*time.Millisecond {
+		t.Errorf("database.transaction.retry_backoff = %s, want 50ms", cfg.Database.Transaction.RetryBackoff)
+	}
+
+	_, src, err := load(append(append([]string{}, sqliteArgs...), "migrate", "up"))
+	if err != nil {
+		t.Fatalf("load() error: %v", err)
+	}
+	if want := []string{"migrate", "up"}; !reflect.DeepEqual(src.args, want) {
+		t.Errorf("args = %q, want %q", src.args, want)
+	}
+}
+
+func TestLoadValidationErrors(t *testing.T) {
+	clearEnv(t)
+	file := writeFile(t, "config.yaml", `
+server:
+  port: http
+  unknown: 1
+`)
+	t.Setenv("ACCESS_LOG_SAMPLE_RATE", "often")
+
+	_, err := Load([]string{
+		"--config=" + file,
+		"--database.driver=oracle",
+		"--logging.level=loud",
+		"--database.master.pool.max_idle_conns=30",
+		"--database.transaction.isolation=chaos",
+		"--server.timezone=Mars/Olympus",
+	})
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("Load() error = %v, want a *ValidationError", err)
+	}
+
+	// every problem is reported at once
+	want := []string{
+		"server.unknown: unknown key in " + file,
+		"logging.access_log.sample_rate: invalid value in ACCESS_LOG_SAMPLE_RATE",
+		"database.driver: must be one of postgres, mysql, sqlite",
+		"server.port: must be numeric",
+		"database.transaction.isolation: must be one of read_committed, repeatable_read, serializable",
+		`logging.level: unknown level "loud"`,
+		`server.timezone: unknown timezone "Mars/Olympus"`,
+		"database.master.pool.max_idle_conns: must be at most max_open_conns",
+		"database.master.name: is required",
+		"database.master.host: is required",
+	}
+	for _, problem := range want {
+		found := false
+		for _, got := range verr.Problems {
+			if strings.HasPrefix(got, problem) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("problems %q, missing %q", verr.Problems, problem)
+		}
+	}
+	if !strings.HasPrefix(err.Error(), "invalid configuration:\n  - ") {
+		t.Errorf("Error() = %q", err.Error())
+	}
+
+	if _, err := Load([]string{"--config=" + writeFile(t, "config.json", "{}")}); err == nil || !strings.Contains(err.Error(), "unsupported config file extension") {
+		t.Errorf("Load() of a JSON file error = %v, want an unsupported extension", err)
+	}
+	if _, err := Load([]string{"--no-such-flag"}); err == nil {
+		t.Error("Load() with an unknown flag returned no error")
+	}
+}
+
+func TestRedacted(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("MASTER_DB_PASSWORD", "hunter2")
+	cfg := mustLoad(t)
+
+	dump := cfg.Redacted()
+	master := dump["database"].(map[string]interface{})["master"].(map[string]interface{})
+	if master["password"] != logger.RedactedValue {
+		t.Errorf("database.master.password = %v, want %s", master["password"], logger.RedactedValue)
+	}
+	if master["name"] != "test.db" {
+		t.Errorf("database.master.name = %v, want test.db", master["name"])
+	}
+	// empty secrets show they are unset
+	if secret := dump["server"].(map[string]interface{})["secret"]; secret != "" {
+		t.Errorf("server.secret = %v, want empty", secret)
+	}
+	// durations are dumped like in the file
+	if timeout := master["statement_timeout"]; timeout != "30s" {
+		t.Errorf("database.master.statement_timeout = %v, want 30s", timeout)
+	}
+}
+
+func TestReload(t *testing.T) {
+	clearEnv(t)
+	file := writeFile(t, "config.yaml", "logging:\n  level: info\nserver:\n  port: \"8001\"\n")
+	previous := Get()
+	t.Cleanup(func() { current.Store(previous) })
+
+	if err := SetupConfig(append([]string{"--config=" + file}, sqliteArgs...)); err != nil {
+		t.Fatalf("SetupConfig() error: %v", err)
+	}
+	var reloaded *Configuration
+	OnReload(func(cfg *Configuration) { reloaded = cfg })
+
+	// the level is reloadable, the port requires a restart
+	if err := os.WriteFile(file, []byte("logging:\n  level: debug\nserver:\n  port: \"8002\"\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error: %v", err)
+	}
+	if err := Reload(); err != nil {
+		t.Fatalf("Reload() error: %v", err)
+	}
+	if reloaded != Get() {
+		t.Error("the listener was not called with the new configuration")
+	}
+	if got := Get().Logging.Level; got != "debug" {
+		t.Errorf("logging.level = %q, want debug", got)
+	}
+	if got := Get().Server.Port; got != "8001" {
+		t.Errorf("server.port = %q, want 8001 until a restart", got)
+	}
+
+	// an invalid configuration is rejected as a whole
+	if err := os.WriteFile(file, []byte("logging:\n  level: loud\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error: %v", err)
+	}
+	if err := Reload(); err == nil {
+		t.Error("Reload() of an invalid configuration returned no error")
+	}
+	if got := Get().Logging.Level; got != "debug" {
+		t.Errorf("logging.level = %q after a rejected reload, want debug", got)
+	}
+}
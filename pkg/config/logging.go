@@ -0,0 +1,53 @@
+package config
+
+import (
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/logger"
+
+	"github.com/sirupsen/logrus"
+)
+
+type LoggingConfiguration struct {
+	Level     string                 `yaml:"level" env:"LOG_LEVEL" default:"info" reload:"true"`
+	AccessLog AccessLogConfiguration `yaml:"access_log" env:"ACCESS_LOG_"`
+	Redact    RedactConfiguration    `yaml:"redact" env:"LOG_REDACT_"`
+}
+
+type AccessLogConfiguration struct {
+	// SampleRate is the fraction of successful requests that are logged.
+	SampleRate float64  `yaml:"sample_rate" env:"SAMPLE_RATE" default:"1" validate:"min=0,max=1" reload:"true"`
+	SkipPaths  []string `yaml:"skip_paths" env:"SKIP_PATHS" default:"/api/v1/health,/livez,/readyz,/grpc.health.v1.Health/Check" reload:"true"`
+}
+
+// RedactConfiguration extends the built-in redaction rules. Patterns are
+// separated by newlines in the environment so they may contain commas.
+type RedactConfiguration struct {
+	Fields   []string `yaml:"fields" env:"FIELDS" reload:"true"`
+	Patterns []string `yaml:"patterns" env:"PATTERNS" sep:"\n" reload:"true"`
+}
+
+// LogLevel returns the parsed log level, validated when loading.
+func (c LoggingConfiguration) LogLevel() logrus.Level {
+	level, err := logrus.ParseLevel(c.Level)
+	if err != nil {
+		return logrus.InfoLevel
+	}
+	return level
+}
+
+// AccessLogConfig returns the access log options.
+func AccessLogConfig() logger.AccessLogOptions {
+	accessLog := Get().Logging.AccessLog
+	return logger.AccessLogOptions{
+		SampleRate: accessLog.SampleRate,
+		SkipPaths:  accessLog.SkipPaths,
+	}
+}
+
+// RedactionConfig returns the log redaction rules.
+func RedactionConfig() logger.RedactionOptions {
+	redact := Get().Logging.Redact
+	return logger.RedactionOptions{
+		Fields:   redact.Fields,
+		Patterns: redact.Patterns,
+	}
+}
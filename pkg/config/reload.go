@@ -0,0 +1,136 @@
+package config
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/logger"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/metrics"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// reloadDebounce groups the events of a single file update.
+const reloadDebounce = 200 * time.Millisecond
+
+var (
+	reloadMu  sync.Mutex
+	listeners []func(cfg *Configuration)
+)
+
+// OnReload registers fn to be called with the new configuration after every
+// successful reload. Listeners apply the fields tagged `reload:"true"`.
+func OnReload(fn func(cfg *Configuration)) {
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+	listeners = append(listeners, fn)
+}
+
+// Reload loads the configuration again from the same sources as SetupConfig.
+// An invalid configuration is rejected as a whole. Changes to fields that
+// require a restart are logged and dropped, the remaining changes are swapped
+// in atomically and passed to the listeners.
+func Reload() error {
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+
+	next, _, err := load(loadArgs)
+	if err != nil {
+		metrics.ObserveConfigReload(false)
+		logger.Errorf("config reload rejected: %v", err)
+		return err
+	}
+
+	prev := Get()
+	prevValue := reflect.ValueOf(prev).Elem()
+	nextValue := reflect.ValueOf(next).Elem()
+	var applied []string
+	for _, f := range fields() {
+		oldLeaf, newLeaf := prevValue.FieldByIndex(f.index), nextValue.FieldByIndex(f.index)
+		if reflect.DeepEqual(oldLeaf.Interface(), newLeaf.Interface()) {
+			continue
+		}
+		if !f.reload {
+			logger.Warnf("config reload: %s requires a restart, change ignored", f.key)
+			newLeaf.Set(oldLeaf)
+			continue
+		}
+		applied = append(applied, f.key)
+	}
+
+	current.Store(next)
+	for _, fn := range listeners {
+		fn(next)
+	}
+	metrics.ObserveConfigReload(true)
+	logger.Infof("config reloaded, changed: %v", applied)
+	return nil
+}
+
+// Watch reloads the configuration on SIGHUP and whenever the configuration
+// file changes, until ctx is done.
+func Watch(ctx context.Context) {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGHUP)
+	defer signal.Stop(sigCh)
+
+	reloadMu.Lock()
+	file := loadedFile
+	reloadMu.Unlock()
+
+	var events <-chan fsnotify.Event
+	var errs <-chan error
+	if file != "" {
+		watcher, err := fsnotify.NewWatcher()
+		if err != nil {
+			logger.Errorf("failed to watch config file: %v", err)
+		} else {
+			defer watcher.Close()
+			// watch the directory so files replaced by editors or by
+			// Kubernetes ConfigMap updates are still seen
+			if err := watcher.Add(filepath.Dir(file)); err != nil {
+				logger.Errorf("failed to watch config file: %v", err)
+			}
+			events, errs = watcher.Events, watcher.Errors
+		}
+	}
+
+	debounce := time.NewTimer(reloadDebounce)
+	debounce.Stop()
+	defer debounce.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sigCh:
+			logger.Infof("received SIGHUP, reloading config")
+			Reload()
+		case event := <-events:
+			if isConfigEvent(event, file) {
+				debounce.Reset(reloadDebounce)
+			}
+		case err := <-errs:
+			logger.Errorf("config watcher error: %v", err)
+		case <-debounce.C:
+			logger.Infof("config file changed, reloading config")
+			Reload()
+		}
+	}
+}
+
+// isConfigEvent reports whether event may have changed the configuration file.
+func isConfigEvent(event fsnotify.Event, file string) bool {
+	if event.Op == fsnotify.Chmod {
+		return false
+	}
+	name := filepath.Clean(event.Name)
+	// Kubernetes swaps the ..data symlink when a ConfigMap changes
+	return name == filepath.Clean(file) || filepath.Base(name) == "..data"
+}
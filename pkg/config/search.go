@@ -0,0 +1,33 @@
+package config
+
+import (
+	"time"
+)
+
+// SearchConfiguration tunes SearchTags.
+type SearchConfiguration struct {
+	// Threshold is the minimum similarity, between 0 and 1, of a fuzzy match
+	// when the request does not set one. Lower values tolerate more typos.
+	Threshold float64 `yaml:"threshold" env:"SEARCH_THRESHOLD" default:"0.3" validate:"gt=0,max=1" reload:"true"`
+	// DefaultLimit is the number of matches returned when the request does
+	// not set one, MaxLimit bounds the one it sets.
+	DefaultLimit int                       `yaml:"default_limit" env:"SEARCH_DEFAULT_LIMIT" default:"20" validate:"gte=1" reload:"true"`
+	MaxLimit     int                       `yaml:"max_limit" env:"SEARCH_MAX_LIMIT" default:"100" validate:"gte=1" reload:"true"`
+	Autocomplete AutocompleteConfiguration `yaml:"autocomplete" env:"AUTOCOMPLETE_"`
+}
+
+// AutocompleteConfiguration tunes AutocompleteTags.
+type AutocompleteConfiguration struct {
+	// DefaultLimit is the number of suggestions returned when the request
+	// does not set one, MaxLimit bounds the one it sets.
+	DefaultLimit int `yaml:"default_limit" env:"DEFAULT_LIMIT" default:"10" validate:"gte=1" reload:"true"`
+	MaxLimit     int `yaml:"max_limit" env:"MAX_LIMIT" default:"50" validate:"gte=1" reload:"true"`
+	// RefreshInterval is the delay between two reloads of the index, which
+	// pick up the changes made by other instances.
+	RefreshInterval time.Duration `yaml:"refresh_interval" env:"REFRESH_INTERVAL" default:"1m" validate:"gt=0"`
+}
+
+// SearchConfig returns the search settings.
+func SearchConfig() SearchConfiguration {
+	return Get().Search
+}
@@ -2,19 +2,56 @@ package config
 
 import (
 	"fmt"
-	"os"
+	"time"
 
 	"github.com/ponyjackal/go-microservice-boilerplate/pkg/logger"
 )
 
 type ServerConfiguration struct {
-	Port                 string
-	Secret               string
-	LimitCountPerRequest int64
+	Host                 string            `yaml:"host" env:"SERVER_HOST" default:"0.0.0.0"`
+	Port                 string            `yaml:"port" env:"SERVER_PORT" default:"8000" validate:"required,numeric"`
+	GRPCPort             string            `yaml:"grpc_port" env:"GRPC_SERVER_PORT" default:"50051" validate:"required,numeric"`
+	GatewayPort          string            `yaml:"gateway_port" env:"GATEWAY_SERVER_PORT" validate:"omitempty,numeric"`
+	AdminPort            string            `yaml:"admin_port" env:"ADMIN_SERVER_PORT" validate:"omitempty,numeric"`
+	Secret               string            `yaml:"secret" env:"SECRET" secret:"true"`
+	Debug                bool              `yaml:"debug" env:"DEBUG"`
+	AllowedHosts         []string          `yaml:"allowed_hosts" env:"ALLOWED_HOSTS"`
+	CORS                 CORSConfiguration `yaml:"cors" env:"CORS_"`
+	LimitCountPerRequest int64             `yaml:"limit_count_per_request" validate:"gte=0"`
+	// Timezone is the IANA timezone used for logs and for display fields
+	// when a request has no preference. Timestamps are stored in UTC.
+	Timezone string `yaml:"timezone" env:"SERVER_TIMEZONE" default:"UTC"`
+	// ShutdownTimeout bounds the time given to servers to drain.
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT" default:"30s" validate:"gt=0"`
+	// ShutdownDrainDelay is the delay between reporting not ready and
+	// stopping the servers.
+	ShutdownDrainDelay time.Duration `yaml:"shutdown_drain_delay" env:"SHUTDOWN_DRAIN_DELAY" validate:"gte=0"`
+}
+
+type CORSConfiguration struct {
+	// AllowedOrigins lists the origins allowed to call the API, * allows any.
+	AllowedOrigins []string `yaml:"allowed_origins" env:"ALLOWED_ORIGINS" default:"*" reload:"true"`
 }
 
 func ServerConfig() string {
-	appServer := fmt.Sprintf("%s:%s", os.Getenv("SERVER_HOST"), os.Getenv("SERVER_PORT"))
+	server := Get().Server
+	appServer := fmt.Sprintf("%s:%s", server.Host, server.Port)
 	logger.Infof("Server Running at : %s", appServer)
 	return appServer
 }
+
+// TimezoneConfig returns the server timezone, validated when loading.
+func TimezoneConfig() *time.Location {
+	loc, err := time.LoadLocation(Get().Server.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// ShutdownConfig returns the time given to servers to drain, and the delay
+// between reporting not ready and stopping the servers.
+func ShutdownConfig() (time.Duration, time.Duration) {
+	server := Get().Server
+	return server.ShutdownTimeout, server.ShutdownDrainDelay
+}
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/tagname"
+)
+
+// TagsConfiguration holds the rules applied to tag names.
+type TagsConfiguration struct {
+	Normalization NormalizationConfiguration `yaml:"normalization" env:"TAG_NAME_"`
+	// MaxDepth bounds the number of levels of the hierarchy, the roots being
+	// the first.
+	MaxDepth int `yaml:"max_depth" env:"TAG_MAX_DEPTH" default:"8" validate:"gte=1" reload:"true"`
+}
+
+// NormalizationConfiguration selects the steps normalizing the names given
+// to SaveTag and UpdateTag. Slugs do not depend on it, they always ignore
+// case and whitespace.
+type NormalizationConfiguration struct {
+	Trim               bool `yaml:"trim" env:"TRIM" default:"true" reload:"true"`
+	CollapseWhitespace bool `yaml:"collapse_whitespace" env:"COLLAPSE_WHITESPACE" default:"true" reload:"true"`
+	// NFC composes the characters, e.g. "e" followed by a combining acute
+	// accent becomes "é".
+	NFC bool `yaml:"nfc" env:"NFC" default:"true" reload:"true"`
+	// CaseFold stores the names in folded case, close to lower case. Disable
+	// it to keep the case given by the clients.
+	CaseFold bool `yaml:"case_fold" env:"CASE_FOLD" default:"true" reload:"true"`
+}
+
+// TagsConfig returns the tag settings.
+func TagsConfig() TagsConfiguration {
+	return Get().Tags
+}
+
+// NormalizationPolicy returns the normalization of the tag names.
+func NormalizationPolicy() tagname.Policy {
+	normalization := Get().Tags.Normalization
+	return tagname.Policy{
+		Trim:               normalization.Trim,
+		CollapseWhitespace: normalization.CollapseWhitespace,
+		NFC:                normalization.NFC,
+		CaseFold:           normalization.CaseFold,
+	}
+}
@@ -0,0 +1,26 @@
+package config
+
+import (
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/tracing"
+)
+
+type TracingConfiguration struct {
+	ServiceName string `yaml:"service_name" env:"OTEL_SERVICE_NAME" default:"go-microservice-boilerplate" validate:"required"`
+	// Exporter is one of none, stdout or otlp.
+	Exporter     string  `yaml:"exporter" env:"TRACING_EXPORTER" default:"none" validate:"oneof=none stdout otlp"`
+	SampleRatio  float64 `yaml:"sample_ratio" env:"TRACING_SAMPLE_RATIO" default:"1" validate:"min=0,max=1"`
+	OTLPEndpoint string  `yaml:"otlp_endpoint" env:"TRACING_OTLP_ENDPOINT"`
+	OTLPInsecure bool    `yaml:"otlp_insecure" env:"TRACING_OTLP_INSECURE"`
+}
+
+// TracingConfig returns the tracing options.
+func TracingConfig() tracing.Options {
+	t := Get().Tracing
+	return tracing.Options{
+		ServiceName: t.ServiceName,
+		Exporter:    t.Exporter,
+		Endpoint:    t.OTLPEndpoint,
+		Insecure:    t.OTLPInsecure,
+		SampleRatio: t.SampleRatio,
+	}
+}
@@ -1 +1,29 @@
 package constants
+
+const (
+	// ServiceName identifies this service in traces unless overridden.
+	ServiceName = "go-microservice-boilerplate"
+
+	// RequestIDHeader is the HTTP header carrying the request ID.
+	RequestIDHeader = "X-Request-Id"
+	// RequestIDMetadataKey is the gRPC metadata key carrying the request ID.
+	RequestIDMetadataKey = "x-request-id"
+
+	// TimezoneHeader is the HTTP header carrying the IANA timezone used for
+	// display fields, e.g. Europe/Paris.
+	TimezoneHeader = "X-Timezone"
+	// TimezoneMetadataKey is the gRPC metadata key carrying the timezone.
+	TimezoneMetadataKey = "x-timezone"
+
+	// TenantIDHeader and UserIDHeader identify the caller for feature flag
+	// targeting, TenantIDMetadataKey and UserIDMetadataKey over gRPC.
+	TenantIDHeader      = "X-Tenant-Id"
+	TenantIDMetadataKey = "x-tenant-id"
+	UserIDHeader        = "X-User-Id"
+	UserIDMetadataKey   = "x-user-id"
+
+	// SessionIDHeader identifies the session reading its own writes,
+	// SessionIDMetadataKey over gRPC. The user ID is used when it is missing.
+	SessionIDHeader      = "X-Session-Id"
+	SessionIDMetadataKey = "x-session-id"
+)
@@ -0,0 +1,60 @@
+package flags
+
+import (
+	"context"
+	"strings"
+)
+
+// Attributes flags can target.
+const (
+	AttributeTenant = "tenant"
+	AttributeUser   = "user"
+	// AttributeHeaderPrefix prefixes header attributes, e.g. header:x-beta.
+	AttributeHeaderPrefix = "header:"
+)
+
+// Attributes describe the caller of a request.
+type Attributes struct {
+	Tenant string
+	User   string
+	// Headers holds the first value of each request header or gRPC
+	// metadata, keyed by lower case name.
+	Headers map[string]string
+}
+
+type attributesKey struct{}
+
+// ContextWithAttributes returns a copy of ctx carrying the attributes of the
+// caller.
+func ContextWithAttributes(ctx context.Context, attrs Attributes) context.Context {
+	return context.WithValue(ctx, attributesKey{}, attrs)
+}
+
+// AttributesFromContext returns the attributes of the caller, empty when none
+// were stored.
+func AttributesFromContext(ctx context.Context) Attributes {
+	attrs, _ := ctx.Value(attributesKey{}).(Attributes)
+	return attrs
+}
+
+// Value returns the value of an attribute, empty when it is missing.
+func (a Attributes) Value(attribute string) string {
+	switch attribute {
+	case AttributeTenant:
+		return a.Tenant
+	case AttributeUser:
+		return a.User
+	}
+	if name := strings.TrimPrefix(attribute, AttributeHeaderPrefix); name != attribute {
+		return a.Headers[strings.ToLower(name)]
+	}
+	return ""
+}
+
+func validAttribute(attribute string) bool {
+	switch attribute {
+	case AttributeTenant, AttributeUser:
+		return true
+	}
+	return strings.HasPrefix(attribute, AttributeHeaderPrefix) && len(attribute) > len(AttributeHeaderPrefix)
+}
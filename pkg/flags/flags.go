@@ -0,0 +1,279 @@
+package flags
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/flags/internal/override"
+)
+
+// Flag types.
+const (
+	// TypeBoolean serves on or off to everyone, unless a rule matches.
+	TypeBoolean = "boolean"
+	// TypePercentage serves on to a stable share of the bucketing attribute.
+	TypePercentage = "percentage"
+	// TypeVariant splits the bucketing attribute between weighted variants.
+	TypeVariant = "variant"
+)
+
+// Variants served by boolean and percentage flags.
+const (
+	On  = "on"
+	Off = "off"
+)
+
+// Evaluation reasons.
+const (
+	ReasonUnknown  = "unknown"
+	ReasonOverride = "override"
+	ReasonRule     = "rule"
+	ReasonDefault  = "default"
+	ReasonSplit    = "split"
+)
+
+// Flag is the definition of a feature flag, as read from the configuration.
+type Flag struct {
+	Type        string `json:"type"`
+	Description string `json:"description,omitempty"`
+	// Enabled is the value of a boolean flag when no rule matches.
+	Enabled bool `json:"enabled,omitempty"`
+	// Percentage is the share, between 0 and 100, of a percentage flag
+	// served on.
+	Percentage float64 `json:"percentage,omitempty"`
+	// Variants are the weighted variants of a variant flag.
+	Variants []WeightedVariant `json:"variants,omitempty"`
+	// Default is the variant served when the bucketing attribute is missing,
+	// the first variant when empty.
+	Default string `json:"default,omitempty"`
+	// BucketBy is the attribute splitting percentage and variant flags,
+	// user when empty.
+	BucketBy string `json:"bucket_by,omitempty"`
+	// Rules are evaluated in order, the first matching rule wins.
+	Rules []Rule `json:"rules,omitempty"`
+}
+
+// WeightedVariant is a variant of a variant flag.
+type WeightedVariant struct {
+	Name   string `json:"name"`
+	Weight int    `json:"weight"`
+}
+
+// Rule serves a variant to requests whose attribute is one of Values.
+// Attribute is tenant, user or header:<name>. Serve is on or off for
+// boolean and percentage flags, and a variant name for variant flags.
+type Rule struct {
+	Attribute string   `json:"attribute"`
+	Values    []string `json:"values"`
+	Serve     string   `json:"serve"`
+}
+
+// Evaluation is the outcome of a flag for a request.
+type Evaluation struct {
+	Key     string `json:"key"`
+	Variant string `json:"variant"`
+	Reason  string `json:"reason"`
+}
+
+var definitions atomic.Value // map[string]Flag
+
+// Set validates and replaces every flag definition. It is called at startup
+// and on every configuration reload.
+func Set(defs map[string]Flag) error {
+	if problems := Validate(defs); len(problems) > 0 {
+		return fmt.Errorf("invalid feature flags: %s", strings.Join(problems, "; "))
+	}
+	copied := make(map[string]Flag, len(defs))
+	for key, flag := range defs {
+		copied[key] = flag
+	}
+	definitions.Store(copied)
+	return nil
+}
+
+// All returns the current flag definitions.
+func All() map[string]Flag {
+	defs, _ := definitions.Load().(map[string]Flag)
+	return defs
+}
+
+// Overrides returns the variants forced by the tests, see flagstest.
+func Overrides() map[string]string {
+	return override.All()
+}
+
+// Bool reports whether a boolean or percentage flag is on for the request.
+// Unknown flags are off.
+func Bool(ctx context.Context, key string) bool {
+	return Evaluate(ctx, key).Variant == On
+}
+
+// Variant returns the variant of a flag served to the request, empty for
+// unknown flags.
+func Variant(ctx context.Context, key string) string {
+	return Evaluate(ctx, key).Variant
+}
+
+// Evaluate evaluates a flag against the attributes stored in ctx.
+func Evaluate(ctx context.Context, key string) Evaluation {
+	if variant, overridden := override.Get(key); overridden {
+		return Evaluation{Key: key, Variant: variant, Reason: ReasonOverride}
+	}
+
+	flag, ok := All()[key]
+	if !ok {
+		return Evaluation{Key: key, Reason: ReasonUnknown}
+	}
+
+	attrs := AttributesFromContext(ctx)
+	for _, rule := range flag.Rules {
+		if rule.matches(attrs) {
+			return Evaluation{Key: key, Variant: rule.Serve, Reason: ReasonRule}
+		}
+	}
+
+	switch flag.Type {
+	case TypeBoolean:
+		if flag.Enabled {
+			return Evaluation{Key: key, Variant: On, Reason: ReasonDefault}
+		}
+		return Evaluation{Key: key, Variant: Off, Reason: ReasonDefault}
+	case TypePercentage:
+		id := attrs.Value(flag.bucketBy())
+		if id == "" {
+			return Evaluation{Key: key, Variant: Off, Reason: ReasonDefault}
+		}
+		if float64(bucket(key, id)) < flag.Percentage*100 {
+			return Evaluation{Key: key, Variant: On, Reason: ReasonSplit}
+		}
+		return Evaluation{Key: key, Variant: Off, Reason: ReasonSplit}
+	default:
+		id := attrs.Value(flag.bucketBy())
+		if id == "" {
+			return Evaluation{Key: key, Variant: flag.defaultVariant(), Reason: ReasonDefault}
+		}
+		return Evaluation{Key: key, Variant: flag.split(bucket(key, id)), Reason: ReasonSplit}
+	}
+}
+
+// Validate returns the problems of the flag definitions, sorted by flag.
+func Validate(defs map[string]Flag) []string {
+	keys := make([]string, 0, len(defs))
+	for key := range defs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var problems []string
+	for _, key := range keys {
+		for _, problem := range defs[key].validate() {
+			problems = append(problems, key+": "+problem)
+		}
+	}
+	return problems
+}
+
+func (f Flag) validate() []string {
+	var problems []string
+	served := map[string]bool{On: true, Off: true}
+
+	switch f.Type {
+	case TypeBoolean:
+	case TypePercentage:
+		if f.Percentage < 0 || f.Percentage > 100 {
+			problems = append(problems, "percentage must be between 0 and 100")
+		}
+	case TypeVariant:
+		served = map[string]bool{}
+		total := 0
+		for _, v := range f.Variants {
+			if v.Name == "" || served[v.Name] {
+				problems = append(problems, fmt.Sprintf("variant names must be unique and not empty, got %q", v.Name))
+			}
+			if v.Weight < 0 {
+				problems = append(problems, fmt.Sprintf("variant %q has a negative weight", v.Name))
+			}
+			served[v.Name] = true
+			total += v.Weight
+		}
+		if total <= 0 {
+			problems = append(problems, "variant flags need at least one variant with a positive weight")
+		}
+		if f.Default != "" && !served[f.Default] {
+			problems = append(problems, fmt.Sprintf("default %q is not a variant", f.Default))
+		}
+	default:
+		problems = append(problems, fmt.Sprintf("unknown type %q, expected boolean, percentage or variant", f.Type))
+	}
+
+	if f.BucketBy != "" && !validAttribute(f.BucketBy) {
+		problems = append(problems, fmt.Sprintf("unknown bucket_by attribute %q", f.BucketBy))
+	}
+	for i, rule := range f.Rules {
+		if !validAttribute(rule.Attribute) {
+			problems = append(problems, fmt.Sprintf("rule %d: unknown attribute %q", i, rule.Attribute))
+		}
+		if !served[rule.Serve] {
+			problems = append(problems, fmt.Sprintf("rule %d: cannot serve %q", i, rule.Serve))
+		}
+	}
+	return problems
+}
+
+func (f Flag) bucketBy() string {
+	if f.BucketBy == "" {
+		return AttributeUser
+	}
+	return f.BucketBy
+}
+
+func (f Flag) defaultVariant() string {
+	if f.Default != "" {
+		return f.Default
+	}
+	return f.Variants[0].Name
+}
+
+// split picks the variant owning the bucket, proportionally to the weights.
+func (f Flag) split(b uint32) string {
+	total := 0
+	for _, v := range f.Variants {
+		total += v.Weight
+	}
+	point := int(b) * total / buckets
+	for _, v := range f.Variants {
+		if point < v.Weight {
+			return v.Name
+		}
+		point -= v.Weight
+	}
+	return f.defaultVariant()
+}
+
+func (r Rule) matches(attrs Attributes) bool {
+	value := attrs.Value(r.Attribute)
+	if value == "" {
+		return false
+	}
+	for _, v := range r.Values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// buckets is the resolution of percentage and variant splits.
+const buckets = 10000
+
+// bucket assigns id a stable bucket for the flag, so a user keeps the same
+// variant while the split is unchanged.
+func bucket(key, id string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(key + ":" + id))
+	return h.Sum32() % buckets
+}
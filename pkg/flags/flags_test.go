@@ -0,0 +1,126 @@
+package flags
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+// useFlags replaces the flag definitions until the end of the test.
+func useFlags(t *testing.T, defs map[string]Flag) {
+	t.Helper()
+	previous := All()
+	if err := Set(defs); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { definitions.Store(previous) })
+}
+
+func request(attrs Attributes) context.Context {
+	return ContextWithAttributes(context.Background(), attrs)
+}
+
+func TestEvaluateTargeting(t *testing.T) {
+	useFlags(t, map[string]Flag{
+		"new-search": {
+			Type:    TypeBoolean,
+			Enabled: false,
+			Rules: []Rule{
+				{Attribute: AttributeTenant, Values: []string{"acme"}, Serve: On},
+				{Attribute: "header:X-Beta", Values: []string{"1"}, Serve: On},
+				{Attribute: AttributeUser, Values: []string{"mallory"}, Serve: Off},
+			},
+		},
+		"ranking": {
+			Type:     TypeVariant,
+			Variants: []WeightedVariant{{Name: "usage", Weight: 1}, {Name: "recency", Weight: 0}},
+			Default:  "recency",
+			Rules:    []Rule{{Attribute: AttributeTenant, Values: []string{"acme"}, Serve: "recency"}},
+		},
+	})
+
+	for _, tt := range []struct {
+		name    string
+		key     string
+		attrs   Attributes
+		variant string
+		reason  string
+	}{
+		{"no attributes", "new-search", Attributes{}, Off, ReasonDefault},
+		{"tenant rule", "new-search", Attributes{Tenant: "acme"}, On, ReasonRule},
+		{"other tenant", "new-search", Attributes{Tenant: "globex"}, Off, ReasonDefault},
+		{"header rule, any case", "new-search", Attributes{Headers: map[string]string{"x-beta": "1"}}, On, ReasonRule},
+		{"first rule wins", "new-search", Attributes{Tenant: "acme", User: "mallory"}, On, ReasonRule},
+		{"user rule", "new-search", Attributes{User: "mallory", Headers: map[string]string{"x-beta": "0"}}, Off, ReasonRule},
+		{"variant default without user", "ranking", Attributes{}, "recency", ReasonDefault},
+		{"variant split", "ranking", Attributes{User: "alice"}, "usage", ReasonSplit},
+		{"variant rule", "ranking", Attributes{User: "alice", Tenant: "acme"}, "recency", ReasonRule},
+		{"unknown flag", "missing", Attributes{Tenant: "acme"}, "", ReasonUnknown},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Evaluate(request(tt.attrs), tt.key)
+			if got.Variant != tt.variant || got.Reason != tt.reason {
+				t.Fatalf("got %s (%s), want %s (%s)", got.Variant, got.Reason, tt.variant, tt.reason)
+			}
+		})
+	}
+}
+
+func TestPercentageIsStablePerUser(t *testing.T) {
+	useFlags(t, map[string]Flag{
+		"rollout":   {Type: TypePercentage, Percentage: 30},
+		"by-tenant": {Type: TypePercentage, Percentage: 100, BucketBy: AttributeTenant},
+	})
+
+	on := 0
+	const users = 2000
+	for i := 0; i < users; i++ {
+		ctx := request(Attributes{User: fmt.Sprintf("user-%d", i)})
+		first := Bool(ctx, "rollout")
+		for j := 0; j < 3; j++ {
+			if Bool(ctx, "rollout") != first {
+				t.Fatalf("user-%d changed variant between requests", i)
+			}
+		}
+		if first {
+			on++
+		}
+	}
+	if share := float64(on) / users; share < 0.25 || share > 0.35 {
+		t.Errorf("got %.0f%% of the users on, want about 30%%", share*100)
+	}
+
+	if Bool(request(Attributes{}), "rollout") {
+		t.Error("a request without user is on")
+	}
+	if !Bool(request(Attributes{Tenant: "acme"}), "by-tenant") {
+		t.Error("the flag bucketed by tenant is off at 100%")
+	}
+	if Bool(request(Attributes{User: "alice"}), "by-tenant") {
+		t.Error("the flag bucketed by tenant is on without a tenant")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	problems := Validate(map[string]Flag{
+		"fine":   {Type: TypeBoolean},
+		"kind":   {Type: "toggle"},
+		"share":  {Type: TypePercentage, Percentage: 120},
+		"split":  {Type: TypeVariant, Variants: []WeightedVariant{{Name: "a", Weight: 0}}, Default: "b"},
+		"target": {Type: TypeBoolean, Rules: []Rule{{Attribute: "country", Serve: "maybe"}}},
+	})
+	want := []string{
+		`kind: unknown type "toggle", expected boolean, percentage or variant`,
+		"share: percentage must be between 0 and 100",
+		"split: variant flags need at least one variant with a positive weight",
+		`split: default "b" is not a variant`,
+		`target: rule 0: unknown attribute "country"`,
+		`target: rule 0: cannot serve "maybe"`,
+	}
+	if fmt.Sprint(problems) != fmt.Sprint(want) {
+		t.Fatalf("got problems\n%q\nwant\n%q", problems, want)
+	}
+	if err := Set(map[string]Flag{"kind": {Type: "toggle"}}); err == nil {
+		t.Fatal("Set() accepted invalid flags")
+	}
+}
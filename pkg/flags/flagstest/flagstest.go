@@ -0,0 +1,27 @@
+// Package flagstest forces feature flags in tests.
+package flagstest
+
+import (
+	"testing"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/flags"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/flags/internal/override"
+)
+
+// Override forces the variant of a flag, whatever its definition, until the
+// end of the test. Overrides apply to every request of the process, so tests
+// using them must not run in parallel.
+func Override(tb testing.TB, key, variant string) {
+	tb.Helper()
+	tb.Cleanup(override.Set(key, variant))
+}
+
+// OverrideBool forces a boolean or percentage flag on or off, see Override.
+func OverrideBool(tb testing.TB, key string, enabled bool) {
+	tb.Helper()
+	if enabled {
+		Override(tb, key, flags.On)
+		return
+	}
+	Override(tb, key, flags.Off)
+}
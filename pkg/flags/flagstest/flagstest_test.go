@@ -0,0 +1,41 @@
+package flagstest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/flags"
+)
+
+func TestOverride(t *testing.T) {
+	previous := flags.All()
+	if err := flags.Set(map[string]flags.Flag{"new-search": {Type: flags.TypeBoolean, Enabled: false}}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = flags.Set(previous) })
+	ctx := flags.ContextWithAttributes(context.Background(), flags.Attributes{})
+
+	t.Run("forced", func(t *testing.T) {
+		OverrideBool(t, "new-search", true)
+		if got := flags.Evaluate(ctx, "new-search"); got.Variant != flags.On || got.Reason != flags.ReasonOverride {
+			t.Fatalf("got %+v with an override", got)
+		}
+
+		t.Run("nested", func(t *testing.T) {
+			Override(t, "new-search", flags.Off)
+			if flags.Bool(ctx, "new-search") {
+				t.Fatal("the nested override is ignored")
+			}
+		})
+		if !flags.Bool(ctx, "new-search") {
+			t.Fatal("the outer override was not restored after the nested test")
+		}
+	})
+
+	if flags.Bool(ctx, "new-search") {
+		t.Fatal("the override outlived its test")
+	}
+	if n := len(flags.Overrides()); n != 0 {
+		t.Fatalf("got %d overrides left, want 0", n)
+	}
+}
@@ -0,0 +1,48 @@
+// Package override holds the flag variants forced by the tests, shared by
+// flags and flagstest so that flags does not depend on testing.
+package override
+
+import "sync"
+
+var (
+	mu        sync.RWMutex
+	overrides = map[string]string{}
+)
+
+// Set forces the variant of a flag and returns a function restoring the
+// previous override.
+func Set(key, variant string) (restore func()) {
+	mu.Lock()
+	previous, existed := overrides[key]
+	overrides[key] = variant
+	mu.Unlock()
+
+	return func() {
+		mu.Lock()
+		defer mu.Unlock()
+		if existed {
+			overrides[key] = previous
+		} else {
+			delete(overrides, key)
+		}
+	}
+}
+
+// Get returns the variant forced for a flag, if any.
+func Get(key string) (string, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	variant, ok := overrides[key]
+	return variant, ok
+}
+
+// All returns a copy of the forced variants.
+func All() map[string]string {
+	mu.RLock()
+	defer mu.RUnlock()
+	copied := make(map[string]string, len(overrides))
+	for key, variant := range overrides {
+		copied[key] = variant
+	}
+	return copied
+}
@@ -0,0 +1,301 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/logger"
+
+	"google.golang.org/grpc"
+)
+
+// Exit codes returned by Run.
+const (
+	ExitOK            = 0
+	ExitServerError   = 1
+	ExitShutdownError = 2
+)
+
+// Options configures the shutdown sequence.
+type Options struct {
+	// ShutdownTimeout bounds the time given to servers to drain and to
+	// closers to release their resources.
+	ShutdownTimeout time.Duration
+	// DrainDelay is the time between reporting not ready and stopping the
+	// servers, giving load balancers time to stop routing traffic.
+	DrainDelay time.Duration
+}
+
+type server struct {
+	name  string
+	serve func() error
+	stop  func(ctx context.Context) error
+	// dependsOn names the servers this one calls, they are stopped after it
+	dependsOn []string
+	// stopped are called once the server has stopped, drained or not
+	stopped []func() error
+}
+
+// ServerOption configures how a server is stopped.
+type ServerOption func(s *server)
+
+// DependsOn declares that the server calls the named servers, which are only
+// stopped once it has drained.
+func DependsOn(names ...string) ServerOption {
+	return func(s *server) {
+		s.dependsOn = append(s.dependsOn, names...)
+	}
+}
+
+// OnStopped registers fn to release a resource used by the requests of the
+// server once it has stopped, such as a client connection.
+func OnStopped(fn func() error) ServerOption {
+	return func(s *server) {
+		s.stopped = append(s.stopped, fn)
+	}
+}
+
+type worker struct {
+	name string
+	run  func(ctx context.Context)
+}
+
+type closer struct {
+	name  string
+	close func(ctx context.Context) error
+}
+
+// Manager starts servers and background workers, waits for a signal or the
+// first fatal error and then shuts everything down in order: shutdown hooks,
+// servers, workers and finally closers.
+type Manager struct {
+	opts    Options
+	servers []server
+	workers []worker
+	hooks   []func()
+	closers []closer
+	signals []os.Signal
+}
+
+// New creates a manager reacting to SIGINT and SIGTERM.
+func New(opts Options) *Manager {
+	return &Manager{
+		opts:    opts,
+		signals: []os.Signal{os.Interrupt, syscall.SIGTERM},
+	}
+}
+
+// AddServer registers a server. serve blocks until the server stops and must
+// return nil after a graceful stop. stop drains the server before ctx expires.
+func (m *Manager) AddServer(name string, serve func() error, stop func(ctx context.Context) error, opts ...ServerOption) {
+	s := server{name: name, serve: serve, stop: stop}
+	for _, opt := range opts {
+		opt(&s)
+	}
+	m.servers = append(m.servers, s)
+}
+
+// AddHTTPServer registers an HTTP server, drained with http.Server.Shutdown.
+func (m *Manager) AddHTTPServer(name string, srv *http.Server, opts ...ServerOption) {
+	m.AddServer(name, func() error {
+		if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	}, srv.Shutdown, opts...)
+}
+
+// AddGRPCServer registers a gRPC server serving on lis, drained with
+// grpc.Server.GracefulStop and stopped forcefully once ctx expires.
+func (m *Manager) AddGRPCServer(name string, srv *grpc.Server, lis net.Listener, opts ...ServerOption) {
+	m.AddServer(name, func() error {
+		return srv.Serve(lis)
+	}, func(ctx context.Context) error {
+		done := make(chan struct{})
+		go func() {
+			srv.GracefulStop()
+			close(done)
+		}()
+		select {
+		case <-done:
+			return nil
+		case <-ctx.Done():
+			srv.Stop()
+			return ctx.Err()
+		}
+	}, opts...)
+}
+
+// AddWorker registers a background worker running until its context is done.
+func (m *Manager) AddWorker(name string, run func(ctx context.Context)) {
+	m.workers = append(m.workers, worker{name: name, run: run})
+}
+
+// OnShutdown registers a hook called as soon as the shutdown starts, before
+// any server is stopped.
+func (m *Manager) OnShutdown(hook func()) {
+	m.hooks = append(m.hooks, hook)
+}
+
+// AddCloser registers a resource released after every server and worker has
+// stopped. Closers run in reverse registration order.
+func (m *Manager) AddCloser(name string, close func(ctx context.Context) error) {
+	m.closers = append(m.closers, closer{name: name, close: close})
+}
+
+// Run starts every server and worker and blocks until the service has shut
+// down. It returns the process exit code.
+func (m *Manager) Run() int {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, m.signals...)
+	defer signal.Stop(sigCh)
+
+	errCh := make(chan error, len(m.servers))
+	var serversWg sync.WaitGroup
+	for _, s := range m.servers {
+		serversWg.Add(1)
+		go func(s server) {
+			defer serversWg.Done()
+			logger.Infof("starting %s", s.name)
+			if err := s.serve(); err != nil {
+				errCh <- &serverError{name: s.name, err: err}
+			}
+		}(s)
+	}
+
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	var workersWg sync.WaitGroup
+	for _, w := range m.workers {
+		workersWg.Add(1)
+		go func(w worker) {
+			defer workersWg.Done()
+			logger.Infof("starting %s", w.name)
+			w.run(workersCtx)
+		}(w)
+	}
+
+	// Wait for a signal or a server error
+	code := ExitOK
+	select {
+	case sig := <-sigCh:
+		logger.Infof("Received signal: %v. Shutting down...", sig)
+	case err := <-errCh:
+		logger.Errorf("Server error: %v. Shutting down...", err)
+		code = ExitServerError
+	}
+
+	if !m.shutdown(stopWorkers, &workersWg) && code == ExitOK {
+		code = ExitShutdownError
+	}
+	serversWg.Wait()
+	logger.Infof("shutdown complete")
+	return code
+}
+
+// shutdown runs the shutdown sequence and reports whether it completed cleanly.
+func (m *Manager) shutdown(stopWorkers context.CancelFunc, workersWg *sync.WaitGroup) bool {
+	clean := true
+	for _, hook := range m.hooks {
+		hook()
+	}
+	if m.opts.DrainDelay > 0 {
+		logger.Infof("waiting %s for traffic to drain", m.opts.DrainDelay)
+		time.Sleep(m.opts.DrainDelay)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.opts.ShutdownTimeout)
+	defer cancel()
+
+	// servers stop concurrently, each one once the servers calling it stopped
+	stopped := make(map[string]chan struct{}, len(m.servers))
+	for _, s := range m.servers {
+		stopped[s.name] = make(chan struct{})
+	}
+	callers := make(map[string][]string)
+	for _, s := range m.servers {
+		for _, name := range s.dependsOn {
+			callers[name] = append(callers[name], s.name)
+		}
+	}
+	var stopWg sync.WaitGroup
+	var mu sync.Mutex
+	fail := func(format string, args ...interface{}) {
+		logger.Errorf(format, args...)
+		mu.Lock()
+		clean = false
+		mu.Unlock()
+	}
+	for _, s := range m.servers {
+		stopWg.Add(1)
+		go func(s server) {
+			defer stopWg.Done()
+			defer close(stopped[s.name])
+			for _, caller := range callers[s.name] {
+				<-stopped[caller]
+			}
+			if err := s.stop(ctx); err != nil {
+				fail("failed to stop %s: %v", s.name, err)
+			} else {
+				logger.Infof("stopped %s", s.name)
+			}
+			for _, fn := range s.stopped {
+				if err := fn(); err != nil {
+					fail("failed to release %s: %v", s.name, err)
+				}
+			}
+		}(s)
+	}
+	stopWg.Wait()
+
+	stopWorkers()
+	workersWg.Wait()
+
+	if !m.close(ctx) {
+		clean = false
+	}
+	return clean
+}
+
+// Close releases the resources of a service whose startup failed, before
+// Run was called, by running the closers. It reports whether they all
+// succeeded.
+func (m *Manager) Close() bool {
+	ctx, cancel := context.WithTimeout(context.Background(), m.opts.ShutdownTimeout)
+	defer cancel()
+	return m.close(ctx)
+}
+
+// close runs the closers in reverse registration order.
+func (m *Manager) close(ctx context.Context) bool {
+	clean := true
+	for i := len(m.closers) - 1; i >= 0; i-- {
+		c := m.closers[i]
+		if err := c.close(ctx); err != nil {
+			logger.Errorf("failed to close %s: %v", c.name, err)
+			clean = false
+			continue
+		}
+		logger.Infof("closed %s", c.name)
+	}
+	return clean
+}
+
+type serverError struct {
+	name string
+	err  error
+}
+
+func (e *serverError) Error() string {
+	return e.name + ": " + e.err.Error()
+}
+
+func (e *serverError) Unwrap() error {
+	return e.err
+}
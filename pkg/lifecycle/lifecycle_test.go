@@ -0,0 +1,178 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// events records the steps of a shutdown in order.
+type events struct {
+	mu    sync.Mutex
+	steps []string
+	at    map[string]time.Time
+}
+
+func (e *events) add(step string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.at == nil {
+		e.at = map[string]time.Time{}
+	}
+	e.steps = append(e.steps, step)
+	e.at[step] = time.Now()
+}
+
+func (e *events) index(step string) int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for i, s := range e.steps {
+		if s == step {
+			return i
+		}
+	}
+	return -1
+}
+
+// newTestManager returns a manager shutting down on SIGUSR1.
+func newTestManager(opts Options) *Manager {
+	m := New(opts)
+	m.signals = []os.Signal{syscall.SIGUSR1}
+	return m
+}
+
+// addBlockingServer registers a server serving until it is stopped, the
+// first one started sends SIGUSR1 when signal is set.
+func addBlockingServer(m *Manager, ev *events, name string, stopErr error, signal bool, opts ...ServerOption) {
+	done := make(chan struct{})
+	m.AddServer(name, func() error {
+		if signal {
+			syscall.Kill(os.Getpid(), syscall.SIGUSR1)
+		}
+		<-done
+		return nil
+	}, func(ctx context.Context) error {
+		ev.add("stopping " + name)
+		// leave the other servers time to stop first when unordered
+		time.Sleep(10 * time.Millisecond)
+		close(done)
+		ev.add("stopped " + name)
+		return stopErr
+	}, opts...)
+}
+
+func TestRunStopsInOrder(t *testing.T) {
+	ev := &events{}
+	m := newTestManager(Options{ShutdownTimeout: time.Second})
+	m.OnShutdown(func() { ev.add("hook") })
+	addBlockingServer(m, ev, "grpc", nil, false)
+	addBlockingServer(m, ev, "gateway", nil, true, DependsOn("grpc"), OnStopped(func() error {
+		ev.add("closed gateway connection")
+		return nil
+	}))
+	m.AddWorker("worker", func(ctx context.Context) {
+		<-ctx.Done()
+		ev.add("worker done")
+	})
+	m.AddCloser("first", func(ctx context.Context) error { ev.add("closed first"); return nil })
+	m.AddCloser("second", func(ctx context.Context) error { ev.add("closed second"); return nil })
+
+	if code := m.Run(); code != ExitOK {
+		t.Fatalf("got exit code %d, want %d", code, ExitOK)
+	}
+
+	for _, order := range [][2]string{
+		{"hook", "stopping gateway"},
+		{"stopped gateway", "closed gateway connection"},
+		{"closed gateway connection", "stopping grpc"},
+		{"stopped grpc", "worker done"},
+		{"worker done", "closed second"},
+		{"closed second", "closed first"},
+	} {
+		before, after := ev.index(order[0]), ev.index(order[1])
+		if before < 0 || after < 0 || before > after {
+			t.Errorf("%q did not happen before %q: %v", order[0], order[1], ev.steps)
+		}
+	}
+}
+
+func TestRunWaitsForDrainDelay(t *testing.T) {
+	ev := &events{}
+	delay := 50 * time.Millisecond
+	m := newTestManager(Options{ShutdownTimeout: time.Second, DrainDelay: delay})
+	m.OnShutdown(func() { ev.add("hook") })
+	addBlockingServer(m, ev, "http", nil, true)
+
+	if code := m.Run(); code != ExitOK {
+		t.Fatalf("got exit code %d, want %d", code, ExitOK)
+	}
+	if waited := ev.at["stopping http"].Sub(ev.at["hook"]); waited < delay {
+		t.Fatalf("servers stopped %s after the hooks, want at least %s", waited, delay)
+	}
+}
+
+func TestRunExitCodes(t *testing.T) {
+	t.Run("server error", func(t *testing.T) {
+		m := newTestManager(Options{ShutdownTimeout: time.Second})
+		m.AddServer("broken", func() error {
+			return errors.New("address already in use")
+		}, func(ctx context.Context) error { return nil })
+		if code := m.Run(); code != ExitServerError {
+			t.Fatalf("got exit code %d, want %d", code, ExitServerError)
+		}
+	})
+
+	t.Run("stop error", func(t *testing.T) {
+		m := newTestManager(Options{ShutdownTimeout: time.Second})
+		addBlockingServer(m, &events{}, "http", context.DeadlineExceeded, true)
+		if code := m.Run(); code != ExitShutdownError {
+			t.Fatalf("got exit code %d, want %d", code, ExitShutdownError)
+		}
+	})
+
+	t.Run("closer error", func(t *testing.T) {
+		m := newTestManager(Options{ShutdownTimeout: time.Second})
+		addBlockingServer(m, &events{}, "http", nil, true)
+		m.AddCloser("database", func(ctx context.Context) error { return errors.New("close failed") })
+		if code := m.Run(); code != ExitShutdownError {
+			t.Fatalf("got exit code %d, want %d", code, ExitShutdownError)
+		}
+	})
+
+	t.Run("release error", func(t *testing.T) {
+		m := newTestManager(Options{ShutdownTimeout: time.Second})
+		addBlockingServer(m, &events{}, "gateway", nil, true, OnStopped(func() error { return errors.New("close failed") }))
+		if code := m.Run(); code != ExitShutdownError {
+			t.Fatalf("got exit code %d, want %d", code, ExitShutdownError)
+		}
+	})
+}
+
+func TestClose(t *testing.T) {
+	m := newTestManager(Options{ShutdownTimeout: time.Second})
+	ev := &events{}
+	m.AddCloser("tracing", func(ctx context.Context) error {
+		ev.add("closed tracing")
+		return nil
+	})
+	m.AddCloser("database", func(ctx context.Context) error {
+		ev.add("closed database")
+		return nil
+	})
+
+	if !m.Close() {
+		t.Fatal("Close() reported a failure")
+	}
+	if ev.index("closed database") != 0 || ev.index("closed tracing") != 1 {
+		t.Fatalf("got steps %q, want the closers in reverse order", ev.steps)
+	}
+
+	m.AddCloser("broken", func(ctx context.Context) error { return errors.New("close failed") })
+	if m.Close() {
+		t.Fatal("Close() reported no failure with a failing closer")
+	}
+}
@@ -0,0 +1,143 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync/atomic"
+	"time"
+)
+
+// Transports reported in access log entries.
+const (
+	TransportHTTP    = "http"
+	TransportGRPC    = "grpc"
+	TransportGateway = "gateway"
+)
+
+// AccessLogOptions configures an AccessLogger.
+type AccessLogOptions struct {
+	// SampleRate is the fraction of successful requests that are logged,
+	// between 0 and 1. Failed requests are always logged.
+	SampleRate float64
+	// SkipPaths lists routes or gRPC methods that are never logged.
+	SkipPaths []string
+}
+
+// AccessEntry describes a single request handled by one of the transports.
+type AccessEntry struct {
+	Transport string
+	Method    string
+	Route     string
+	Status    int    // HTTP status code, zero for gRPC
+	Code      string // gRPC status code, empty for plain HTTP
+	Latency   time.Duration
+	BytesIn   int64
+	BytesOut  int64
+	Peer      string
+	UserAgent string
+	Error     string
+}
+
+// AccessLogger writes access log entries in the same structured format for
+// every transport.
+type AccessLogger struct {
+	settings atomic.Value // *accessLogSettings
+}
+
+type accessLogSettings struct {
+	sampleRate float64
+	skip       map[string]struct{}
+}
+
+// NewAccessLogger creates an access logger with the given options.
+func NewAccessLogger(opts AccessLogOptions) *AccessLogger {
+	a := &AccessLogger{}
+	a.SetOptions(opts)
+	return a
+}
+
+// SetOptions replaces the options of the access logger. It is safe to call
+// while requests are being logged.
+func (a *AccessLogger) SetOptions(opts AccessLogOptions) {
+	skip := make(map[string]struct{}, len(opts.SkipPaths))
+	for _, path := range opts.SkipPaths {
+		skip[path] = struct{}{}
+	}
+
+	a.settings.Store(&accessLogSettings{
+		sampleRate: opts.SampleRate,
+		skip:       skip,
+	})
+}
+
+func (a *AccessLogger) current() *accessLogSettings {
+	return a.settings.Load().(*accessLogSettings)
+}
+
+// Skip reports whether requests to route are excluded from the access log.
+func (a *AccessLogger) Skip(route string) bool {
+	_, ok := a.current().skip[route]
+	return ok
+}
+
+// Log writes the entry unless it is sampled out. The request ID and trace
+// IDs are taken from ctx.
+func (a *AccessLogger) Log(ctx context.Context, e AccessEntry) {
+	failed := e.failed()
+	sampleRate := a.current().sampleRate
+	if !failed && sampleRate < 1 && rand.Float64() >= sampleRate {
+		return
+	}
+
+	fields := contextFields(ctx)
+	fields["transport"] = e.Transport
+	fields["method"] = e.Method
+	fields["route"] = e.Route
+	fields["latency_ms"] = float64(e.Latency.Microseconds()) / 1000
+	fields["bytes_in"] = e.BytesIn
+	fields["bytes_out"] = e.BytesOut
+	fields["peer"] = e.Peer
+	fields["user_agent"] = e.UserAgent
+	result := e.Code
+	if e.Transport == TransportGRPC {
+		fields["grpc_code"] = e.Code
+	} else {
+		fields["status"] = e.Status
+		result = fmt.Sprintf("%d", e.Status)
+		if e.Code != "" {
+			fields["grpc_code"] = e.Code
+		}
+	}
+	if e.Error != "" {
+		fields["error"] = e.Error
+	}
+
+	entry := logger.WithFields(fields)
+	message := fmt.Sprintf("%s %s %s", e.Method, e.Route, result)
+	switch {
+	case e.Status >= 500 || isServerCode(e.Code):
+		entry.Error(message)
+	case failed:
+		entry.Warn(message)
+	default:
+		entry.Info(message)
+	}
+}
+
+// failed reports whether the request did not complete successfully.
+func (e AccessEntry) failed() bool {
+	if e.Status >= 400 {
+		return true
+	}
+	return e.Code != "" && e.Code != "OK"
+}
+
+// isServerCode reports whether the gRPC code denotes a server side failure.
+func isServerCode(code string) bool {
+	switch code {
+	case "Unknown", "DeadlineExceeded", "Unimplemented", "Internal", "Unavailable", "DataLoss":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,47 @@
+package logger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	logtest "github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestAccessLogGRPCLevels(t *testing.T) {
+	previous := logger.ReplaceHooks(make(logrus.LevelHooks))
+	defer logger.ReplaceHooks(previous)
+	hook := logtest.NewLocal(logger)
+	a := NewAccessLogger(AccessLogOptions{SampleRate: 1})
+
+	tests := []struct {
+		code  string
+		level logrus.Level
+	}{
+		{"OK", logrus.InfoLevel},
+		{"NotFound", logrus.WarnLevel},
+		{"InvalidArgument", logrus.WarnLevel},
+		{"Internal", logrus.ErrorLevel},
+		{"Unavailable", logrus.ErrorLevel},
+	}
+	for _, tt := range tests {
+		a.Log(context.Background(), AccessEntry{Transport: TransportGRPC, Method: "POST", Route: "/service.Service/GetTags", Code: tt.code})
+		entry := hook.LastEntry()
+		if entry.Level != tt.level || entry.Message != "POST /service.Service/GetTags "+tt.code {
+			t.Errorf("%s: logged %s %q, want %s", tt.code, entry.Level, entry.Message, tt.level)
+		}
+		if entry.Data["grpc_code"] != tt.code {
+			t.Errorf("%s: grpc_code = %v", tt.code, entry.Data["grpc_code"])
+		}
+		if _, ok := entry.Data["status"]; ok {
+			t.Errorf("%s: gRPC entries have no HTTP status", tt.code)
+		}
+	}
+
+	// the gateway reports both
+	a.Log(context.Background(), AccessEntry{Transport: TransportGateway, Method: "GET", Route: "/api/v1/tags/{id}", Status: 404, Code: "NotFound"})
+	entry := hook.LastEntry()
+	if entry.Level != logrus.WarnLevel || entry.Data["status"] != 404 || entry.Data["grpc_code"] != "NotFound" {
+		t.Errorf("gateway: logged %s %v", entry.Level, entry.Data)
+	}
+}
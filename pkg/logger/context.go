@@ -0,0 +1,51 @@
+package logger
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type requestIDKey struct{}
+
+// ContextWithRequestID returns a copy of ctx carrying the given request ID.
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request ID stored in ctx, if any.
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// WithFields returns an entry of the standard logger with the given fields.
+func WithFields(fields Fields) *logrus.Entry {
+	return logger.WithFields(logrus.Fields(fields))
+}
+
+// WithContext returns an entry of the standard logger annotated with the
+// request scoped fields found in ctx: the request ID and the trace and span
+// IDs of the active span.
+func WithContext(ctx context.Context) *logrus.Entry {
+	return logger.WithFields(contextFields(ctx))
+}
+
+func contextFields(ctx context.Context) logrus.Fields {
+	fields := logrus.Fields{}
+	if ctx == nil {
+		return fields
+	}
+	if requestID := RequestIDFromContext(ctx); requestID != "" {
+		fields["request_id"] = requestID
+	}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		fields["trace_id"] = spanContext.TraceID().String()
+		fields["span_id"] = spanContext.SpanID().String()
+	}
+	return fields
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path"
 	"runtime"
+	"sort"
 	"strings"
 	"time"
 
@@ -40,29 +41,49 @@ func getColor(level logrus.Level) int {
 	}
 }
 
-var logger = logrus.New()
+var (
+	logger = logrus.New()
+	// location is the timezone of log timestamps
+	location = time.Local
+)
 
 func init() {
-	env := os.Getenv("APP_ENV")
-
 	logger.Level = logrus.InfoLevel
+	SetEnvironment(os.Getenv("APP_ENV"))
+	logger.SetReportCaller(false)
+}
+
+// SetEnvironment selects the JSON formatter in production and the colored
+// formatter everywhere else.
+func SetEnvironment(env string) {
 	if env == "production" {
 		logger.SetFormatter(&CustomJSONFormatter{
 			JSONFormatter: logrus.JSONFormatter{},
 		})
 	} else {
-		logger.Formatter = &formatter{}
+		logger.SetFormatter(&formatter{})
 	}
-
-	logger.SetReportCaller(false)
 }
 
+// SetLogLevel changes the level of the standard logger, safe to call while
+// logging.
 func SetLogLevel(level logrus.Level) {
-	logger.Level = level
+	logger.SetLevel(level)
+}
+
+// SetLocation sets the timezone of log timestamps. It is meant to be called
+// once at startup, before the servers start.
+func SetLocation(loc *time.Location) {
+	location = loc
 }
 
 type Fields logrus.Fields
 
+// IsLevelEnabled reports whether messages at the given level are logged.
+func IsLevelEnabled(level logrus.Level) bool {
+	return logger.IsLevelEnabled(level)
+}
+
 // Debugf logs a message at level Debug on the standard logger.
 func Debugf(format string, args ...interface{}) {
 	if logger.Level >= logrus.DebugLevel {
@@ -127,16 +148,29 @@ func (f *formatter) Format(entry *logrus.Entry) ([]byte, error) {
 	sb.WriteString(fmt.Sprintf("\x1b[%dm", levelColor)) // Color start
 	sb.WriteString(strings.ToUpper(entry.Level.String()))
 	sb.WriteString(" ")
-	sb.WriteString(entry.Time.Format(time.RFC3339))
+	sb.WriteString(entry.Time.In(location).Format(time.RFC3339))
 	sb.WriteString(" ")
 	sb.WriteString(f.prefix)
 	sb.WriteString(entry.Message)
+	writeFields(&sb, entry.Data)
 	sb.WriteString("\x1b[0m") // Reset color
 	sb.WriteString("\n")
 
 	return sb.Bytes(), nil
 }
 
+// writeFields appends the entry fields as sorted key=value pairs.
+func writeFields(sb *bytes.Buffer, data logrus.Fields) {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		sb.WriteString(fmt.Sprintf(" %s=%v", key, data[key]))
+	}
+}
+
 // Custom JSON Formatter
 type CustomJSONFormatter struct {
 	logrus.JSONFormatter
@@ -148,6 +182,7 @@ func (f *CustomJSONFormatter) Format(entry *logrus.Entry) ([]byte, error) {
 
 	// Adding custom fields for the corrected caller information
 	entry.Data["location"] = fmt.Sprintf("%s:%d", frame.File, frame.Line)
+	entry.Time = entry.Time.In(location)
 
 	return f.JSONFormatter.Format(entry)
 }
@@ -0,0 +1,220 @@
+package logger
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// RedactedValue replaces sensitive values in log output.
+const RedactedValue = "[REDACTED]"
+
+// RedactionOptions configures which values are masked in log output, in
+// addition to the built-in defaults.
+type RedactionOptions struct {
+	// Fields lists field names whose values are always masked, compared
+	// case-insensitively against structured log fields and protobuf fields.
+	Fields []string
+	// Patterns lists regular expressions whose matches are masked in
+	// messages and string values.
+	Patterns []string
+}
+
+type redactPattern struct {
+	re          *regexp.Regexp
+	replacement string
+}
+
+var defaultRedactFields = []string{
+	"authorization",
+	"password",
+	"passwd",
+	"secret",
+	"token",
+	"access_token",
+	"refresh_token",
+	"api_key",
+	"dsn",
+	"email",
+}
+
+var defaultRedactPatterns = []redactPattern{
+	// Authorization header values, whatever their length
+	{regexp.MustCompile(`(?i)\b(authorization:\s*(?:(?:bearer|basic)\s+)?|(?:bearer|basic)\s+)[a-z0-9._~+/=-]+`), "${1}" + RedactedValue},
+	// key=value secrets, including the password of a postgres DSN
+	{regexp.MustCompile(`(?i)\b(password|passwd|pwd|secret|token|api_key)=("[^"]*"|'[^']*'|\S+)`), "${1}=" + RedactedValue},
+	// credentials embedded in URLs
+	{regexp.MustCompile(`://([^:/@\s]+):([^@\s]+)@`), "://${1}:" + RedactedValue + "@"},
+	// the password of a mysql DSN
+	{regexp.MustCompile(`([^:/@\s]+):(\S+)@(tcp|unix)\(`), "${1}:" + RedactedValue + "@${3}("},
+	// JSON web tokens
+	{regexp.MustCompile(`\beyJ[a-zA-Z0-9_-]+\.[a-zA-Z0-9_-]+\.[a-zA-Z0-9_-]+`), RedactedValue},
+	// email addresses
+	{regexp.MustCompile(`[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}`), RedactedValue},
+}
+
+// Redactor masks sensitive values in strings, log fields and protobuf messages.
+type Redactor struct {
+	fields   map[string]struct{}
+	patterns []redactPattern
+}
+
+// NewRedactor creates a redactor with the defaults extended by opts.
+func NewRedactor(opts RedactionOptions) (*Redactor, error) {
+	r := &Redactor{
+		fields:   make(map[string]struct{}),
+		patterns: append([]redactPattern{}, defaultRedactPatterns...),
+	}
+	for _, field := range append(defaultRedactFields, opts.Fields...) {
+		r.fields[strings.ToLower(strings.TrimSpace(field))] = struct{}{}
+	}
+	for _, pattern := range opts.Patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern %q: %w", pattern, err)
+		}
+		r.patterns = append(r.patterns, redactPattern{re: re, replacement: RedactedValue})
+	}
+	return r, nil
+}
+
+// IsSensitive reports whether values of the named field are masked.
+func (r *Redactor) IsSensitive(name string) bool {
+	_, ok := r.fields[strings.ToLower(name)]
+	return ok
+}
+
+// String masks every sensitive pattern found in s.
+func (r *Redactor) String(s string) string {
+	for _, p := range r.patterns {
+		s = p.re.ReplaceAllString(s, p.replacement)
+	}
+	return s
+}
+
+// Value masks a structured log value stored under the given field name.
+func (r *Redactor) Value(name string, value interface{}) interface{} {
+	if r.IsSensitive(name) {
+		return RedactedValue
+	}
+	switch v := value.(type) {
+	case string:
+		return r.String(v)
+	case error:
+		return r.String(v.Error())
+	case proto.Message:
+		return r.Proto(v)
+	}
+	return value
+}
+
+// Proto returns a copy of msg with sensitive fields masked. Fields are
+// sensitive when they are marked with the debug_redact option or when their
+// name is a configured field name.
+func (r *Redactor) Proto(msg proto.Message) proto.Message {
+	if msg == nil {
+		return nil
+	}
+	clone := proto.Clone(msg)
+	r.redactMessage(clone.ProtoReflect())
+	return clone
+}
+
+func (r *Redactor) redactMessage(m protoreflect.Message) {
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if r.isSensitiveField(fd) {
+			if fd.Kind() == protoreflect.StringKind && !fd.IsList() && !fd.IsMap() {
+				m.Set(fd, protoreflect.ValueOfString(RedactedValue))
+			} else {
+				m.Clear(fd)
+			}
+			return true
+		}
+
+		switch {
+		case fd.IsList() && fd.Message() != nil:
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				r.redactMessage(list.Get(i).Message())
+			}
+		case fd.IsMap() && fd.MapValue().Message() != nil:
+			v.Map().Range(func(_ protoreflect.MapKey, mv protoreflect.Value) bool {
+				r.redactMessage(mv.Message())
+				return true
+			})
+		case !fd.IsList() && !fd.IsMap() && fd.Message() != nil:
+			r.redactMessage(v.Message())
+		case fd.Kind() == protoreflect.StringKind && !fd.IsList() && !fd.IsMap():
+			m.Set(fd, protoreflect.ValueOfString(r.String(v.String())))
+		}
+		return true
+	})
+}
+
+func (r *Redactor) isSensitiveField(fd protoreflect.FieldDescriptor) bool {
+	if opts, ok := fd.Options().(*descriptorpb.FieldOptions); ok && opts.GetDebugRedact() {
+		return true
+	}
+	return r.IsSensitive(string(fd.Name()))
+}
+
+var (
+	redactorMu sync.RWMutex
+	redactor   *Redactor
+)
+
+func init() {
+	redactor, _ = NewRedactor(RedactionOptions{})
+	logger.AddHook(redactHook{})
+}
+
+// SetRedaction replaces the redaction rules of the standard logger.
+func SetRedaction(opts RedactionOptions) error {
+	r, err := NewRedactor(opts)
+	if err != nil {
+		return err
+	}
+	redactorMu.Lock()
+	redactor = r
+	redactorMu.Unlock()
+	return nil
+}
+
+func currentRedactor() *Redactor {
+	redactorMu.RLock()
+	defer redactorMu.RUnlock()
+	return redactor
+}
+
+// Redact masks sensitive patterns in s using the standard logger rules.
+func Redact(s string) string {
+	return currentRedactor().String(s)
+}
+
+// RedactProto returns a copy of msg with sensitive fields masked using the
+// standard logger rules.
+func RedactProto(msg proto.Message) proto.Message {
+	return currentRedactor().Proto(msg)
+}
+
+// redactHook masks sensitive values of every entry before it is formatted.
+type redactHook struct{}
+
+func (redactHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (redactHook) Fire(entry *logrus.Entry) error {
+	r := currentRedactor()
+	entry.Message = r.String(entry.Message)
+	for key, value := range entry.Data {
+		entry.Data[key] = r.Value(key, value)
+	}
+	return nil
+}
@@ -0,0 +1,208 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc/codes"
+)
+
+const namespace = "service"
+
+// UnmatchedRoute labels requests that did not match any route, keeping the
+// route label bounded.
+const UnmatchedRoute = "unmatched"
+
+// Registry holds every collector exposed on the admin /metrics endpoint.
+var Registry = prometheus.NewRegistry()
+
+var (
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "Total number of HTTP requests by route template and status code.",
+	}, []string{"transport", "method", "route", "status"})
+
+	httpErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_request_errors_total",
+		Help:      "Total number of HTTP requests answered with a 4xx or 5xx status code.",
+	}, []string{"transport", "method", "route", "status"})
+
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by route template and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"transport", "method", "route", "status"})
+
+	httpInFlight = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "http_requests_in_flight",
+		Help:      "Number of HTTP requests currently being served.",
+	}, []string{"transport"})
+
+	grpcRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "grpc_requests_total",
+		Help:      "Total number of gRPC requests by method and status code.",
+	}, []string{"method", "code"})
+
+	grpcErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "grpc_request_errors_total",
+		Help:      "Total number of gRPC requests completed with a non OK status code.",
+	}, []string{"method", "code"})
+
+	grpcDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "grpc_request_duration_seconds",
+		Help:      "gRPC request latency by method and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "code"})
+
+	grpcInFlight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "grpc_requests_in_flight",
+		Help:      "Number of gRPC requests currently being served.",
+	})
+
+	dbQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "db_query_duration_seconds",
+		Help:      "Database query latency by operation and table.",
+		Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+	}, []string{"operation", "table"})
+
+	dbSlowQueries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "db_slow_queries_total",
+		Help:      "Total number of queries slower than database.slow_query.threshold by operation and table.",
+	}, []string{"operation", "table"})
+
+	dbReplicaHealthy = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "db_replica_healthy",
+		Help:      "Whether a replica receives reads, 0 while it is ejected.",
+	}, []string{"replica"})
+
+	dbReplicaLag = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "db_replica_lag_seconds",
+		Help:      "Replication lag of a replica measured by the last health check.",
+	}, []string{"replica"})
+
+	configReloads = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "config_reloads_total",
+		Help:      "Total number of configuration reloads by result, success or failure.",
+	}, []string{"result"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequests,
+		httpErrors,
+		httpDuration,
+		httpInFlight,
+		grpcRequests,
+		grpcErrors,
+		grpcDuration,
+		grpcInFlight,
+		dbQueryDuration,
+		dbSlowQueries,
+		dbReplicaHealthy,
+		dbReplicaLag,
+		configReloads,
+	)
+}
+
+// Handler returns the HTTP handler serving the registry in the Prometheus
+// exposition format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
+
+// HTTPStarted records an HTTP request entering the given transport and
+// returns a function that must be called once it completes.
+func HTTPStarted(transport string) func() {
+	gauge := httpInFlight.WithLabelValues(transport)
+	gauge.Inc()
+	return gauge.Dec
+}
+
+// ObserveHTTP records a completed HTTP request. route must be a route
+// template, never a raw path.
+func ObserveHTTP(transport, method, route string, status int, duration time.Duration) {
+	code := strconv.Itoa(status)
+	httpRequests.WithLabelValues(transport, method, route, code).Inc()
+	httpDuration.WithLabelValues(transport, method, route, code).Observe(duration.Seconds())
+	if status >= 400 {
+		httpErrors.WithLabelValues(transport, method, route, code).Inc()
+	}
+}
+
+// GRPCStarted records a gRPC request entering the server and returns a
+// function that must be called once it completes.
+func GRPCStarted() func() {
+	grpcInFlight.Inc()
+	return grpcInFlight.Dec
+}
+
+// ObserveGRPC records a completed gRPC request.
+func ObserveGRPC(method string, code codes.Code, duration time.Duration) {
+	grpcRequests.WithLabelValues(method, code.String()).Inc()
+	grpcDuration.WithLabelValues(method, code.String()).Observe(duration.Seconds())
+	if code != codes.OK {
+		grpcErrors.WithLabelValues(method, code.String()).Inc()
+	}
+}
+
+// ObserveDBQuery records the duration of a database query.
+func ObserveDBQuery(operation, table string, duration time.Duration) {
+	dbQueryDuration.WithLabelValues(operation, table).Observe(duration.Seconds())
+}
+
+// ObserveSlowDBQuery counts a query slower than the slow query threshold.
+func ObserveSlowDBQuery(operation, table string) {
+	dbSlowQueries.WithLabelValues(operation, table).Inc()
+}
+
+// ObserveReplica records the result of a replica health check.
+func ObserveReplica(name string, healthy bool, lag time.Duration) {
+	value := 0.0
+	if healthy {
+		value = 1
+	}
+	dbReplicaHealthy.WithLabelValues(name).Set(value)
+	dbReplicaLag.WithLabelValues(name).Set(lag.Seconds())
+}
+
+// ObserveConfigReload records a configuration reload.
+func ObserveConfigReload(ok bool) {
+	result := "success"
+	if !ok {
+		result = "failure"
+	}
+	configReloads.WithLabelValues(result).Inc()
+}
+
+// RegisterDBStats exposes the connection pool statistics of db labelled
+// with the given name, e.g. primary or replica-0. The returned function
+// removes them once the pool is closed.
+func RegisterDBStats(db *sql.DB, name string) (func(), error) {
+	collector := collectors.NewDBStatsCollector(db, name)
+	if err := Registry.Register(collector); err != nil {
+		return nil, err
+	}
+	return func() {
+		Registry.Unregister(collector)
+	}, nil
+}
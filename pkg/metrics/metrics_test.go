@@ -0,0 +1,75 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+)
+
+// scrape returns the exposition of the registry.
+func scrape(t *testing.T) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /metrics = %d", rec.Code)
+	}
+	return rec.Body.String()
+}
+
+// wantSeries fails the test unless every series is exposed with its value.
+func wantSeries(t *testing.T, exposition string, series ...string) {
+	t.Helper()
+	for _, s := range series {
+		if !strings.Contains(exposition, "\n"+s+"\n") {
+			t.Errorf("missing %s", s)
+		}
+	}
+}
+
+func TestObserveHTTP(t *testing.T) {
+	ObserveHTTP("http", "GET", "/metrics-test/:id", 200, time.Millisecond)
+	ObserveHTTP("http", "GET", "/metrics-test/:id", 200, time.Millisecond)
+	ObserveHTTP("http", "GET", "/metrics-test/:id", 404, time.Millisecond)
+
+	exposition := scrape(t)
+	wantSeries(t, exposition,
+		`service_http_requests_total{method="GET",route="/metrics-test/:id",status="200",transport="http"} 2`,
+		`service_http_requests_total{method="GET",route="/metrics-test/:id",status="404",transport="http"} 1`,
+		`service_http_request_duration_seconds_count{method="GET",route="/metrics-test/:id",status="200",transport="http"} 2`,
+		// only the failures are errors
+		`service_http_request_errors_total{method="GET",route="/metrics-test/:id",status="404",transport="http"} 1`,
+	)
+	if strings.Contains(exposition, `service_http_request_errors_total{method="GET",route="/metrics-test/:id",status="200"`) {
+		t.Error("successful requests are counted as errors")
+	}
+}
+
+func TestObserveGRPC(t *testing.T) {
+	method := "/metrics.Test/Get"
+	ObserveGRPC(method, codes.OK, time.Millisecond)
+	ObserveGRPC(method, codes.NotFound, time.Millisecond)
+	ObserveGRPC(method, codes.NotFound, time.Millisecond)
+
+	exposition := scrape(t)
+	wantSeries(t, exposition,
+		`service_grpc_requests_total{code="OK",method="/metrics.Test/Get"} 1`,
+		`service_grpc_requests_total{code="NotFound",method="/metrics.Test/Get"} 2`,
+		`service_grpc_request_errors_total{code="NotFound",method="/metrics.Test/Get"} 2`,
+		`service_grpc_request_duration_seconds_count{code="NotFound",method="/metrics.Test/Get"} 2`,
+	)
+	if strings.Contains(exposition, `service_grpc_request_errors_total{code="OK",method="/metrics.Test/Get"}`) {
+		t.Error("OK calls are counted as errors")
+	}
+}
+
+func TestInFlight(t *testing.T) {
+	done := HTTPStarted("metrics-test")
+	wantSeries(t, scrape(t), `service_http_requests_in_flight{transport="metrics-test"} 1`)
+	done()
+	wantSeries(t, scrape(t), `service_http_requests_in_flight{transport="metrics-test"} 0`)
+}
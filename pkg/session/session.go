@@ -0,0 +1,17 @@
+package session
+
+import "context"
+
+type idKey struct{}
+
+// ContextWithID returns a copy of ctx carrying the session of the caller.
+// Requests sharing a session read their own writes.
+func ContextWithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, idKey{}, id)
+}
+
+// IDFromContext returns the session of the caller, empty when none was stored.
+func IDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(idKey{}).(string)
+	return id
+}
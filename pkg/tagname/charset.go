@@ -0,0 +1,51 @@
+package tagname
+
+import "unicode"
+
+// Charset is a set of characters allowed in tag names.
+type Charset string
+
+const (
+	// AnyCharacters allows every character.
+	AnyCharacters Charset = "any"
+	// ASCII allows the printable ASCII characters.
+	ASCII Charset = "ascii"
+	// Alphanumeric allows the letters, marks and digits of every script,
+	// spaces and hyphens.
+	Alphanumeric Charset = "alphanumeric"
+)
+
+// Charsets are the known charsets.
+var Charsets = []Charset{AnyCharacters, ASCII, Alphanumeric}
+
+// Valid reports whether c is a known charset.
+func (c Charset) Valid() bool {
+	for _, known := range Charsets {
+		if c == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Disallowed returns the first character of name outside c, false when every
+// character is allowed. Unknown charsets allow every character.
+func (c Charset) Disallowed(name string) (rune, bool) {
+	for _, r := range name {
+		if !c.allows(r) {
+			return r, true
+		}
+	}
+	return 0, false
+}
+
+func (c Charset) allows(r rune) bool {
+	switch c {
+	case ASCII:
+		return r >= ' ' && r <= '~'
+	case Alphanumeric:
+		return unicode.IsLetter(r) || unicode.IsMark(r) || unicode.IsDigit(r) || r == ' ' || r == '-'
+	default:
+		return true
+	}
+}
@@ -0,0 +1,126 @@
+// Package tagname normalizes tag names and derives their slugs.
+package tagname
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// MaxSlugLength bounds the length of a slug in bytes.
+const MaxSlugLength = 100
+
+// Policy selects the steps of Normalize.
+type Policy struct {
+	// Trim removes the leading and trailing whitespace.
+	Trim bool
+	// CollapseWhitespace replaces the runs of whitespace with a single space.
+	CollapseWhitespace bool
+	// NFC composes the characters in Unicode Normalization Form C.
+	NFC bool
+	// CaseFold folds the case, close to lowering it.
+	CaseFold bool
+}
+
+// Normalize applies the steps of policy to name, in order: trimming,
+// collapsing whitespace, NFC and case folding.
+func Normalize(name string, policy Policy) string {
+	if policy.Trim {
+		name = strings.TrimSpace(name)
+	}
+	if policy.CollapseWhitespace {
+		name = collapseWhitespace(name)
+	}
+	if policy.NFC {
+		name = norm.NFC.String(name)
+	}
+	if policy.CaseFold {
+		name = cases.Fold().String(name)
+	}
+	return name
+}
+
+func collapseWhitespace(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	space := false
+	for _, r := range s {
+		if unicode.IsSpace(r) {
+			space = true
+			continue
+		}
+		if space && b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		space = false
+		b.WriteRune(r)
+	}
+	// keep the trailing whitespace when it is not trimmed
+	if space {
+		b.WriteByte(' ')
+	}
+	return b.String()
+}
+
+// symbols are spelled out in slugs, so that "C++" and "C#" do not both
+// become "c".
+var symbols = map[rune]string{
+	'+': "plus",
+	'#': "sharp",
+	'&': "and",
+	'@': "at",
+}
+
+// Slug returns the URL path segment identifying name: lower case letters and
+// digits separated by single hyphens. Latin letters lose their diacritics,
+// the letters and marks of other scripts are kept. It is empty when name has
+// no letter, digit or spelled out symbol.
+func Slug(name string) string {
+	var b strings.Builder
+	hyphen := false
+	write := func(s string) {
+		if hyphen && b.Len() > 0 {
+			b.WriteByte('-')
+		}
+		hyphen = false
+		b.WriteString(s)
+	}
+	// base is the last letter or digit written
+	var base rune
+	for _, r := range norm.NFKD.String(cases.Fold().String(name)) {
+		switch {
+		case unicode.IsMark(r):
+			// marks decomposed by NFKD, e.g. the dakuten of "ガ", are
+			// composed again by NFC below, Latin diacritics are dropped
+			if base != 0 && !unicode.Is(unicode.Latin, base) {
+				b.WriteRune(r)
+			}
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			write(string(r))
+			base = r
+		case symbols[r] != "":
+			hyphen = true
+			write(symbols[r])
+			hyphen, base = true, 0
+		default:
+			hyphen, base = true, 0
+		}
+	}
+	return truncate(norm.NFC.String(b.String()), MaxSlugLength)
+}
+
+// truncate cuts s to at most n bytes on a rune boundary, without a trailing
+// hyphen.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	cut := n
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return strings.TrimRight(s[:cut], "-")
+}
@@ -0,0 +1,82 @@
+package timezone
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+var defaultLocation atomic.Value // *time.Location
+
+type locationKey struct{}
+
+// SetDefault sets the server timezone used when a request has no preference.
+// It never changes time.Local.
+func SetDefault(loc *time.Location) {
+	defaultLocation.Store(loc)
+}
+
+// Default returns the server timezone, UTC unless set with SetDefault.
+func Default() *time.Location {
+	if loc, ok := defaultLocation.Load().(*time.Location); ok {
+		return loc
+	}
+	return time.UTC
+}
+
+// Load returns the location with the given IANA name. An empty name returns
+// the server timezone.
+func Load(name string) (*time.Location, error) {
+	if name == "" {
+		return Default(), nil
+	}
+	return time.LoadLocation(name)
+}
+
+// ContextWithLocation returns a copy of ctx carrying the timezone preferred by
+// the caller.
+func ContextWithLocation(ctx context.Context, loc *time.Location) context.Context {
+	return context.WithValue(ctx, locationKey{}, loc)
+}
+
+// FromContext returns the timezone preferred by the caller, or the server
+// timezone.
+func FromContext(ctx context.Context) *time.Location {
+	if loc, ok := ctx.Value(locationKey{}).(*time.Location); ok {
+		return loc
+	}
+	return Default()
+}
+
+// Now returns the current time in UTC. It is used for every persisted
+// timestamp.
+func Now() time.Time {
+	return time.Now().UTC()
+}
+
+// Format formats t in UTC as RFC 3339 with nanoseconds, so it parses back to
+// the same instant. The zero time formats as an empty string.
+func Format(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// FormatInContext formats t as RFC 3339 in the timezone preferred by the
+// caller, for display only.
+func FormatInContext(ctx context.Context, t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.In(FromContext(ctx)).Format(time.RFC3339)
+}
+
+// Parse parses an RFC 3339 timestamp and normalizes it to UTC.
+func Parse(value string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.UTC(), nil
+}
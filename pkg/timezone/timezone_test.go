@@ -0,0 +1,94 @@
+package timezone
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func mustLoad(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return loc
+}
+
+// useDefault sets the server timezone until the end of the test.
+func useDefault(t *testing.T, loc *time.Location) {
+	previous := Default()
+	SetDefault(loc)
+	t.Cleanup(func() { SetDefault(previous) })
+}
+
+func TestFormatParseRoundTrip(t *testing.T) {
+	useDefault(t, mustLoad(t, "Asia/Tokyo"))
+	// a wall clock time of a zone with a half hour offset
+	in := time.Date(2026, 3, 29, 1, 30, 0, 123456789, mustLoad(t, "Asia/Kolkata"))
+
+	formatted := Format(in)
+	if formatted != "2026-03-28T20:00:00.123456789Z" {
+		t.Fatalf("Format() = %q, want UTC with nanoseconds", formatted)
+	}
+	out, err := Parse(formatted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !out.Equal(in) || out.Location() != time.UTC {
+		t.Fatalf("Parse(Format(t)) = %v, want %v in UTC", out, in)
+	}
+
+	// offsets are normalized to UTC too
+	out, err = Parse("2026-03-29T01:30:00.123456789+05:30")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !out.Equal(in) || out.Location() != time.UTC {
+		t.Fatalf("Parse() = %v, want %v in UTC", out, in)
+	}
+}
+
+func TestTimestampRoundTrip(t *testing.T) {
+	in := time.Date(2026, 10, 25, 2, 30, 0, 987654321, mustLoad(t, "Europe/Paris"))
+
+	ts := timestamppb.New(in)
+	if err := ts.CheckValid(); err != nil {
+		t.Fatal(err)
+	}
+	out, err := Parse(Format(ts.AsTime()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !out.Equal(in) {
+		t.Fatalf("got %v through a Timestamp, want %v", out, in)
+	}
+	if back := timestamppb.New(out); back.Seconds != ts.Seconds || back.Nanos != ts.Nanos {
+		t.Fatalf("got Timestamp %v, want %v", back, ts)
+	}
+}
+
+func TestFormatInContext(t *testing.T) {
+	useDefault(t, mustLoad(t, "Asia/Tokyo"))
+	at := time.Date(2026, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	if got := FormatInContext(context.Background(), at); got != "2026-01-15T21:00:00+09:00" {
+		t.Errorf("got %q in the server timezone", got)
+	}
+	ctx := ContextWithLocation(context.Background(), mustLoad(t, "America/New_York"))
+	if got := FormatInContext(ctx, at); got != "2026-01-15T07:00:00-05:00" {
+		t.Errorf("got %q in the preferred timezone", got)
+	}
+	if got := FormatInContext(ctx, time.Time{}); got != "" {
+		t.Errorf("got %q for the zero time", got)
+	}
+}
+
+func TestNowIsUTC(t *testing.T) {
+	useDefault(t, mustLoad(t, "Asia/Tokyo"))
+	if loc := Now().Location(); loc != time.UTC {
+		t.Fatalf("Now() is in %v, want UTC", loc)
+	}
+}
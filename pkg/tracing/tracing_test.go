@@ -0,0 +1,71 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestNewProviderRecordsChildren(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracer := NewProvider(recorder, resource.Empty(), 1).Tracer("test")
+
+	ctx, parent := tracer.Start(context.Background(), "parent")
+	_, child := tracer.Start(ctx, "child")
+	child.End()
+	parent.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want 2", len(spans))
+	}
+	if spans[0].Name() != "child" || spans[0].Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Fatalf("the child span is not linked to its parent")
+	}
+	if spans[0].SpanContext().TraceID() != parent.SpanContext().TraceID() {
+		t.Fatalf("the child span is in another trace")
+	}
+}
+
+func TestNewProviderRespectsParentSampling(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	// new traces are never sampled
+	tracer := NewProvider(recorder, resource.Empty(), 0).Tracer("test")
+
+	_, root := tracer.Start(context.Background(), "root")
+	root.End()
+	if n := len(recorder.Ended()); n != 0 {
+		t.Fatalf("got %d spans with a zero ratio, want 0", n)
+	}
+
+	// a sampled caller, e.g. propagated by traceparent, is always followed
+	remote := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{1},
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	})
+	_, span := tracer.Start(trace.ContextWithRemoteSpanContext(context.Background(), remote), "server")
+	span.End()
+	spans := recorder.Ended()
+	if len(spans) != 1 || spans[0].Parent().SpanID() != remote.SpanID() {
+		t.Fatalf("the span of a sampled parent was not recorded")
+	}
+}
+
+func TestSetupExporters(t *testing.T) {
+	shutdown, err := Setup(context.Background(), Options{Exporter: ExporterNone})
+	if err != nil {
+		t.Fatalf("Setup() error: %v", err)
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown error: %v", err)
+	}
+
+	if _, err := Setup(context.Background(), Options{Exporter: "zipkin"}); err == nil {
+		t.Fatal("Setup() accepted an unknown exporter")
+	}
+}
@@ -0,0 +1,189 @@
+// Package trigram measures how similar two strings are by the trigrams they
+// share, following the semantics of the Postgres pg_trgm extension so the
+// results match on every database driver.
+package trigram
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Span is a half-open range [Start, End) of character offsets in a string.
+type Span struct {
+	Start int
+	End   int
+}
+
+// trigram is one trigram of a word along with the offsets, in the original
+// string, of the characters it covers.
+type trigram struct {
+	value string
+	span  Span
+}
+
+// extract returns the trigrams of s in order. Like pg_trgm, s is lowered and
+// split into words of letters and digits, each padded with two spaces in
+// front and one behind.
+func extract(s string) []trigram {
+	var trigrams []trigram
+	runes := []rune(strings.ToLower(s))
+	for start := 0; start < len(runes); {
+		if !isWordRune(runes[start]) {
+			start++
+			continue
+		}
+		end := start
+		for end < len(runes) && isWordRune(runes[end]) {
+			end++
+		}
+
+		padded := append([]rune("  "), runes[start:end]...)
+		padded = append(padded, ' ')
+		for i := 0; i+3 <= len(padded); i++ {
+			// padded[i] is the character at start+i-2 of the string
+			span := Span{Start: max(start+i-2, start), End: min(start+i+1, end)}
+			trigrams = append(trigrams, trigram{value: string(padded[i : i+3]), span: span})
+		}
+		start = end
+	}
+	return trigrams
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// set returns the distinct values of trigrams.
+func set(trigrams []trigram) map[string]bool {
+	values := make(map[string]bool, len(trigrams))
+	for _, t := range trigrams {
+		values[t.value] = true
+	}
+	return values
+}
+
+// Trigrams returns the distinct trigrams of s in order, padded like pg_trgm.
+func Trigrams(s string) []string {
+	var values []string
+	seen := map[string]bool{}
+	for _, t := range extract(s) {
+		if !seen[t.value] {
+			seen[t.value] = true
+			values = append(values, t.value)
+		}
+	}
+	return values
+}
+
+// Similarity returns the share of the trigrams of a and b found in both,
+// between 0 and 1, as pg_trgm similarity(a, b).
+func Similarity(a, b string) float64 {
+	setA, setB := set(extract(a)), set(extract(b))
+	if len(setA) == 0 || len(setB) == 0 {
+		return 0
+	}
+	shared := 0
+	for value := range setA {
+		if setB[value] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(setA)+len(setB)-shared)
+}
+
+// WordSimilarity returns the greatest similarity between the trigrams of
+// query and a continuous run of the trigrams of text, as pg_trgm
+// word_similarity(query, text). It is high when query is close to a part of
+// text, e.g. a word with a typo.
+func WordSimilarity(query, text string) float64 {
+	queryTrigrams := set(extract(query))
+	textTrigrams := extract(text)
+	if len(queryTrigrams) == 0 || len(textTrigrams) == 0 {
+		return 0
+	}
+
+	best := 0.0
+	for i := range textTrigrams {
+		// a run starting with a trigram missing from query is never better
+		// than the same run without it
+		if !queryTrigrams[textTrigrams[i].value] {
+			continue
+		}
+		seen := map[string]bool{}
+		shared, extra := 0, 0
+		for _, t := range textTrigrams[i:] {
+			if seen[t.value] {
+				continue
+			}
+			seen[t.value] = true
+			if !queryTrigrams[t.value] {
+				extra++
+				continue
+			}
+			shared++
+			if score := float64(shared) / float64(len(queryTrigrams)+extra); score > best {
+				best = score
+			}
+		}
+	}
+	return best
+}
+
+// Highlights returns the spans of text matching query, as character offsets.
+// It is the first occurrence of query when text contains it, ignoring case,
+// otherwise the characters covered by the trigrams shared with query.
+func Highlights(query, text string) []Span {
+	lowerQuery, lowerText := []rune(strings.ToLower(query)), []rune(strings.ToLower(text))
+	if i := indexRunes(lowerText, lowerQuery); i >= 0 && len(lowerQuery) > 0 {
+		return []Span{{Start: i, End: i + len(lowerQuery)}}
+	}
+
+	queryTrigrams := set(extract(query))
+	covered := make([]bool, len(lowerText))
+	for _, t := range extract(text) {
+		if !queryTrigrams[t.value] {
+			continue
+		}
+		for i := t.span.Start; i < t.span.End && i < len(covered); i++ {
+			covered[i] = true
+		}
+	}
+
+	var spans []Span
+	for i := 0; i < len(covered); i++ {
+		if !covered[i] {
+			continue
+		}
+		start := i
+		for i < len(covered) && covered[i] {
+			i++
+		}
+		spans = append(spans, Span{Start: start, End: i})
+	}
+	return spans
+}
+
+// indexRunes returns the offset of the first occurrence of sub in s, -1 when
+// it is missing.
+func indexRunes(s, sub []rune) int {
+	for i := 0; i+len(sub) <= len(s); i++ {
+		if string(s[i:i+len(sub)]) == string(sub) {
+			return i
+		}
+	}
+	return -1
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -84,6 +84,10 @@ func GRPCErrorHandler(c *gin.Context, err error) {
 			c.JSON(http.StatusBadRequest, gin.H{"error": grpcStatus.Message()})
 		case codes.NotFound:
 			c.JSON(http.StatusNotFound, gin.H{"error": grpcStatus.Message()})
+		case codes.AlreadyExists:
+			c.JSON(http.StatusConflict, gin.H{"error": grpcStatus.Message()})
+		case codes.FailedPrecondition:
+			c.JSON(http.StatusBadRequest, gin.H{"error": grpcStatus.Message()})
 		case codes.PermissionDenied:
 			c.JSON(http.StatusForbidden, gin.H{"error": grpcStatus.Message()})
 		case codes.Internal:
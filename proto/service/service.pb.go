@@ -35,96 +35,385 @@ var file_service_service_proto_rawDesc = []byte{
 	0x74, 0x6f, 0x63, 0x2d, 0x67, 0x65, 0x6e, 0x2d, 0x6f, 0x70, 0x65, 0x6e, 0x61, 0x70, 0x69, 0x76,
 	0x32, 0x2f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61,
 	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0d, 0x74, 0x61, 0x67,
-	0x2f, 0x74, 0x61, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0xbe, 0x05, 0x0a, 0x07, 0x53,
-	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x84, 0x01, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x54, 0x61,
+	0x2f, 0x74, 0x61, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0x92, 0x23, 0x0a, 0x07, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x8f, 0x01, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x54, 0x61,
 	0x67, 0x73, 0x12, 0x11, 0x2e, 0x74, 0x61, 0x67, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x61, 0x67, 0x73,
 	0x51, 0x75, 0x65, 0x72, 0x79, 0x1a, 0x14, 0x2e, 0x74, 0x61, 0x67, 0x2e, 0x47, 0x65, 0x74, 0x54,
-	0x61, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x50, 0x92, 0x41, 0x39,
+	0x61, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x5b, 0x92, 0x41, 0x44,
 	0x0a, 0x04, 0x54, 0x61, 0x67, 0x73, 0x12, 0x08, 0x47, 0x65, 0x74, 0x20, 0x74, 0x61, 0x67, 0x73,
-	0x1a, 0x15, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x20, 0x74, 0x61, 0x67, 0x73, 0x20,
-	0x62, 0x79, 0x20, 0x6e, 0x61, 0x6d, 0x65, 0x3a, 0x10, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x6a, 0x73, 0x6f, 0x6e, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0e, 0x12,
-	0x0c, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x74, 0x61, 0x67, 0x73, 0x12, 0x79, 0x0a,
-	0x0a, 0x47, 0x65, 0x74, 0x54, 0x61, 0x67, 0x42, 0x79, 0x49, 0x64, 0x12, 0x0a, 0x2e, 0x74, 0x61,
-	0x67, 0x2e, 0x54, 0x61, 0x67, 0x49, 0x64, 0x1a, 0x08, 0x2e, 0x74, 0x61, 0x67, 0x2e, 0x54, 0x61,
-	0x67, 0x22, 0x55, 0x92, 0x41, 0x39, 0x0a, 0x04, 0x54, 0x61, 0x67, 0x73, 0x12, 0x09, 0x47, 0x65,
-	0x74, 0x20, 0x61, 0x20, 0x74, 0x61, 0x67, 0x1a, 0x14, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76,
-	0x65, 0x20, 0x61, 0x20, 0x74, 0x61, 0x67, 0x20, 0x62, 0x79, 0x20, 0x69, 0x64, 0x3a, 0x10, 0x61,
+	0x1a, 0x20, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x20, 0x74, 0x61, 0x67, 0x73, 0x20,
+	0x62, 0x79, 0x20, 0x6e, 0x61, 0x6d, 0x65, 0x20, 0x61, 0x6e, 0x64, 0x20, 0x70, 0x61, 0x72, 0x65,
+	0x6e, 0x74, 0x3a, 0x10, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f,
+	0x6a, 0x73, 0x6f, 0x6e, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0e, 0x12, 0x0c, 0x2f, 0x61, 0x70, 0x69,
+	0x2f, 0x76, 0x31, 0x2f, 0x74, 0x61, 0x67, 0x73, 0x12, 0xa7, 0x01, 0x0a, 0x0a, 0x53, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x54, 0x61, 0x67, 0x73, 0x12, 0x14, 0x2e, 0x74, 0x61, 0x67, 0x2e, 0x53, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x54, 0x61, 0x67, 0x73, 0x51, 0x75, 0x65, 0x72, 0x79, 0x1a, 0x17, 0x2e,
+	0x74, 0x61, 0x67, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x6a, 0x92, 0x41, 0x4c, 0x0a, 0x04, 0x54, 0x61, 0x67,
+	0x73, 0x12, 0x0b, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x20, 0x74, 0x61, 0x67, 0x73, 0x1a, 0x25,
+	0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x20, 0x74, 0x61, 0x67, 0x73, 0x20, 0x62, 0x79, 0x20, 0x6e,
+	0x61, 0x6d, 0x65, 0x2c, 0x20, 0x74, 0x6f, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x20,
+	0x74, 0x79, 0x70, 0x6f, 0x73, 0x3a, 0x10, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x2f, 0x6a, 0x73, 0x6f, 0x6e, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x15, 0x12, 0x13, 0x2f,
+	0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x74, 0x61, 0x67, 0x73, 0x3a, 0x73, 0x65, 0x61, 0x72,
+	0x63, 0x68, 0x12, 0xdd, 0x01, 0x0a, 0x10, 0x41, 0x75, 0x74, 0x6f, 0x63, 0x6f, 0x6d, 0x70, 0x6c,
+	0x65, 0x74, 0x65, 0x54, 0x61, 0x67, 0x73, 0x12, 0x1a, 0x2e, 0x74, 0x61, 0x67, 0x2e, 0x41, 0x75,
+	0x74, 0x6f, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x61, 0x67, 0x73, 0x51, 0x75,
+	0x65, 0x72, 0x79, 0x1a, 0x1d, 0x2e, 0x74, 0x61, 0x67, 0x2e, 0x41, 0x75, 0x74, 0x6f, 0x63, 0x6f,
+	0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x8d, 0x01, 0x92, 0x41, 0x69, 0x0a, 0x04, 0x54, 0x61, 0x67, 0x73, 0x12, 0x11,
+	0x41, 0x75, 0x74, 0x6f, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x20, 0x74, 0x61, 0x67,
+	0x73, 0x1a, 0x3c, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x20, 0x74, 0x68, 0x65, 0x20, 0x74,
+	0x61, 0x67, 0x73, 0x20, 0x73, 0x74, 0x61, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x20, 0x77, 0x69, 0x74,
+	0x68, 0x20, 0x61, 0x20, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x2c, 0x20, 0x74, 0x68, 0x65, 0x20,
+	0x6d, 0x6f, 0x73, 0x74, 0x20, 0x75, 0x73, 0x65, 0x64, 0x20, 0x66, 0x69, 0x72, 0x73, 0x74, 0x3a,
+	0x10, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x6a, 0x73, 0x6f,
+	0x6e, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1b, 0x12, 0x19, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31,
+	0x2f, 0x74, 0x61, 0x67, 0x73, 0x3a, 0x61, 0x75, 0x74, 0x6f, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65,
+	0x74, 0x65, 0x12, 0xb0, 0x02, 0x0a, 0x0e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x54, 0x61, 0x67,
+	0x55, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x2e, 0x74, 0x61, 0x67, 0x2e, 0x52, 0x65, 0x63, 0x6f,
+	0x72, 0x64, 0x54, 0x61, 0x67, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x08, 0x2e, 0x74, 0x61, 0x67, 0x2e, 0x54, 0x61, 0x67, 0x22, 0xf7, 0x01, 0x92, 0x41,
+	0xd1, 0x01, 0x0a, 0x04, 0x54, 0x61, 0x67, 0x73, 0x12, 0x10, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x20, 0x74, 0x61, 0x67, 0x20, 0x75, 0x73, 0x61, 0x67, 0x65, 0x1a, 0x92, 0x01, 0x41, 0x64, 0x64,
+	0x20, 0x74, 0x6f, 0x20, 0x74, 0x68, 0x65, 0x20, 0x75, 0x73, 0x61, 0x67, 0x65, 0x20, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x20, 0x6f, 0x66, 0x20, 0x61, 0x20, 0x74, 0x61, 0x67, 0x2c, 0x20, 0x77, 0x68,
+	0x69, 0x63, 0x68, 0x20, 0x72, 0x61, 0x6e, 0x6b, 0x73, 0x20, 0x74, 0x68, 0x65, 0x20, 0x61, 0x75,
+	0x74, 0x6f, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x20, 0x43,
+	0x61, 0x6c, 0x6c, 0x65, 0x64, 0x20, 0x62, 0x79, 0x20, 0x74, 0x68, 0x65, 0x20, 0x73, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x73, 0x20, 0x74, 0x61, 0x67, 0x67, 0x69, 0x6e, 0x67, 0x20, 0x69, 0x74,
+	0x65, 0x6d, 0x73, 0x2c, 0x20, 0x77, 0x69, 0x74, 0x68, 0x20, 0x61, 0x20, 0x6e, 0x65, 0x67, 0x61,
+	0x74, 0x69, 0x76, 0x65, 0x20, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x20, 0x77, 0x68, 0x65, 0x6e, 0x20,
+	0x74, 0x68, 0x65, 0x79, 0x20, 0x75, 0x6e, 0x74, 0x61, 0x67, 0x20, 0x74, 0x68, 0x65, 0x6d, 0x32,
+	0x10, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x6a, 0x73, 0x6f,
+	0x6e, 0x3a, 0x10, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x6a,
+	0x73, 0x6f, 0x6e, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1c, 0x3a, 0x01, 0x2a, 0x22, 0x17, 0x2f, 0x61,
+	0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x74, 0x61, 0x67, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x2f,
+	0x75, 0x73, 0x61, 0x67, 0x65, 0x12, 0xbb, 0x01, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x54, 0x61, 0x67,
+	0x42, 0x79, 0x49, 0x64, 0x12, 0x0a, 0x2e, 0x74, 0x61, 0x67, 0x2e, 0x54, 0x61, 0x67, 0x49, 0x64,
+	0x1a, 0x08, 0x2e, 0x74, 0x61, 0x67, 0x2e, 0x54, 0x61, 0x67, 0x22, 0x96, 0x01, 0x92, 0x41, 0x7a,
+	0x0a, 0x04, 0x54, 0x61, 0x67, 0x73, 0x12, 0x09, 0x47, 0x65, 0x74, 0x20, 0x61, 0x20, 0x74, 0x61,
+	0x67, 0x1a, 0x55, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x20, 0x61, 0x20, 0x74, 0x61,
+	0x67, 0x20, 0x62, 0x79, 0x20, 0x69, 0x64, 0x2c, 0x20, 0x74, 0x68, 0x65, 0x20, 0x69, 0x64, 0x20,
+	0x6f, 0x66, 0x20, 0x61, 0x6e, 0x20, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x20, 0x72, 0x65, 0x73, 0x6f,
+	0x6c, 0x76, 0x65, 0x73, 0x20, 0x74, 0x6f, 0x20, 0x69, 0x74, 0x73, 0x20, 0x74, 0x61, 0x67, 0x20,
+	0x77, 0x69, 0x74, 0x68, 0x20, 0x72, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f,
+	0x66, 0x72, 0x6f, 0x6d, 0x20, 0x73, 0x65, 0x74, 0x3a, 0x10, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x6a, 0x73, 0x6f, 0x6e, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x13,
+	0x12, 0x11, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x74, 0x61, 0x67, 0x73, 0x2f, 0x7b,
+	0x69, 0x64, 0x7d, 0x12, 0xf0, 0x01, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x54, 0x61, 0x67, 0x42, 0x79,
+	0x53, 0x6c, 0x75, 0x67, 0x12, 0x0c, 0x2e, 0x74, 0x61, 0x67, 0x2e, 0x54, 0x61, 0x67, 0x53, 0x6c,
+	0x75, 0x67, 0x1a, 0x08, 0x2e, 0x74, 0x61, 0x67, 0x2e, 0x54, 0x61, 0x67, 0x22, 0xc7, 0x01, 0x92,
+	0x41, 0xa0, 0x01, 0x0a, 0x04, 0x54, 0x61, 0x67, 0x73, 0x12, 0x11, 0x47, 0x65, 0x74, 0x20, 0x61,
+	0x20, 0x74, 0x61, 0x67, 0x20, 0x62, 0x79, 0x20, 0x73, 0x6c, 0x75, 0x67, 0x1a, 0x73, 0x52, 0x65,
+	0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x20, 0x61, 0x20, 0x74, 0x61, 0x67, 0x20, 0x62, 0x79, 0x20,
+	0x74, 0x68, 0x65, 0x20, 0x73, 0x6c, 0x75, 0x67, 0x20, 0x64, 0x65, 0x72, 0x69, 0x76, 0x65, 0x64,
+	0x20, 0x66, 0x72, 0x6f, 0x6d, 0x20, 0x69, 0x74, 0x73, 0x20, 0x6e, 0x61, 0x6d, 0x65, 0x2c, 0x20,
+	0x74, 0x68, 0x65, 0x20, 0x73, 0x6c, 0x75, 0x67, 0x20, 0x6f, 0x66, 0x20, 0x61, 0x6e, 0x20, 0x61,
+	0x6c, 0x69, 0x61, 0x73, 0x20, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x73, 0x20, 0x74, 0x6f,
+	0x20, 0x69, 0x74, 0x73, 0x20, 0x74, 0x61, 0x67, 0x20, 0x77, 0x69, 0x74, 0x68, 0x20, 0x72, 0x65,
+	0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x20, 0x73, 0x65,
+	0x74, 0x3a, 0x10, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x6a,
+	0x73, 0x6f, 0x6e, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1d, 0x12, 0x1b, 0x2f, 0x61, 0x70, 0x69, 0x2f,
+	0x76, 0x31, 0x2f, 0x74, 0x61, 0x67, 0x73, 0x2f, 0x62, 0x79, 0x2d, 0x73, 0x6c, 0x75, 0x67, 0x2f,
+	0x7b, 0x73, 0x6c, 0x75, 0x67, 0x7d, 0x12, 0x8a, 0x01, 0x0a, 0x07, 0x53, 0x61, 0x76, 0x65, 0x54,
+	0x61, 0x67, 0x12, 0x13, 0x2e, 0x74, 0x61, 0x67, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x54, 0x61, 0x67,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x08, 0x2e, 0x74, 0x61, 0x67, 0x2e, 0x54, 0x61,
+	0x67, 0x22, 0x60, 0x92, 0x41, 0x46, 0x0a, 0x04, 0x54, 0x61, 0x67, 0x73, 0x12, 0x08, 0x53, 0x61,
+	0x76, 0x65, 0x20, 0x74, 0x61, 0x67, 0x1a, 0x10, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x20, 0x61,
+	0x20, 0x6e, 0x65, 0x77, 0x20, 0x74, 0x61, 0x67, 0x32, 0x10, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x6a, 0x73, 0x6f, 0x6e, 0x3a, 0x10, 0x61, 0x70, 0x70, 0x6c,
+	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x6a, 0x73, 0x6f, 0x6e, 0x82, 0xd3, 0xe4, 0x93,
+	0x02, 0x11, 0x3a, 0x01, 0x2a, 0x22, 0x0c, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x74,
+	0x61, 0x67, 0x73, 0x12, 0xaa, 0x01, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x54, 0x61, 0x67, 0x41, 0x6c,
+	0x69, 0x61, 0x73, 0x65, 0x73, 0x12, 0x0a, 0x2e, 0x74, 0x61, 0x67, 0x2e, 0x54, 0x61, 0x67, 0x49,
+	0x64, 0x1a, 0x0f, 0x2e, 0x74, 0x61, 0x67, 0x2e, 0x54, 0x61, 0x67, 0x41, 0x6c, 0x69, 0x61, 0x73,
+	0x65, 0x73, 0x22, 0x7c, 0x92, 0x41, 0x58, 0x0a, 0x04, 0x54, 0x61, 0x67, 0x73, 0x12, 0x10, 0x4c,
+	0x69, 0x73, 0x74, 0x20, 0x74, 0x61, 0x67, 0x20, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x65, 0x73, 0x1a,
+	0x2c, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x20, 0x74, 0x68, 0x65, 0x20, 0x61, 0x6c,
+	0x69, 0x61, 0x73, 0x65, 0x73, 0x20, 0x6f, 0x66, 0x20, 0x61, 0x20, 0x74, 0x61, 0x67, 0x20, 0x73,
+	0x6f, 0x72, 0x74, 0x65, 0x64, 0x20, 0x62, 0x79, 0x20, 0x6e, 0x61, 0x6d, 0x65, 0x3a, 0x10, 0x61,
 	0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x6a, 0x73, 0x6f, 0x6e, 0x82,
-	0xd3, 0xe4, 0x93, 0x02, 0x13, 0x12, 0x11, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x74,
-	0x61, 0x67, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x12, 0x8a, 0x01, 0x0a, 0x07, 0x53, 0x61, 0x76,
-	0x65, 0x54, 0x61, 0x67, 0x12, 0x13, 0x2e, 0x74, 0x61, 0x67, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x54,
-	0x61, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x08, 0x2e, 0x74, 0x61, 0x67, 0x2e,
-	0x54, 0x61, 0x67, 0x22, 0x60, 0x92, 0x41, 0x46, 0x0a, 0x04, 0x54, 0x61, 0x67, 0x73, 0x12, 0x08,
-	0x53, 0x61, 0x76, 0x65, 0x20, 0x74, 0x61, 0x67, 0x1a, 0x10, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
-	0x20, 0x61, 0x20, 0x6e, 0x65, 0x77, 0x20, 0x74, 0x61, 0x67, 0x32, 0x10, 0x61, 0x70, 0x70, 0x6c,
-	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x6a, 0x73, 0x6f, 0x6e, 0x3a, 0x10, 0x61, 0x70,
-	0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x6a, 0x73, 0x6f, 0x6e, 0x82, 0xd3,
-	0xe4, 0x93, 0x02, 0x11, 0x3a, 0x01, 0x2a, 0x22, 0x0c, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31,
-	0x2f, 0x74, 0x61, 0x67, 0x73, 0x12, 0x9b, 0x01, 0x0a, 0x09, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
-	0x54, 0x61, 0x67, 0x12, 0x15, 0x2e, 0x74, 0x61, 0x67, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
-	0x54, 0x61, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x08, 0x2e, 0x74, 0x61, 0x67,
-	0x2e, 0x54, 0x61, 0x67, 0x22, 0x6d, 0x92, 0x41, 0x4e, 0x0a, 0x04, 0x54, 0x61, 0x67, 0x73, 0x12,
-	0x0a, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x20, 0x74, 0x61, 0x67, 0x1a, 0x16, 0x55, 0x70, 0x64,
-	0x61, 0x74, 0x65, 0x20, 0x61, 0x6e, 0x20, 0x65, 0x78, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x20,
-	0x74, 0x61, 0x67, 0x32, 0x10, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x2f, 0x6a, 0x73, 0x6f, 0x6e, 0x3a, 0x10, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x2f, 0x6a, 0x73, 0x6f, 0x6e, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x16, 0x3a, 0x01, 0x2a,
-	0x1a, 0x11, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x74, 0x61, 0x67, 0x73, 0x2f, 0x7b,
-	0x69, 0x64, 0x7d, 0x12, 0x85, 0x01, 0x0a, 0x09, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x61,
-	0x67, 0x12, 0x0a, 0x2e, 0x74, 0x61, 0x67, 0x2e, 0x54, 0x61, 0x67, 0x49, 0x64, 0x1a, 0x16, 0x2e,
-	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x54, 0x92, 0x41, 0x38, 0x0a, 0x04, 0x54, 0x61, 0x67, 0x73,
-	0x12, 0x0a, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x20, 0x74, 0x61, 0x67, 0x1a, 0x12, 0x52, 0x65,
-	0x6d, 0x6f, 0x76, 0x65, 0x20, 0x61, 0x20, 0x74, 0x61, 0x67, 0x20, 0x62, 0x79, 0x20, 0x49, 0x44,
+	0xd3, 0xe4, 0x93, 0x02, 0x1b, 0x12, 0x19, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x74,
+	0x61, 0x67, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x2f, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x65, 0x73,
+	0x12, 0xcb, 0x01, 0x0a, 0x0b, 0x41, 0x64, 0x64, 0x54, 0x61, 0x67, 0x41, 0x6c, 0x69, 0x61, 0x73,
+	0x12, 0x17, 0x2e, 0x74, 0x61, 0x67, 0x2e, 0x41, 0x64, 0x64, 0x54, 0x61, 0x67, 0x41, 0x6c, 0x69,
+	0x61, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0d, 0x2e, 0x74, 0x61, 0x67, 0x2e,
+	0x54, 0x61, 0x67, 0x41, 0x6c, 0x69, 0x61, 0x73, 0x22, 0x93, 0x01, 0x92, 0x41, 0x68, 0x0a, 0x04,
+	0x54, 0x61, 0x67, 0x73, 0x12, 0x0f, 0x41, 0x64, 0x64, 0x20, 0x61, 0x20, 0x74, 0x61, 0x67, 0x20,
+	0x61, 0x6c, 0x69, 0x61, 0x73, 0x1a, 0x2b, 0x41, 0x64, 0x64, 0x20, 0x61, 0x6e, 0x20, 0x61, 0x6c,
+	0x74, 0x65, 0x72, 0x6e, 0x61, 0x74, 0x69, 0x76, 0x65, 0x20, 0x6e, 0x61, 0x6d, 0x65, 0x20, 0x72,
+	0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x20, 0x74, 0x6f, 0x20, 0x74, 0x68, 0x65, 0x20, 0x74,
+	0x61, 0x67, 0x32, 0x10, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f,
+	0x6a, 0x73, 0x6f, 0x6e, 0x3a, 0x10, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x2f, 0x6a, 0x73, 0x6f, 0x6e, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x22, 0x3a, 0x01, 0x2a, 0x22,
+	0x1d, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x74, 0x61, 0x67, 0x73, 0x2f, 0x7b, 0x74,
+	0x61, 0x67, 0x5f, 0x69, 0x64, 0x7d, 0x2f, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x65, 0x73, 0x12, 0xb4,
+	0x01, 0x0a, 0x0e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x54, 0x61, 0x67, 0x41, 0x6c, 0x69, 0x61,
+	0x73, 0x12, 0x0f, 0x2e, 0x74, 0x61, 0x67, 0x2e, 0x54, 0x61, 0x67, 0x41, 0x6c, 0x69, 0x61, 0x73,
+	0x49, 0x64, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x79, 0x92, 0x41, 0x4c, 0x0a,
+	0x04, 0x54, 0x61, 0x67, 0x73, 0x12, 0x12, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x20, 0x61, 0x20,
+	0x74, 0x61, 0x67, 0x20, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x1a, 0x1e, 0x52, 0x65, 0x6d, 0x6f, 0x76,
+	0x65, 0x20, 0x61, 0x6e, 0x20, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x20, 0x6f, 0x66, 0x20, 0x61, 0x20,
+	0x74, 0x61, 0x67, 0x20, 0x62, 0x79, 0x20, 0x69, 0x64, 0x3a, 0x10, 0x61, 0x70, 0x70, 0x6c, 0x69,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x6a, 0x73, 0x6f, 0x6e, 0x82, 0xd3, 0xe4, 0x93, 0x02,
+	0x24, 0x2a, 0x22, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x74, 0x61, 0x67, 0x73, 0x2f,
+	0x7b, 0x74, 0x61, 0x67, 0x5f, 0x69, 0x64, 0x7d, 0x2f, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x65, 0x73,
+	0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x12, 0xb9, 0x01, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x54, 0x61, 0x67,
+	0x43, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x12, 0x0a, 0x2e, 0x74, 0x61, 0x67, 0x2e, 0x54,
+	0x61, 0x67, 0x49, 0x64, 0x1a, 0x14, 0x2e, 0x74, 0x61, 0x67, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x61,
+	0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x84, 0x01, 0x92, 0x41, 0x5f,
+	0x0a, 0x04, 0x54, 0x61, 0x67, 0x73, 0x12, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x20, 0x74, 0x61, 0x67,
+	0x20, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x1a, 0x32, 0x52, 0x65, 0x74, 0x72, 0x69,
+	0x65, 0x76, 0x65, 0x20, 0x74, 0x68, 0x65, 0x20, 0x74, 0x61, 0x67, 0x73, 0x20, 0x72, 0x69, 0x67,
+	0x68, 0x74, 0x20, 0x62, 0x65, 0x6c, 0x6f, 0x77, 0x20, 0x61, 0x20, 0x74, 0x61, 0x67, 0x20, 0x73,
+	0x6f, 0x72, 0x74, 0x65, 0x64, 0x20, 0x62, 0x79, 0x20, 0x6e, 0x61, 0x6d, 0x65, 0x3a, 0x10, 0x61,
+	0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x6a, 0x73, 0x6f, 0x6e, 0x82,
+	0xd3, 0xe4, 0x93, 0x02, 0x1c, 0x12, 0x1a, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x74,
+	0x61, 0x67, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x2f, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65,
+	0x6e, 0x12, 0xca, 0x01, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x54, 0x61, 0x67, 0x41, 0x6e, 0x63, 0x65,
+	0x73, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x0a, 0x2e, 0x74, 0x61, 0x67, 0x2e, 0x54, 0x61, 0x67, 0x49,
+	0x64, 0x1a, 0x14, 0x2e, 0x74, 0x61, 0x67, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x61, 0x67, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x94, 0x01, 0x92, 0x41, 0x6e, 0x0a, 0x04, 0x54,
+	0x61, 0x67, 0x73, 0x12, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x20, 0x74, 0x61, 0x67, 0x20, 0x61, 0x6e,
+	0x63, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x73, 0x1a, 0x40, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76,
+	0x65, 0x20, 0x74, 0x68, 0x65, 0x20, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x73, 0x20,
+	0x6f, 0x66, 0x20, 0x61, 0x20, 0x74, 0x61, 0x67, 0x20, 0x66, 0x72, 0x6f, 0x6d, 0x20, 0x69, 0x74,
+	0x73, 0x20, 0x72, 0x6f, 0x6f, 0x74, 0x20, 0x64, 0x6f, 0x77, 0x6e, 0x20, 0x74, 0x6f, 0x20, 0x69,
+	0x74, 0x73, 0x20, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x3a, 0x10, 0x61, 0x70, 0x70, 0x6c, 0x69,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x6a, 0x73, 0x6f, 0x6e, 0x82, 0xd3, 0xe4, 0x93, 0x02,
+	0x1d, 0x12, 0x1b, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x74, 0x61, 0x67, 0x73, 0x2f,
+	0x7b, 0x69, 0x64, 0x7d, 0x2f, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x73, 0x12, 0xc2,
+	0x01, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x54, 0x61, 0x67, 0x53, 0x75, 0x62, 0x74, 0x72, 0x65, 0x65,
+	0x12, 0x0a, 0x2e, 0x74, 0x61, 0x67, 0x2e, 0x54, 0x61, 0x67, 0x49, 0x64, 0x1a, 0x0f, 0x2e, 0x74,
+	0x61, 0x67, 0x2e, 0x54, 0x61, 0x67, 0x53, 0x75, 0x62, 0x74, 0x72, 0x65, 0x65, 0x22, 0x93, 0x01,
+	0x92, 0x41, 0x6f, 0x0a, 0x04, 0x54, 0x61, 0x67, 0x73, 0x12, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x20,
+	0x61, 0x20, 0x74, 0x61, 0x67, 0x20, 0x73, 0x75, 0x62, 0x74, 0x72, 0x65, 0x65, 0x1a, 0x41, 0x52,
+	0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x20, 0x65, 0x76, 0x65, 0x72, 0x79, 0x20, 0x64, 0x65,
+	0x73, 0x63, 0x65, 0x6e, 0x64, 0x61, 0x6e, 0x74, 0x20, 0x6f, 0x66, 0x20, 0x61, 0x20, 0x74, 0x61,
+	0x67, 0x20, 0x77, 0x69, 0x74, 0x68, 0x20, 0x69, 0x74, 0x73, 0x20, 0x64, 0x65, 0x70, 0x74, 0x68,
+	0x2c, 0x20, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x20, 0x62, 0x79, 0x20, 0x6c, 0x65, 0x76, 0x65, 0x6c,
 	0x3a, 0x10, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x6a, 0x73,
-	0x6f, 0x6e, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x13, 0x2a, 0x11, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76,
-	0x31, 0x2f, 0x74, 0x61, 0x67, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x42, 0x90, 0x02, 0x92, 0x41,
-	0x75, 0x12, 0x0e, 0x0a, 0x07, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x32, 0x03, 0x31, 0x2e,
-	0x30, 0x2a, 0x02, 0x01, 0x02, 0x32, 0x10, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1b, 0x12, 0x19, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76,
+	0x31, 0x2f, 0x74, 0x61, 0x67, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x2f, 0x73, 0x75, 0x62, 0x74,
+	0x72, 0x65, 0x65, 0x12, 0x83, 0x02, 0x0a, 0x07, 0x4d, 0x6f, 0x76, 0x65, 0x54, 0x61, 0x67, 0x12,
+	0x13, 0x2e, 0x74, 0x61, 0x67, 0x2e, 0x4d, 0x6f, 0x76, 0x65, 0x54, 0x61, 0x67, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x08, 0x2e, 0x74, 0x61, 0x67, 0x2e, 0x54, 0x61, 0x67, 0x22, 0xd8,
+	0x01, 0x92, 0x41, 0xb1, 0x01, 0x0a, 0x04, 0x54, 0x61, 0x67, 0x73, 0x12, 0x08, 0x4d, 0x6f, 0x76,
+	0x65, 0x20, 0x74, 0x61, 0x67, 0x1a, 0x7b, 0x4d, 0x6f, 0x76, 0x65, 0x20, 0x61, 0x20, 0x74, 0x61,
+	0x67, 0x20, 0x61, 0x6e, 0x64, 0x20, 0x69, 0x74, 0x73, 0x20, 0x73, 0x75, 0x62, 0x74, 0x72, 0x65,
+	0x65, 0x20, 0x75, 0x6e, 0x64, 0x65, 0x72, 0x20, 0x61, 0x6e, 0x6f, 0x74, 0x68, 0x65, 0x72, 0x20,
+	0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x2c, 0x20, 0x6f, 0x72, 0x20, 0x74, 0x6f, 0x20, 0x74, 0x68,
+	0x65, 0x20, 0x72, 0x6f, 0x6f, 0x74, 0x73, 0x2e, 0x20, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x73,
+	0x20, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x73, 0x20, 0x61, 0x6e, 0x64, 0x20, 0x73, 0x75, 0x62, 0x74,
+	0x72, 0x65, 0x65, 0x73, 0x20, 0x64, 0x65, 0x65, 0x70, 0x65, 0x72, 0x20, 0x74, 0x68, 0x61, 0x6e,
+	0x20, 0x74, 0x68, 0x65, 0x20, 0x6d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d, 0x20, 0x64, 0x65, 0x70,
+	0x74, 0x68, 0x32, 0x10, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f,
+	0x6a, 0x73, 0x6f, 0x6e, 0x3a, 0x10, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x2f, 0x6a, 0x73, 0x6f, 0x6e, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1d, 0x3a, 0x01, 0x2a, 0x1a,
+	0x18, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x74, 0x61, 0x67, 0x73, 0x2f, 0x7b, 0x69,
+	0x64, 0x7d, 0x2f, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x12, 0xab, 0x02, 0x0a, 0x09, 0x4d, 0x65,
+	0x72, 0x67, 0x65, 0x54, 0x61, 0x67, 0x73, 0x12, 0x15, 0x2e, 0x74, 0x61, 0x67, 0x2e, 0x4d, 0x65,
+	0x72, 0x67, 0x65, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16,
+	0x2e, 0x74, 0x61, 0x67, 0x2e, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xee, 0x01, 0x92, 0x41, 0xcd, 0x01, 0x0a, 0x04, 0x54,
+	0x61, 0x67, 0x73, 0x12, 0x0a, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x20, 0x74, 0x61, 0x67, 0x73, 0x1a,
+	0x94, 0x01, 0x53, 0x6f, 0x66, 0x74, 0x20, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x20, 0x74, 0x68,
+	0x65, 0x20, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x20, 0x74, 0x61, 0x67, 0x73, 0x2c, 0x20, 0x74,
+	0x75, 0x72, 0x6e, 0x20, 0x74, 0x68, 0x65, 0x69, 0x72, 0x20, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x20,
+	0x69, 0x6e, 0x74, 0x6f, 0x20, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x65, 0x73, 0x20, 0x6f, 0x66, 0x20,
+	0x74, 0x68, 0x65, 0x20, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x20, 0x61, 0x6e, 0x64, 0x20, 0x6d,
+	0x6f, 0x76, 0x65, 0x20, 0x74, 0x68, 0x65, 0x69, 0x72, 0x20, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x65,
+	0x73, 0x2c, 0x20, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x20, 0x61, 0x6e, 0x64, 0x20,
+	0x75, 0x73, 0x61, 0x67, 0x65, 0x20, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x20, 0x74, 0x6f, 0x20,
+	0x69, 0x74, 0x2c, 0x20, 0x69, 0x6e, 0x20, 0x6f, 0x6e, 0x65, 0x20, 0x74, 0x72, 0x61, 0x6e, 0x73,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x32, 0x10, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x2f, 0x6a, 0x73, 0x6f, 0x6e, 0x3a, 0x10, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x6a, 0x73, 0x6f, 0x6e, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x17,
+	0x3a, 0x01, 0x2a, 0x22, 0x12, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x74, 0x61, 0x67,
+	0x73, 0x3a, 0x6d, 0x65, 0x72, 0x67, 0x65, 0x12, 0x9b, 0x01, 0x0a, 0x09, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x54, 0x61, 0x67, 0x12, 0x15, 0x2e, 0x74, 0x61, 0x67, 0x2e, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x54, 0x61, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x08, 0x2e, 0x74,
+	0x61, 0x67, 0x2e, 0x54, 0x61, 0x67, 0x22, 0x6d, 0x92, 0x41, 0x4e, 0x0a, 0x04, 0x54, 0x61, 0x67,
+	0x73, 0x12, 0x0a, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x20, 0x74, 0x61, 0x67, 0x1a, 0x16, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x20, 0x61, 0x6e, 0x20, 0x65, 0x78, 0x69, 0x73, 0x74, 0x69, 0x6e,
+	0x67, 0x20, 0x74, 0x61, 0x67, 0x32, 0x10, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69,
 	0x6f, 0x6e, 0x2f, 0x6a, 0x73, 0x6f, 0x6e, 0x3a, 0x10, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x6a, 0x73, 0x6f, 0x6e, 0x52, 0x3b, 0x0a, 0x03, 0x34, 0x30, 0x34,
-	0x12, 0x34, 0x0a, 0x2a, 0x52, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x65, 0x64, 0x20, 0x77, 0x68, 0x65,
-	0x6e, 0x20, 0x74, 0x68, 0x65, 0x20, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x20, 0x64,
-	0x6f, 0x65, 0x73, 0x20, 0x6e, 0x6f, 0x74, 0x20, 0x65, 0x78, 0x69, 0x73, 0x74, 0x2e, 0x12, 0x06,
-	0x0a, 0x04, 0x9a, 0x02, 0x01, 0x07, 0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x2e, 0x73, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x42, 0x0c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x50, 0x72, 0x6f, 0x74,
-	0x6f, 0x50, 0x01, 0x5a, 0x3f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
-	0x70, 0x6f, 0x6e, 0x79, 0x6a, 0x61, 0x63, 0x6b, 0x61, 0x6c, 0x2f, 0x67, 0x6f, 0x2d, 0x6d, 0x69,
-	0x63, 0x72, 0x6f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2d, 0x62, 0x6f, 0x69, 0x6c, 0x65,
-	0x72, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0xa2, 0x02, 0x03, 0x53, 0x58, 0x58, 0xaa, 0x02, 0x07, 0x53, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0xca, 0x02, 0x07, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0xe2, 0x02,
-	0x13, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61,
-	0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x07, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x6a, 0x73, 0x6f, 0x6e, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x16, 0x3a,
+	0x01, 0x2a, 0x1a, 0x11, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x74, 0x61, 0x67, 0x73,
+	0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x12, 0x85, 0x01, 0x0a, 0x09, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x54, 0x61, 0x67, 0x12, 0x0a, 0x2e, 0x74, 0x61, 0x67, 0x2e, 0x54, 0x61, 0x67, 0x49, 0x64, 0x1a,
+	0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x54, 0x92, 0x41, 0x38, 0x0a, 0x04, 0x54, 0x61,
+	0x67, 0x73, 0x12, 0x0a, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x20, 0x74, 0x61, 0x67, 0x1a, 0x12,
+	0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x20, 0x61, 0x20, 0x74, 0x61, 0x67, 0x20, 0x62, 0x79, 0x20,
+	0x49, 0x44, 0x3a, 0x10, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f,
+	0x6a, 0x73, 0x6f, 0x6e, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x13, 0x2a, 0x11, 0x2f, 0x61, 0x70, 0x69,
+	0x2f, 0x76, 0x31, 0x2f, 0x74, 0x61, 0x67, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x12, 0xd1, 0x01,
+	0x0a, 0x0d, 0x47, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x12,
+	0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1a, 0x2e, 0x74, 0x61, 0x67, 0x2e, 0x47, 0x65,
+	0x74, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x8b, 0x01, 0x92, 0x41, 0x6e, 0x0a, 0x0a, 0x4e, 0x61, 0x6d, 0x65, 0x73,
+	0x70, 0x61, 0x63, 0x65, 0x73, 0x12, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x20, 0x6e, 0x61, 0x6d, 0x65,
+	0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x1a, 0x3d, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65,
+	0x20, 0x65, 0x76, 0x65, 0x72, 0x79, 0x20, 0x74, 0x61, 0x67, 0x20, 0x6e, 0x61, 0x6d, 0x65, 0x73,
+	0x70, 0x61, 0x63, 0x65, 0x20, 0x77, 0x69, 0x74, 0x68, 0x20, 0x69, 0x74, 0x73, 0x20, 0x73, 0x65,
+	0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x20, 0x73, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x20, 0x62, 0x79,
+	0x20, 0x6e, 0x61, 0x6d, 0x65, 0x3a, 0x10, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x2f, 0x6a, 0x73, 0x6f, 0x6e, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x14, 0x12, 0x12, 0x2f,
+	0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65,
+	0x73, 0x12, 0xa7, 0x01, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61,
+	0x63, 0x65, 0x12, 0x12, 0x2e, 0x74, 0x61, 0x67, 0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61,
+	0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x1a, 0x0e, 0x2e, 0x74, 0x61, 0x67, 0x2e, 0x4e, 0x61, 0x6d,
+	0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x22, 0x73, 0x92, 0x41, 0x4f, 0x0a, 0x0a, 0x4e, 0x61, 0x6d,
+	0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x12, 0x0d, 0x47, 0x65, 0x74, 0x20, 0x6e, 0x61, 0x6d,
+	0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x1a, 0x20, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65,
+	0x20, 0x61, 0x20, 0x74, 0x61, 0x67, 0x20, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65,
+	0x20, 0x62, 0x79, 0x20, 0x6e, 0x61, 0x6d, 0x65, 0x3a, 0x10, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x6a, 0x73, 0x6f, 0x6e, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1b,
+	0x12, 0x19, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70,
+	0x61, 0x63, 0x65, 0x73, 0x2f, 0x7b, 0x6e, 0x61, 0x6d, 0x65, 0x7d, 0x12, 0xcb, 0x01, 0x0a, 0x0f,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12,
+	0x19, 0x2e, 0x74, 0x61, 0x67, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70,
+	0x61, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0e, 0x2e, 0x74, 0x61, 0x67,
+	0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x22, 0x8c, 0x01, 0x92, 0x41, 0x6c,
+	0x0a, 0x0a, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x12, 0x10, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x20, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x1a, 0x28,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x20, 0x61, 0x20, 0x74, 0x61, 0x67, 0x20, 0x6e, 0x61, 0x6d,
+	0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x20, 0x77, 0x69, 0x74, 0x68, 0x20, 0x69, 0x74, 0x73, 0x20,
+	0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x32, 0x10, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x6a, 0x73, 0x6f, 0x6e, 0x3a, 0x10, 0x61, 0x70, 0x70, 0x6c,
+	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x6a, 0x73, 0x6f, 0x6e, 0x82, 0xd3, 0xe4, 0x93,
+	0x02, 0x17, 0x3a, 0x01, 0x2a, 0x22, 0x12, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x6e,
+	0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x12, 0xd0, 0x01, 0x0a, 0x0f, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x19, 0x2e,
+	0x74, 0x61, 0x67, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0e, 0x2e, 0x74, 0x61, 0x67, 0x2e, 0x4e,
+	0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x22, 0x91, 0x01, 0x92, 0x41, 0x6a, 0x0a, 0x0a,
+	0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x12, 0x10, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x20, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x1a, 0x26, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x20, 0x74, 0x68, 0x65, 0x20, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67,
+	0x73, 0x20, 0x6f, 0x66, 0x20, 0x61, 0x20, 0x74, 0x61, 0x67, 0x20, 0x6e, 0x61, 0x6d, 0x65, 0x73,
+	0x70, 0x61, 0x63, 0x65, 0x32, 0x10, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x2f, 0x6a, 0x73, 0x6f, 0x6e, 0x3a, 0x10, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x2f, 0x6a, 0x73, 0x6f, 0x6e, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1e, 0x3a, 0x01,
+	0x2a, 0x1a, 0x19, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x6e, 0x61, 0x6d, 0x65, 0x73,
+	0x70, 0x61, 0x63, 0x65, 0x73, 0x2f, 0x7b, 0x6e, 0x61, 0x6d, 0x65, 0x7d, 0x12, 0x80, 0x02, 0x0a,
+	0x0f, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65,
+	0x12, 0x12, 0x2e, 0x74, 0x61, 0x67, 0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65,
+	0x4e, 0x61, 0x6d, 0x65, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0xc0, 0x01, 0x92,
+	0x41, 0x9b, 0x01, 0x0a, 0x0a, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x12,
+	0x10, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x20, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63,
+	0x65, 0x1a, 0x69, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x20, 0x61, 0x20, 0x74, 0x61, 0x67, 0x20,
+	0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x20, 0x77, 0x69, 0x74, 0x68, 0x6f, 0x75,
+	0x74, 0x20, 0x74, 0x61, 0x67, 0x73, 0x2c, 0x20, 0x61, 0x6c, 0x6f, 0x6e, 0x67, 0x20, 0x77, 0x69,
+	0x74, 0x68, 0x20, 0x69, 0x74, 0x73, 0x20, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x20, 0x74,
+	0x61, 0x67, 0x73, 0x2e, 0x20, 0x54, 0x68, 0x65, 0x20, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74,
+	0x20, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x20, 0x63, 0x61, 0x6e, 0x6e, 0x6f,
+	0x74, 0x20, 0x62, 0x65, 0x20, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x3a, 0x10, 0x61, 0x70,
+	0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x6a, 0x73, 0x6f, 0x6e, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x1b, 0x2a, 0x19, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x6e, 0x61,
+	0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x2f, 0x7b, 0x6e, 0x61, 0x6d, 0x65, 0x7d, 0x42,
+	0x90, 0x02, 0x92, 0x41, 0x75, 0x12, 0x0e, 0x0a, 0x07, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x32, 0x03, 0x31, 0x2e, 0x30, 0x2a, 0x02, 0x01, 0x02, 0x32, 0x10, 0x61, 0x70, 0x70, 0x6c, 0x69,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x6a, 0x73, 0x6f, 0x6e, 0x3a, 0x10, 0x61, 0x70, 0x70,
+	0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x6a, 0x73, 0x6f, 0x6e, 0x52, 0x3b, 0x0a,
+	0x03, 0x34, 0x30, 0x34, 0x12, 0x34, 0x0a, 0x2a, 0x52, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x65, 0x64,
+	0x20, 0x77, 0x68, 0x65, 0x6e, 0x20, 0x74, 0x68, 0x65, 0x20, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x20, 0x64, 0x6f, 0x65, 0x73, 0x20, 0x6e, 0x6f, 0x74, 0x20, 0x65, 0x78, 0x69, 0x73,
+	0x74, 0x2e, 0x12, 0x06, 0x0a, 0x04, 0x9a, 0x02, 0x01, 0x07, 0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x2e,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x42, 0x0c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x3f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x63, 0x6f, 0x6d, 0x2f, 0x70, 0x6f, 0x6e, 0x79, 0x6a, 0x61, 0x63, 0x6b, 0x61, 0x6c, 0x2f, 0x67,
+	0x6f, 0x2d, 0x6d, 0x69, 0x63, 0x72, 0x6f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2d, 0x62,
+	0x6f, 0x69, 0x6c, 0x65, 0x72, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0xa2, 0x02, 0x03, 0x53, 0x58, 0x58, 0xaa, 0x02,
+	0x07, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0xca, 0x02, 0x07, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0xe2, 0x02, 0x13, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5c, 0x47, 0x50, 0x42,
+	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x07, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var file_service_service_proto_goTypes = []interface{}{
-	(*tag.GetTagsQuery)(nil),     // 0: tag.GetTagsQuery
-	(*tag.TagId)(nil),            // 1: tag.TagId
-	(*tag.SaveTagRequest)(nil),   // 2: tag.SaveTagRequest
-	(*tag.UpdateTagRequest)(nil), // 3: tag.UpdateTagRequest
-	(*tag.GetTagsResponse)(nil),  // 4: tag.GetTagsResponse
-	(*tag.Tag)(nil),              // 5: tag.Tag
-	(*emptypb.Empty)(nil),        // 6: google.protobuf.Empty
+	(*tag.GetTagsQuery)(nil),             // 0: tag.GetTagsQuery
+	(*tag.SearchTagsQuery)(nil),          // 1: tag.SearchTagsQuery
+	(*tag.AutocompleteTagsQuery)(nil),    // 2: tag.AutocompleteTagsQuery
+	(*tag.RecordTagUsageRequest)(nil),    // 3: tag.RecordTagUsageRequest
+	(*tag.TagId)(nil),                    // 4: tag.TagId
+	(*tag.TagSlug)(nil),                  // 5: tag.TagSlug
+	(*tag.SaveTagRequest)(nil),           // 6: tag.SaveTagRequest
+	(*tag.AddTagAliasRequest)(nil),       // 7: tag.AddTagAliasRequest
+	(*tag.TagAliasId)(nil),               // 8: tag.TagAliasId
+	(*tag.MoveTagRequest)(nil),           // 9: tag.MoveTagRequest
+	(*tag.MergeTagsRequest)(nil),         // 10: tag.MergeTagsRequest
+	(*tag.UpdateTagRequest)(nil),         // 11: tag.UpdateTagRequest
+	(*emptypb.Empty)(nil),                // 12: google.protobuf.Empty
+	(*tag.NamespaceName)(nil),            // 13: tag.NamespaceName
+	(*tag.SaveNamespaceRequest)(nil),     // 14: tag.SaveNamespaceRequest
+	(*tag.GetTagsResponse)(nil),          // 15: tag.GetTagsResponse
+	(*tag.SearchTagsResponse)(nil),       // 16: tag.SearchTagsResponse
+	(*tag.AutocompleteTagsResponse)(nil), // 17: tag.AutocompleteTagsResponse
+	(*tag.Tag)(nil),                      // 18: tag.Tag
+	(*tag.TagAliases)(nil),               // 19: tag.TagAliases
+	(*tag.TagAlias)(nil),                 // 20: tag.TagAlias
+	(*tag.TagSubtree)(nil),               // 21: tag.TagSubtree
+	(*tag.MergeTagsResponse)(nil),        // 22: tag.MergeTagsResponse
+	(*tag.GetNamespacesResponse)(nil),    // 23: tag.GetNamespacesResponse
+	(*tag.Namespace)(nil),                // 24: tag.Namespace
 }
 var file_service_service_proto_depIdxs = []int32{
-	0, // 0: service.Service.GetTags:input_type -> tag.GetTagsQuery
-	1, // 1: service.Service.GetTagById:input_type -> tag.TagId
-	2, // 2: service.Service.SaveTag:input_type -> tag.SaveTagRequest
-	3, // 3: service.Service.UpdateTag:input_type -> tag.UpdateTagRequest
-	1, // 4: service.Service.DeleteTag:input_type -> tag.TagId
-	4, // 5: service.Service.GetTags:output_type -> tag.GetTagsResponse
-	5, // 6: service.Service.GetTagById:output_type -> tag.Tag
-	5, // 7: service.Service.SaveTag:output_type -> tag.Tag
-	5, // 8: service.Service.UpdateTag:output_type -> tag.Tag
-	6, // 9: service.Service.DeleteTag:output_type -> google.protobuf.Empty
-	5, // [5:10] is the sub-list for method output_type
-	0, // [0:5] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	0,  // 0: service.Service.GetTags:input_type -> tag.GetTagsQuery
+	1,  // 1: service.Service.SearchTags:input_type -> tag.SearchTagsQuery
+	2,  // 2: service.Service.AutocompleteTags:input_type -> tag.AutocompleteTagsQuery
+	3,  // 3: service.Service.RecordTagUsage:input_type -> tag.RecordTagUsageRequest
+	4,  // 4: service.Service.GetTagById:input_type -> tag.TagId
+	5,  // 5: service.Service.GetTagBySlug:input_type -> tag.TagSlug
+	6,  // 6: service.Service.SaveTag:input_type -> tag.SaveTagRequest
+	4,  // 7: service.Service.GetTagAliases:input_type -> tag.TagId
+	7,  // 8: service.Service.AddTagAlias:input_type -> tag.AddTagAliasRequest
+	8,  // 9: service.Service.RemoveTagAlias:input_type -> tag.TagAliasId
+	4,  // 10: service.Service.GetTagChildren:input_type -> tag.TagId
+	4,  // 11: service.Service.GetTagAncestors:input_type -> tag.TagId
+	4,  // 12: service.Service.GetTagSubtree:input_type -> tag.TagId
+	9,  // 13: service.Service.MoveTag:input_type -> tag.MoveTagRequest
+	10, // 14: service.Service.MergeTags:input_type -> tag.MergeTagsRequest
+	11, // 15: service.Service.UpdateTag:input_type -> tag.UpdateTagRequest
+	4,  // 16: service.Service.DeleteTag:input_type -> tag.TagId
+	12, // 17: service.Service.GetNamespaces:input_type -> google.protobuf.Empty
+	13, // 18: service.Service.GetNamespace:input_type -> tag.NamespaceName
+	14, // 19: service.Service.CreateNamespace:input_type -> tag.SaveNamespaceRequest
+	14, // 20: service.Service.UpdateNamespace:input_type -> tag.SaveNamespaceRequest
+	13, // 21: service.Service.DeleteNamespace:input_type -> tag.NamespaceName
+	15, // 22: service.Service.GetTags:output_type -> tag.GetTagsResponse
+	16, // 23: service.Service.SearchTags:output_type -> tag.SearchTagsResponse
+	17, // 24: service.Service.AutocompleteTags:output_type -> tag.AutocompleteTagsResponse
+	18, // 25: service.Service.RecordTagUsage:output_type -> tag.Tag
+	18, // 26: service.Service.GetTagById:output_type -> tag.Tag
+	18, // 27: service.Service.GetTagBySlug:output_type -> tag.Tag
+	18, // 28: service.Service.SaveTag:output_type -> tag.Tag
+	19, // 29: service.Service.GetTagAliases:output_type -> tag.TagAliases
+	20, // 30: service.Service.AddTagAlias:output_type -> tag.TagAlias
+	12, // 31: service.Service.RemoveTagAlias:output_type -> google.protobuf.Empty
+	15, // 32: service.Service.GetTagChildren:output_type -> tag.GetTagsResponse
+	15, // 33: service.Service.GetTagAncestors:output_type -> tag.GetTagsResponse
+	21, // 34: service.Service.GetTagSubtree:output_type -> tag.TagSubtree
+	18, // 35: service.Service.MoveTag:output_type -> tag.Tag
+	22, // 36: service.Service.MergeTags:output_type -> tag.MergeTagsResponse
+	18, // 37: service.Service.UpdateTag:output_type -> tag.Tag
+	12, // 38: service.Service.DeleteTag:output_type -> google.protobuf.Empty
+	23, // 39: service.Service.GetNamespaces:output_type -> tag.GetNamespacesResponse
+	24, // 40: service.Service.GetNamespace:output_type -> tag.Namespace
+	24, // 41: service.Service.CreateNamespace:output_type -> tag.Namespace
+	24, // 42: service.Service.UpdateNamespace:output_type -> tag.Namespace
+	12, // 43: service.Service.DeleteNamespace:output_type -> google.protobuf.Empty
+	22, // [22:44] is the sub-list for method output_type
+	0,  // [0:22] is the sub-list for method input_type
+	0,  // [0:0] is the sub-list for extension type_name
+	0,  // [0:0] is the sub-list for extension extendee
+	0,  // [0:0] is the sub-list for field type_name
 }
 
 func init() { file_service_service_proto_init() }
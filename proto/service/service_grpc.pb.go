@@ -21,11 +21,28 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Service_GetTags_FullMethodName    = "/service.Service/GetTags"
-	Service_GetTagById_FullMethodName = "/service.Service/GetTagById"
-	Service_SaveTag_FullMethodName    = "/service.Service/SaveTag"
-	Service_UpdateTag_FullMethodName  = "/service.Service/UpdateTag"
-	Service_DeleteTag_FullMethodName  = "/service.Service/DeleteTag"
+	Service_GetTags_FullMethodName          = "/service.Service/GetTags"
+	Service_SearchTags_FullMethodName       = "/service.Service/SearchTags"
+	Service_AutocompleteTags_FullMethodName = "/service.Service/AutocompleteTags"
+	Service_RecordTagUsage_FullMethodName   = "/service.Service/RecordTagUsage"
+	Service_GetTagById_FullMethodName       = "/service.Service/GetTagById"
+	Service_GetTagBySlug_FullMethodName     = "/service.Service/GetTagBySlug"
+	Service_SaveTag_FullMethodName          = "/service.Service/SaveTag"
+	Service_GetTagAliases_FullMethodName    = "/service.Service/GetTagAliases"
+	Service_AddTagAlias_FullMethodName      = "/service.Service/AddTagAlias"
+	Service_RemoveTagAlias_FullMethodName   = "/service.Service/RemoveTagAlias"
+	Service_GetTagChildren_FullMethodName   = "/service.Service/GetTagChildren"
+	Service_GetTagAncestors_FullMethodName  = "/service.Service/GetTagAncestors"
+	Service_GetTagSubtree_FullMethodName    = "/service.Service/GetTagSubtree"
+	Service_MoveTag_FullMethodName          = "/service.Service/MoveTag"
+	Service_MergeTags_FullMethodName        = "/service.Service/MergeTags"
+	Service_UpdateTag_FullMethodName        = "/service.Service/UpdateTag"
+	Service_DeleteTag_FullMethodName        = "/service.Service/DeleteTag"
+	Service_GetNamespaces_FullMethodName    = "/service.Service/GetNamespaces"
+	Service_GetNamespace_FullMethodName     = "/service.Service/GetNamespace"
+	Service_CreateNamespace_FullMethodName  = "/service.Service/CreateNamespace"
+	Service_UpdateNamespace_FullMethodName  = "/service.Service/UpdateNamespace"
+	Service_DeleteNamespace_FullMethodName  = "/service.Service/DeleteNamespace"
 )
 
 // ServiceClient is the client API for Service service.
@@ -34,14 +51,48 @@ const (
 type ServiceClient interface {
 	// obtains tags by name
 	GetTags(ctx context.Context, in *tag.GetTagsQuery, opts ...grpc.CallOption) (*tag.GetTagsResponse, error)
+	// searches tags by name, ranking exact, prefix and fuzzy matches
+	SearchTags(ctx context.Context, in *tag.SearchTagsQuery, opts ...grpc.CallOption) (*tag.SearchTagsResponse, error)
+	// completes tag names from memory, the most used first
+	AutocompleteTags(ctx context.Context, in *tag.AutocompleteTagsQuery, opts ...grpc.CallOption) (*tag.AutocompleteTagsResponse, error)
+	// counts the items tagged with a tag
+	RecordTagUsage(ctx context.Context, in *tag.RecordTagUsageRequest, opts ...grpc.CallOption) (*tag.Tag, error)
 	// obtains tag by id
 	GetTagById(ctx context.Context, in *tag.TagId, opts ...grpc.CallOption) (*tag.Tag, error)
+	// obtains tag by slug
+	GetTagBySlug(ctx context.Context, in *tag.TagSlug, opts ...grpc.CallOption) (*tag.Tag, error)
 	// save tag
 	SaveTag(ctx context.Context, in *tag.SaveTagRequest, opts ...grpc.CallOption) (*tag.Tag, error)
+	// lists the aliases of a tag
+	GetTagAliases(ctx context.Context, in *tag.TagId, opts ...grpc.CallOption) (*tag.TagAliases, error)
+	// adds an alias to a tag
+	AddTagAlias(ctx context.Context, in *tag.AddTagAliasRequest, opts ...grpc.CallOption) (*tag.TagAlias, error)
+	// removes an alias of a tag
+	RemoveTagAlias(ctx context.Context, in *tag.TagAliasId, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// lists the children of a tag
+	GetTagChildren(ctx context.Context, in *tag.TagId, opts ...grpc.CallOption) (*tag.GetTagsResponse, error)
+	// lists the ancestors of a tag
+	GetTagAncestors(ctx context.Context, in *tag.TagId, opts ...grpc.CallOption) (*tag.GetTagsResponse, error)
+	// lists the descendants of a tag
+	GetTagSubtree(ctx context.Context, in *tag.TagId, opts ...grpc.CallOption) (*tag.TagSubtree, error)
+	// moves a tag in the hierarchy
+	MoveTag(ctx context.Context, in *tag.MoveTagRequest, opts ...grpc.CallOption) (*tag.Tag, error)
+	// merges duplicate tags into a target
+	MergeTags(ctx context.Context, in *tag.MergeTagsRequest, opts ...grpc.CallOption) (*tag.MergeTagsResponse, error)
 	// update tag
 	UpdateTag(ctx context.Context, in *tag.UpdateTagRequest, opts ...grpc.CallOption) (*tag.Tag, error)
 	// deletes a tag
 	DeleteTag(ctx context.Context, in *tag.TagId, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// lists the namespaces
+	GetNamespaces(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*tag.GetNamespacesResponse, error)
+	// gets a namespace
+	GetNamespace(ctx context.Context, in *tag.NamespaceName, opts ...grpc.CallOption) (*tag.Namespace, error)
+	// creates a namespace
+	CreateNamespace(ctx context.Context, in *tag.SaveNamespaceRequest, opts ...grpc.CallOption) (*tag.Namespace, error)
+	// updates the settings of a namespace
+	UpdateNamespace(ctx context.Context, in *tag.SaveNamespaceRequest, opts ...grpc.CallOption) (*tag.Namespace, error)
+	// deletes a namespace
+	DeleteNamespace(ctx context.Context, in *tag.NamespaceName, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
 type serviceClient struct {
@@ -61,6 +112,33 @@ func (c *serviceClient) GetTags(ctx context.Context, in *tag.GetTagsQuery, opts
 	return out, nil
 }
 
+func (c *serviceClient) SearchTags(ctx context.Context, in *tag.SearchTagsQuery, opts ...grpc.CallOption) (*tag.SearchTagsResponse, error) {
+	out := new(tag.SearchTagsResponse)
+	err := c.cc.Invoke(ctx, Service_SearchTags_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) AutocompleteTags(ctx context.Context, in *tag.AutocompleteTagsQuery, opts ...grpc.CallOption) (*tag.AutocompleteTagsResponse, error) {
+	out := new(tag.AutocompleteTagsResponse)
+	err := c.cc.Invoke(ctx, Service_AutocompleteTags_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) RecordTagUsage(ctx context.Context, in *tag.RecordTagUsageRequest, opts ...grpc.CallOption) (*tag.Tag, error) {
+	out := new(tag.Tag)
+	err := c.cc.Invoke(ctx, Service_RecordTagUsage_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *serviceClient) GetTagById(ctx context.Context, in *tag.TagId, opts ...grpc.CallOption) (*tag.Tag, error) {
 	out := new(tag.Tag)
 	err := c.cc.Invoke(ctx, Service_GetTagById_FullMethodName, in, out, opts...)
@@ -70,6 +148,15 @@ func (c *serviceClient) GetTagById(ctx context.Context, in *tag.TagId, opts ...g
 	return out, nil
 }
 
+func (c *serviceClient) GetTagBySlug(ctx context.Context, in *tag.TagSlug, opts ...grpc.CallOption) (*tag.Tag, error) {
+	out := new(tag.Tag)
+	err := c.cc.Invoke(ctx, Service_GetTagBySlug_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *serviceClient) SaveTag(ctx context.Context, in *tag.SaveTagRequest, opts ...grpc.CallOption) (*tag.Tag, error) {
 	out := new(tag.Tag)
 	err := c.cc.Invoke(ctx, Service_SaveTag_FullMethodName, in, out, opts...)
@@ -79,6 +166,78 @@ func (c *serviceClient) SaveTag(ctx context.Context, in *tag.SaveTagRequest, opt
 	return out, nil
 }
 
+func (c *serviceClient) GetTagAliases(ctx context.Context, in *tag.TagId, opts ...grpc.CallOption) (*tag.TagAliases, error) {
+	out := new(tag.TagAliases)
+	err := c.cc.Invoke(ctx, Service_GetTagAliases_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) AddTagAlias(ctx context.Context, in *tag.AddTagAliasRequest, opts ...grpc.CallOption) (*tag.TagAlias, error) {
+	out := new(tag.TagAlias)
+	err := c.cc.Invoke(ctx, Service_AddTagAlias_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) RemoveTagAlias(ctx context.Context, in *tag.TagAliasId, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Service_RemoveTagAlias_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) GetTagChildren(ctx context.Context, in *tag.TagId, opts ...grpc.CallOption) (*tag.GetTagsResponse, error) {
+	out := new(tag.GetTagsResponse)
+	err := c.cc.Invoke(ctx, Service_GetTagChildren_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) GetTagAncestors(ctx context.Context, in *tag.TagId, opts ...grpc.CallOption) (*tag.GetTagsResponse, error) {
+	out := new(tag.GetTagsResponse)
+	err := c.cc.Invoke(ctx, Service_GetTagAncestors_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) GetTagSubtree(ctx context.Context, in *tag.TagId, opts ...grpc.CallOption) (*tag.TagSubtree, error) {
+	out := new(tag.TagSubtree)
+	err := c.cc.Invoke(ctx, Service_GetTagSubtree_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) MoveTag(ctx context.Context, in *tag.MoveTagRequest, opts ...grpc.CallOption) (*tag.Tag, error) {
+	out := new(tag.Tag)
+	err := c.cc.Invoke(ctx, Service_MoveTag_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) MergeTags(ctx context.Context, in *tag.MergeTagsRequest, opts ...grpc.CallOption) (*tag.MergeTagsResponse, error) {
+	out := new(tag.MergeTagsResponse)
+	err := c.cc.Invoke(ctx, Service_MergeTags_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *serviceClient) UpdateTag(ctx context.Context, in *tag.UpdateTagRequest, opts ...grpc.CallOption) (*tag.Tag, error) {
 	out := new(tag.Tag)
 	err := c.cc.Invoke(ctx, Service_UpdateTag_FullMethodName, in, out, opts...)
@@ -97,20 +256,99 @@ func (c *serviceClient) DeleteTag(ctx context.Context, in *tag.TagId, opts ...gr
 	return out, nil
 }
 
+func (c *serviceClient) GetNamespaces(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*tag.GetNamespacesResponse, error) {
+	out := new(tag.GetNamespacesResponse)
+	err := c.cc.Invoke(ctx, Service_GetNamespaces_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) GetNamespace(ctx context.Context, in *tag.NamespaceName, opts ...grpc.CallOption) (*tag.Namespace, error) {
+	out := new(tag.Namespace)
+	err := c.cc.Invoke(ctx, Service_GetNamespace_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) CreateNamespace(ctx context.Context, in *tag.SaveNamespaceRequest, opts ...grpc.CallOption) (*tag.Namespace, error) {
+	out := new(tag.Namespace)
+	err := c.cc.Invoke(ctx, Service_CreateNamespace_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) UpdateNamespace(ctx context.Context, in *tag.SaveNamespaceRequest, opts ...grpc.CallOption) (*tag.Namespace, error) {
+	out := new(tag.Namespace)
+	err := c.cc.Invoke(ctx, Service_UpdateNamespace_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) DeleteNamespace(ctx context.Context, in *tag.NamespaceName, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Service_DeleteNamespace_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ServiceServer is the server API for Service service.
 // All implementations must embed UnimplementedServiceServer
 // for forward compatibility
 type ServiceServer interface {
 	// obtains tags by name
 	GetTags(context.Context, *tag.GetTagsQuery) (*tag.GetTagsResponse, error)
+	// searches tags by name, ranking exact, prefix and fuzzy matches
+	SearchTags(context.Context, *tag.SearchTagsQuery) (*tag.SearchTagsResponse, error)
+	// completes tag names from memory, the most used first
+	AutocompleteTags(context.Context, *tag.AutocompleteTagsQuery) (*tag.AutocompleteTagsResponse, error)
+	// counts the items tagged with a tag
+	RecordTagUsage(context.Context, *tag.RecordTagUsageRequest) (*tag.Tag, error)
 	// obtains tag by id
 	GetTagById(context.Context, *tag.TagId) (*tag.Tag, error)
+	// obtains tag by slug
+	GetTagBySlug(context.Context, *tag.TagSlug) (*tag.Tag, error)
 	// save tag
 	SaveTag(context.Context, *tag.SaveTagRequest) (*tag.Tag, error)
+	// lists the aliases of a tag
+	GetTagAliases(context.Context, *tag.TagId) (*tag.TagAliases, error)
+	// adds an alias to a tag
+	AddTagAlias(context.Context, *tag.AddTagAliasRequest) (*tag.TagAlias, error)
+	// removes an alias of a tag
+	RemoveTagAlias(context.Context, *tag.TagAliasId) (*emptypb.Empty, error)
+	// lists the children of a tag
+	GetTagChildren(context.Context, *tag.TagId) (*tag.GetTagsResponse, error)
+	// lists the ancestors of a tag
+	GetTagAncestors(context.Context, *tag.TagId) (*tag.GetTagsResponse, error)
+	// lists the descendants of a tag
+	GetTagSubtree(context.Context, *tag.TagId) (*tag.TagSubtree, error)
+	// moves a tag in the hierarchy
+	MoveTag(context.Context, *tag.MoveTagRequest) (*tag.Tag, error)
+	// merges duplicate tags into a target
+	MergeTags(context.Context, *tag.MergeTagsRequest) (*tag.MergeTagsResponse, error)
 	// update tag
 	UpdateTag(context.Context, *tag.UpdateTagRequest) (*tag.Tag, error)
 	// deletes a tag
 	DeleteTag(context.Context, *tag.TagId) (*emptypb.Empty, error)
+	// lists the namespaces
+	GetNamespaces(context.Context, *emptypb.Empty) (*tag.GetNamespacesResponse, error)
+	// gets a namespace
+	GetNamespace(context.Context, *tag.NamespaceName) (*tag.Namespace, error)
+	// creates a namespace
+	CreateNamespace(context.Context, *tag.SaveNamespaceRequest) (*tag.Namespace, error)
+	// updates the settings of a namespace
+	UpdateNamespace(context.Context, *tag.SaveNamespaceRequest) (*tag.Namespace, error)
+	// deletes a namespace
+	DeleteNamespace(context.Context, *tag.NamespaceName) (*emptypb.Empty, error)
 	mustEmbedUnimplementedServiceServer()
 }
 
@@ -121,18 +359,69 @@ type UnimplementedServiceServer struct {
 func (UnimplementedServiceServer) GetTags(context.Context, *tag.GetTagsQuery) (*tag.GetTagsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTags not implemented")
 }
+func (UnimplementedServiceServer) SearchTags(context.Context, *tag.SearchTagsQuery) (*tag.SearchTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchTags not implemented")
+}
+func (UnimplementedServiceServer) AutocompleteTags(context.Context, *tag.AutocompleteTagsQuery) (*tag.AutocompleteTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AutocompleteTags not implemented")
+}
+func (UnimplementedServiceServer) RecordTagUsage(context.Context, *tag.RecordTagUsageRequest) (*tag.Tag, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordTagUsage not implemented")
+}
 func (UnimplementedServiceServer) GetTagById(context.Context, *tag.TagId) (*tag.Tag, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTagById not implemented")
 }
+func (UnimplementedServiceServer) GetTagBySlug(context.Context, *tag.TagSlug) (*tag.Tag, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTagBySlug not implemented")
+}
 func (UnimplementedServiceServer) SaveTag(context.Context, *tag.SaveTagRequest) (*tag.Tag, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SaveTag not implemented")
 }
+func (UnimplementedServiceServer) GetTagAliases(context.Context, *tag.TagId) (*tag.TagAliases, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTagAliases not implemented")
+}
+func (UnimplementedServiceServer) AddTagAlias(context.Context, *tag.AddTagAliasRequest) (*tag.TagAlias, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddTagAlias not implemented")
+}
+func (UnimplementedServiceServer) RemoveTagAlias(context.Context, *tag.TagAliasId) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveTagAlias not implemented")
+}
+func (UnimplementedServiceServer) GetTagChildren(context.Context, *tag.TagId) (*tag.GetTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTagChildren not implemented")
+}
+func (UnimplementedServiceServer) GetTagAncestors(context.Context, *tag.TagId) (*tag.GetTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTagAncestors not implemented")
+}
+func (UnimplementedServiceServer) GetTagSubtree(context.Context, *tag.TagId) (*tag.TagSubtree, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTagSubtree not implemented")
+}
+func (UnimplementedServiceServer) MoveTag(context.Context, *tag.MoveTagRequest) (*tag.Tag, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveTag not implemented")
+}
+func (UnimplementedServiceServer) MergeTags(context.Context, *tag.MergeTagsRequest) (*tag.MergeTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MergeTags not implemented")
+}
 func (UnimplementedServiceServer) UpdateTag(context.Context, *tag.UpdateTagRequest) (*tag.Tag, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateTag not implemented")
 }
 func (UnimplementedServiceServer) DeleteTag(context.Context, *tag.TagId) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteTag not implemented")
 }
+func (UnimplementedServiceServer) GetNamespaces(context.Context, *emptypb.Empty) (*tag.GetNamespacesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNamespaces not implemented")
+}
+func (UnimplementedServiceServer) GetNamespace(context.Context, *tag.NamespaceName) (*tag.Namespace, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNamespace not implemented")
+}
+func (UnimplementedServiceServer) CreateNamespace(context.Context, *tag.SaveNamespaceRequest) (*tag.Namespace, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateNamespace not implemented")
+}
+func (UnimplementedServiceServer) UpdateNamespace(context.Context, *tag.SaveNamespaceRequest) (*tag.Namespace, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateNamespace not implemented")
+}
+func (UnimplementedServiceServer) DeleteNamespace(context.Context, *tag.NamespaceName) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteNamespace not implemented")
+}
 func (UnimplementedServiceServer) mustEmbedUnimplementedServiceServer() {}
 
 // UnsafeServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -164,6 +453,60 @@ func _Service_GetTags_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Service_SearchTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(tag.SearchTagsQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).SearchTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Service_SearchTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).SearchTags(ctx, req.(*tag.SearchTagsQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_AutocompleteTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(tag.AutocompleteTagsQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).AutocompleteTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Service_AutocompleteTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).AutocompleteTags(ctx, req.(*tag.AutocompleteTagsQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_RecordTagUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(tag.RecordTagUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).RecordTagUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Service_RecordTagUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).RecordTagUsage(ctx, req.(*tag.RecordTagUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Service_GetTagById_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(tag.TagId)
 	if err := dec(in); err != nil {
@@ -182,6 +525,24 @@ func _Service_GetTagById_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Service_GetTagBySlug_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(tag.TagSlug)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).GetTagBySlug(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Service_GetTagBySlug_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).GetTagBySlug(ctx, req.(*tag.TagSlug))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Service_SaveTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(tag.SaveTagRequest)
 	if err := dec(in); err != nil {
@@ -200,6 +561,150 @@ func _Service_SaveTag_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Service_GetTagAliases_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(tag.TagId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).GetTagAliases(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Service_GetTagAliases_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).GetTagAliases(ctx, req.(*tag.TagId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_AddTagAlias_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(tag.AddTagAliasRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).AddTagAlias(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Service_AddTagAlias_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).AddTagAlias(ctx, req.(*tag.AddTagAliasRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_RemoveTagAlias_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(tag.TagAliasId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).RemoveTagAlias(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Service_RemoveTagAlias_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).RemoveTagAlias(ctx, req.(*tag.TagAliasId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_GetTagChildren_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(tag.TagId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).GetTagChildren(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Service_GetTagChildren_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).GetTagChildren(ctx, req.(*tag.TagId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_GetTagAncestors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(tag.TagId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).GetTagAncestors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Service_GetTagAncestors_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).GetTagAncestors(ctx, req.(*tag.TagId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_GetTagSubtree_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(tag.TagId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).GetTagSubtree(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Service_GetTagSubtree_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).GetTagSubtree(ctx, req.(*tag.TagId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_MoveTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(tag.MoveTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).MoveTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Service_MoveTag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).MoveTag(ctx, req.(*tag.MoveTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_MergeTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(tag.MergeTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).MergeTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Service_MergeTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).MergeTags(ctx, req.(*tag.MergeTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Service_UpdateTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(tag.UpdateTagRequest)
 	if err := dec(in); err != nil {
@@ -236,6 +741,96 @@ func _Service_DeleteTag_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Service_GetNamespaces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).GetNamespaces(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Service_GetNamespaces_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).GetNamespaces(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_GetNamespace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(tag.NamespaceName)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).GetNamespace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Service_GetNamespace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).GetNamespace(ctx, req.(*tag.NamespaceName))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_CreateNamespace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(tag.SaveNamespaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).CreateNamespace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Service_CreateNamespace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).CreateNamespace(ctx, req.(*tag.SaveNamespaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_UpdateNamespace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(tag.SaveNamespaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).UpdateNamespace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Service_UpdateNamespace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).UpdateNamespace(ctx, req.(*tag.SaveNamespaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_DeleteNamespace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(tag.NamespaceName)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).DeleteNamespace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Service_DeleteNamespace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).DeleteNamespace(ctx, req.(*tag.NamespaceName))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Service_ServiceDesc is the grpc.ServiceDesc for Service service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -247,14 +842,62 @@ var Service_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetTags",
 			Handler:    _Service_GetTags_Handler,
 		},
+		{
+			MethodName: "SearchTags",
+			Handler:    _Service_SearchTags_Handler,
+		},
+		{
+			MethodName: "AutocompleteTags",
+			Handler:    _Service_AutocompleteTags_Handler,
+		},
+		{
+			MethodName: "RecordTagUsage",
+			Handler:    _Service_RecordTagUsage_Handler,
+		},
 		{
 			MethodName: "GetTagById",
 			Handler:    _Service_GetTagById_Handler,
 		},
+		{
+			MethodName: "GetTagBySlug",
+			Handler:    _Service_GetTagBySlug_Handler,
+		},
 		{
 			MethodName: "SaveTag",
 			Handler:    _Service_SaveTag_Handler,
 		},
+		{
+			MethodName: "GetTagAliases",
+			Handler:    _Service_GetTagAliases_Handler,
+		},
+		{
+			MethodName: "AddTagAlias",
+			Handler:    _Service_AddTagAlias_Handler,
+		},
+		{
+			MethodName: "RemoveTagAlias",
+			Handler:    _Service_RemoveTagAlias_Handler,
+		},
+		{
+			MethodName: "GetTagChildren",
+			Handler:    _Service_GetTagChildren_Handler,
+		},
+		{
+			MethodName: "GetTagAncestors",
+			Handler:    _Service_GetTagAncestors_Handler,
+		},
+		{
+			MethodName: "GetTagSubtree",
+			Handler:    _Service_GetTagSubtree_Handler,
+		},
+		{
+			MethodName: "MoveTag",
+			Handler:    _Service_MoveTag_Handler,
+		},
+		{
+			MethodName: "MergeTags",
+			Handler:    _Service_MergeTags_Handler,
+		},
 		{
 			MethodName: "UpdateTag",
 			Handler:    _Service_UpdateTag_Handler,
@@ -263,6 +906,26 @@ var Service_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteTag",
 			Handler:    _Service_DeleteTag_Handler,
 		},
+		{
+			MethodName: "GetNamespaces",
+			Handler:    _Service_GetNamespaces_Handler,
+		},
+		{
+			MethodName: "GetNamespace",
+			Handler:    _Service_GetNamespace_Handler,
+		},
+		{
+			MethodName: "CreateNamespace",
+			Handler:    _Service_CreateNamespace_Handler,
+		},
+		{
+			MethodName: "UpdateNamespace",
+			Handler:    _Service_UpdateNamespace_Handler,
+		},
+		{
+			MethodName: "DeleteNamespace",
+			Handler:    _Service_DeleteNamespace_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "service/service.proto",
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/adapters/database"
+	"github.com/ponyjackal/go-microservice-boilerplate/internal/adapters/database/seeds"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/config"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/lifecycle"
+	"github.com/ponyjackal/go-microservice-boilerplate/pkg/logger"
+)
+
+// runSeed runs the seed subcommand and returns the process exit code. The
+// configuration is read from --config, CONFIG_FILE and the environment.
+func runSeed(args []string) int {
+	fs := flag.NewFlagSet("seed", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: seed [flags] [fixture...]\n\nenvironments: %s\n\nflags:\n", strings.Join(seeds.Environments(), ", "))
+		fs.PrintDefaults()
+	}
+	env := fs.String("env", "dev", "environment of the fixtures")
+	reset := fs.Bool("reset", false, "delete every record of the fixture models first")
+	configFile := fs.String("config", "", "path to a YAML or TOML configuration file (env "+config.ConfigFileEnv+")")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return lifecycle.ExitOK
+		}
+		return lifecycle.ExitServerError
+	}
+
+	var configArgs []string
+	if *configFile != "" {
+		configArgs = []string{"--config", *configFile}
+	}
+	if code, ok := setup(configArgs); !ok {
+		return code
+	}
+	if *reset && config.Get().App.Env == "production" {
+		logger.Errorf("seed --reset is refused in production")
+		return lifecycle.ExitServerError
+	}
+
+	ctx := context.Background()
+	db, err := database.New(ctx, config.Get().Database, database.Options{})
+	if err != nil {
+		logger.Errorf("database New() error: %s", err)
+		return lifecycle.ExitServerError
+	}
+	defer db.Close()
+
+	if err := seeds.Seed(ctx, db, *env, seeds.Options{Names: fs.Args(), Reset: *reset}); err != nil {
+		logger.Errorf("seeds Seed() error: %s", err)
+		return lifecycle.ExitServerError
+	}
+	return lifecycle.ExitOK
+}